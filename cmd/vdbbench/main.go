@@ -0,0 +1,376 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Command vdbbench is a load-testing and benchmark tool for Tencent Cloud VectorDB.
+//
+// It sends a weighted mix of upsert, search, hybrid search and filtered query requests through
+// the HTTP [tcvectordb.Client], the gRPC [tcvectordb.RpcClient] or the [tcvectordb.RpcClientPool],
+// either with a fixed number of concurrent workers or at a target QPS, and reports the throughput,
+// the latency percentiles and histograms, and the errors by code of each operation.
+//
+// Examples:
+//
+//	# search at 200 QPS for one minute over gRPC, creating the collection first
+//	vdbbench -url http://10.0.0.1:80 -key $KEY -protocol grpc -setup -mix upsert=1,search=9 -qps 200 -duration 1m
+//
+//	# closed-loop with 32 workers against the in-process stand-in server
+//	vdbbench -stub -stub-latency 2ms -concurrency 32 -requests 100000 -output json
+//
+// The documents of a file-backed workload are read from -data, with one JSON object per line
+// such as {"id": "0001", "vector": [0.1, 0.2], "fields": {"category": "c1"}}. The vectors are
+// upserted in turn and sampled as the query vectors.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+type benchClient interface {
+	tcvectordb.FlatDocumentInterface
+	Close()
+}
+
+type options struct {
+	url         string
+	username    string
+	key         string
+	protocol    string
+	poolSize    int
+	timeout     time.Duration
+	database    string
+	collection  string
+	setup       bool
+	cleanup     bool
+	dimension   int
+	data        string
+	mix         string
+	batchSize   int
+	topK        int
+	ef          uint32
+	filter      string
+	concurrency int
+	qps         float64
+	duration    time.Duration
+	requests    int64
+	seed        int64
+	output      string
+	stub        bool
+	stubLatency time.Duration
+	stubJitter  time.Duration
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.url, "url", os.Getenv("VDB_URL"), "the url of the instance, defaults to $VDB_URL")
+	flag.StringVar(&opts.username, "username", "root", "the username")
+	flag.StringVar(&opts.key, "key", os.Getenv("VDB_KEY"), "the api key, defaults to $VDB_KEY")
+	flag.StringVar(&opts.protocol, "protocol", "http", "the client to use: http, grpc or pool")
+	flag.IntVar(&opts.poolSize, "pool-size", 0, "the number of the connections of the grpc pool")
+	flag.DurationVar(&opts.timeout, "timeout", 10*time.Second, "the timeout of each request")
+	flag.StringVar(&opts.database, "db", "vdbbench", "the database name")
+	flag.StringVar(&opts.collection, "coll", "vdbbench", "the collection name")
+	flag.BoolVar(&opts.setup, "setup", false, "create the database and the collection if they don't exist")
+	flag.BoolVar(&opts.cleanup, "cleanup", false, "drop the collection after the run")
+	flag.IntVar(&opts.dimension, "dim", 128, "the dimension of the synthetic vectors")
+	flag.StringVar(&opts.data, "data", "", "the JSON lines file of the documents, instead of synthetic ones")
+	flag.StringVar(&opts.mix, "mix", "search=1", "the weights of the operations: upsert, search, hybrid and query")
+	flag.IntVar(&opts.batchSize, "batch", 100, "the number of the documents of each upsert")
+	flag.IntVar(&opts.topK, "topk", 10, "the limit of the searches and queries")
+	var ef uint
+	flag.UintVar(&ef, "ef", 0, "the ef of the searches, 0 for the server default")
+	flag.StringVar(&opts.filter, "filter", "", `the filter of the searches and queries; "{category}" is replaced by a random category, such as 'category="{category}"'`)
+	flag.IntVar(&opts.concurrency, "concurrency", 8, "the number of the concurrent workers")
+	flag.Float64Var(&opts.qps, "qps", 0, "the target requests per second, 0 to send as fast as the workers can")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "how long to run")
+	flag.Int64Var(&opts.requests, "requests", 0, "stop after the number of requests, 0 for no limit")
+	flag.Int64Var(&opts.seed, "seed", 0, "the seed of the workload, 0 for a random seed")
+	flag.StringVar(&opts.output, "output", "text", "the report format: text or json")
+	flag.BoolVar(&opts.stub, "stub", false, "run against an in-process stand-in server instead of -url")
+	flag.DurationVar(&opts.stubLatency, "stub-latency", 0, "the latency of the stand-in server")
+	flag.DurationVar(&opts.stubJitter, "stub-jitter", 0, "the random extra latency of the stand-in server")
+	flag.Parse()
+	opts.ef = uint32(ef)
+
+	report, err := run(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch opts.output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		report.writeText(os.Stdout)
+	}
+}
+
+func run(opts options) (*Report, error) {
+	mix, err := parseMix(opts.mix)
+	if err != nil {
+		return nil, err
+	}
+	if opts.concurrency <= 0 {
+		return nil, fmt.Errorf("concurrency must be greater than 0")
+	}
+	if opts.stub {
+		if opts.protocol != "http" {
+			return nil, fmt.Errorf("the stand-in server only supports the http protocol")
+		}
+		stub := newStubServer(opts.stubLatency, opts.stubJitter)
+		defer stub.Close()
+		opts.url = stub.URL
+		if opts.key == "" {
+			opts.key = "stub"
+		}
+	}
+
+	cli, err := newClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	seed := opts.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	w := &workload{
+		cli:        cli,
+		database:   opts.database,
+		collection: opts.collection,
+		dimension:  opts.dimension,
+		batchSize:  opts.batchSize,
+		topK:       opts.topK,
+		ef:         opts.ef,
+		filter:     opts.filter,
+		sparse:     contains(mix, opHybrid),
+		mix:        mix,
+		rand:       rand.New(rand.NewSource(seed)),
+	}
+	if opts.data != "" {
+		if w.records, err = loadRecords(opts.data); err != nil {
+			return nil, err
+		}
+		w.dimension = len(w.records[0].Vector)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if opts.setup && !opts.stub {
+		if err := setupCollection(ctx, cli, opts.database, opts.collection, uint32(w.dimension), w.sparse); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := make(map[string]*opStats)
+	for _, op := range allOps {
+		stats[op] = newOpStats(op)
+	}
+	elapsed := drive(ctx, w, stats, opts)
+
+	report := &Report{
+		Protocol:    opts.protocol,
+		Url:         opts.url,
+		Database:    opts.database,
+		Collection:  opts.collection,
+		Concurrency: opts.concurrency,
+		TargetQPS:   opts.qps,
+		Duration:    elapsed.Seconds(),
+	}
+	for _, op := range allOps {
+		r := stats[op].report(elapsed)
+		if r.Requests == 0 {
+			continue
+		}
+		report.Requests += r.Requests
+		report.Ops = append(report.Ops, r)
+	}
+	if elapsed > 0 {
+		report.Throughput = float64(report.Requests) / elapsed.Seconds()
+	}
+
+	if opts.cleanup && !opts.stub {
+		if err := dropCollection(context.Background(), cli, opts.database, opts.collection); err != nil {
+			log.Printf("[Warning] drop collection %v failed. err: %v", opts.collection, err)
+		}
+	}
+	return report, nil
+}
+
+// drive runs the workers until the duration or the number of requests is reached, or ctx is done.
+// With a target QPS the requests are released by a ticker, and skipped if all the workers are busy.
+func drive(ctx context.Context, w *workload, stats map[string]*opStats, opts options) time.Duration {
+	runCtx, cancel := context.WithTimeout(ctx, opts.duration)
+	defer cancel()
+
+	var sent int64
+	next := func() bool {
+		if runCtx.Err() != nil {
+			return false
+		}
+		if opts.requests > 0 && atomic.AddInt64(&sent, 1) > opts.requests {
+			cancel()
+			return false
+		}
+		return true
+	}
+	do := func() {
+		op := w.nextOp()
+		begin := time.Now()
+		// The requests run with ctx rather than runCtx, so those in flight at the end are not canceled.
+		err := w.run(ctx, op)
+		stats[op].record(time.Since(begin), err)
+	}
+
+	var tokens chan struct{}
+	if opts.qps > 0 {
+		tokens = make(chan struct{}, opts.concurrency)
+		interval := time.Duration(float64(time.Second) / opts.qps)
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-runCtx.Done():
+					close(tokens)
+					return
+				case <-ticker.C:
+					select {
+					case tokens <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < opts.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if tokens == nil {
+				for next() {
+					do()
+				}
+				return
+			}
+			for range tokens {
+				if !next() {
+					return
+				}
+				do()
+			}
+		}()
+	}
+	wg.Wait()
+	return time.Since(start)
+}
+
+func newClient(opts options) (benchClient, error) {
+	option := &tcvectordb.ClientOption{
+		Timeout:            opts.timeout,
+		MaxIdleConnPerHost: opts.concurrency,
+		RpcPoolSize:        opts.poolSize,
+		ReadConsistency:    tcvectordb.EventualConsistency,
+	}
+	switch opts.protocol {
+	case "http":
+		return tcvectordb.NewClient(opts.url, opts.username, opts.key, option)
+	case "grpc":
+		return tcvectordb.NewRpcClient(opts.url, opts.username, opts.key, option)
+	case "pool":
+		return tcvectordb.NewRpcClientPool(opts.url, opts.username, opts.key, option)
+	}
+	return nil, fmt.Errorf("unknown protocol %v, expected http, grpc or pool", opts.protocol)
+}
+
+func setupCollection(ctx context.Context, cli benchClient, database, collection string, dimension uint32, sparse bool) error {
+	indexes := tcvectordb.Indexes{
+		VectorIndex: []tcvectordb.VectorIndex{{
+			FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.HNSW},
+			Dimension:   dimension,
+			MetricType:  tcvectordb.COSINE,
+			Params:      &tcvectordb.HNSWParam{M: 16, EfConstruction: 200},
+		}},
+		FilterIndex: []tcvectordb.FilterIndex{
+			{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY},
+			{FieldName: "category", FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER},
+		},
+	}
+	if sparse {
+		indexes.SparseVectorIndex = []tcvectordb.SparseVectorIndex{{
+			FieldName:  "sparse_vector",
+			FieldType:  tcvectordb.SparseVector,
+			IndexType:  tcvectordb.SPARSE_INVERTED,
+			MetricType: tcvectordb.IP,
+		}}
+	}
+	switch c := cli.(type) {
+	case tcvectordb.VdbClient:
+		if _, err := c.CreateDatabaseIfNotExists(ctx, database); err != nil {
+			return fmt.Errorf("create database %v failed. err: %v", database, err)
+		}
+		if _, err := c.CreateCollectionIfNotExists(ctx, database, collection, 1, 1, "vdbbench", indexes); err != nil {
+			return fmt.Errorf("create collection %v failed. err: %v", collection, err)
+		}
+	case interface {
+		CreateDatabaseIfNotExists(ctx context.Context, name string) (*tcvectordb.CreateDatabaseResult, error)
+		Database(name string) *tcvectordb.Database
+	}:
+		if _, err := c.CreateDatabaseIfNotExists(ctx, database); err != nil {
+			return fmt.Errorf("create database %v failed. err: %v", database, err)
+		}
+		if _, err := c.Database(database).CreateCollectionIfNotExists(ctx, collection, 1, 1, "vdbbench", indexes); err != nil {
+			return fmt.Errorf("create collection %v failed. err: %v", collection, err)
+		}
+	default:
+		return fmt.Errorf("client %T does not support creating collections", cli)
+	}
+	return nil
+}
+
+func dropCollection(ctx context.Context, cli benchClient, database, collection string) error {
+	switch c := cli.(type) {
+	case tcvectordb.VdbClient:
+		_, err := c.DropCollection(ctx, database, collection)
+		return err
+	case interface {
+		Database(name string) *tcvectordb.Database
+	}:
+		_, err := c.Database(database).DropCollection(ctx, collection)
+		return err
+	}
+	return fmt.Errorf("client %T does not support dropping collections", cli)
+}
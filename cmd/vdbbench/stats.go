@@ -0,0 +1,231 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// latencyBuckets are the upper bounds of the histogram buckets, doubling from 1ms.
+var latencyBuckets = func() []time.Duration {
+	var buckets []time.Duration
+	for d := time.Millisecond; d <= 16*time.Second; d *= 2 {
+		buckets = append(buckets, d)
+	}
+	return buckets
+}()
+
+var errorCodePattern = regexp.MustCompile(`(?:response code is|code:) (-?\d+)`)
+
+// opStats collects the results of an operation.
+type opStats struct {
+	mu        sync.Mutex
+	name      string
+	latencies []time.Duration
+	errors    map[string]int
+}
+
+func newOpStats(name string) *opStats {
+	return &opStats{name: name, errors: make(map[string]int)}
+}
+
+func (s *opStats) record(latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.errors[errorCode(err)]++
+		return
+	}
+	s.latencies = append(s.latencies, latency)
+}
+
+// errorCode classifies an error by the server code, the gRPC status code or the context error.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	if m := errorCodePattern.FindStringSubmatch(err.Error()); m != nil {
+		return m[1]
+	}
+	if s, ok := status.FromError(err); ok {
+		return "grpc:" + s.Code().String()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	}
+	return "other"
+}
+
+// OpReport holds the results of an operation.
+type OpReport struct {
+	Op            string         `json:"op"`
+	Requests      int            `json:"requests"`
+	Succeeded     int            `json:"succeeded"`
+	Failed        int            `json:"failed"`
+	Throughput    float64        `json:"throughput"`
+	LatencyMeanMs float64        `json:"latencyMeanMs"`
+	LatencyP50Ms  float64        `json:"latencyP50Ms"`
+	LatencyP90Ms  float64        `json:"latencyP90Ms"`
+	LatencyP99Ms  float64        `json:"latencyP99Ms"`
+	LatencyMaxMs  float64        `json:"latencyMaxMs"`
+	Histogram     []Bucket       `json:"histogram"`
+	Errors        map[string]int `json:"errors,omitempty"`
+}
+
+// Bucket is a bucket of the latency histogram, counting the latencies above the previous bucket and up to Le,
+// which is "+Inf" for the latencies above the largest bucket.
+type Bucket struct {
+	Le    string `json:"le"`
+	Count int    `json:"count"`
+}
+
+// Report holds the results of a benchmark run.
+type Report struct {
+	Protocol    string     `json:"protocol"`
+	Url         string     `json:"url"`
+	Database    string     `json:"database"`
+	Collection  string     `json:"collection"`
+	Concurrency int        `json:"concurrency"`
+	TargetQPS   float64    `json:"targetQps,omitempty"`
+	Duration    float64    `json:"durationSeconds"`
+	Requests    int        `json:"requests"`
+	Throughput  float64    `json:"throughput"`
+	Ops         []OpReport `json:"ops"`
+}
+
+func (s *opStats) report(elapsed time.Duration) OpReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := OpReport{Op: s.name, Succeeded: len(s.latencies), Errors: s.errors}
+	for _, n := range s.errors {
+		r.Failed += n
+	}
+	r.Requests = r.Succeeded + r.Failed
+	if elapsed > 0 {
+		r.Throughput = float64(r.Requests) / elapsed.Seconds()
+	}
+	if len(s.latencies) == 0 {
+		return r
+	}
+	sorted := make([]time.Duration, len(s.latencies))
+	copy(sorted, s.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	r.LatencyMeanMs = ms(total / time.Duration(len(sorted)))
+	r.LatencyP50Ms = ms(percentile(sorted, 0.50))
+	r.LatencyP90Ms = ms(percentile(sorted, 0.90))
+	r.LatencyP99Ms = ms(percentile(sorted, 0.99))
+	r.LatencyMaxMs = ms(sorted[len(sorted)-1])
+
+	i := 0
+	for _, le := range latencyBuckets {
+		count := 0
+		for i < len(sorted) && sorted[i] <= le {
+			count++
+			i++
+		}
+		r.Histogram = append(r.Histogram, Bucket{Le: le.String(), Count: count})
+	}
+	if i < len(sorted) {
+		r.Histogram = append(r.Histogram, Bucket{Le: "+Inf", Count: len(sorted) - i})
+	}
+	// Trailing empty buckets are dropped to keep the histogram short.
+	for len(r.Histogram) > 0 && r.Histogram[len(r.Histogram)-1].Count == 0 {
+		r.Histogram = r.Histogram[:len(r.Histogram)-1]
+	}
+	return r
+}
+
+// writeText writes the report as tables.
+func (r *Report) writeText(w io.Writer) {
+	fmt.Fprintf(w, "protocol: %v, url: %v, collection: %v.%v\n", r.Protocol, r.Url, r.Database, r.Collection)
+	if r.TargetQPS > 0 {
+		fmt.Fprintf(w, "concurrency: %v, target qps: %v\n", r.Concurrency, r.TargetQPS)
+	} else {
+		fmt.Fprintf(w, "concurrency: %v\n", r.Concurrency)
+	}
+	fmt.Fprintf(w, "duration: %.2fs, requests: %v, throughput: %.2f req/s\n\n", r.Duration, r.Requests, r.Throughput)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "op\trequests\tfailed\treq/s\tmean(ms)\tp50(ms)\tp90(ms)\tp99(ms)\tmax(ms)")
+	for _, op := range r.Ops {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\n", op.Op, op.Requests, op.Failed, op.Throughput,
+			op.LatencyMeanMs, op.LatencyP50Ms, op.LatencyP90Ms, op.LatencyP99Ms, op.LatencyMaxMs)
+	}
+	tw.Flush()
+
+	for _, op := range r.Ops {
+		if len(op.Histogram) != 0 {
+			fmt.Fprintf(w, "\n%v latency histogram:\n", op.Op)
+		}
+		for _, b := range op.Histogram {
+			bar := 0
+			if op.Succeeded > 0 {
+				bar = b.Count * 40 / op.Succeeded
+			}
+			fmt.Fprintf(w, "  <= %-8v %8v %v\n", b.Le, b.Count, strings.Repeat("#", bar))
+		}
+		if len(op.Errors) != 0 {
+			codes := make([]string, 0, len(op.Errors))
+			for code := range op.Errors {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			fmt.Fprintf(w, "\n%v errors by code:\n", op.Op)
+			for _, code := range codes {
+				fmt.Fprintf(w, "  %v: %v\n", code, op.Errors[code])
+			}
+		}
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
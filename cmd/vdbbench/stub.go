@@ -0,0 +1,110 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// stubServer is an in-process stand-in of the HTTP API, which answers the document requests of the
+// benchmark with generated results after an optional latency. It measures the overhead of the client
+// and the benchmark itself without a real instance.
+type stubServer struct {
+	*httptest.Server
+	latency time.Duration
+	jitter  time.Duration
+	mu      sync.Mutex
+	rand    *rand.Rand
+}
+
+type stubDocument struct {
+	Id    string  `json:"id"`
+	Score float32 `json:"score"`
+}
+
+func newStubServer(latency, jitter time.Duration) *stubServer {
+	s := &stubServer{latency: latency, jitter: jitter, rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *stubServer) handle(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Documents []json.RawMessage `json:"documents"`
+		Search    struct {
+			Vectors [][]float32 `json:"vectors"`
+			Limit   int         `json:"limit"`
+		} `json:"search"`
+		Query struct {
+			Limit int `json:"limit"`
+		} `json:"query"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	s.sleep()
+
+	res := map[string]interface{}{"code": 0, "msg": "operation success"}
+	switch r.URL.Path {
+	case "/document/upsert":
+		res["affectedCount"] = len(req.Documents)
+	case "/document/search", "/document/hybridSearch":
+		lists := len(req.Search.Vectors)
+		if lists == 0 {
+			lists = 1
+		}
+		documents := make([][]stubDocument, lists)
+		for i := range documents {
+			documents[i] = s.documents(req.Search.Limit)
+		}
+		res["documents"] = documents
+	case "/document/query":
+		res["documents"] = s.documents(req.Query.Limit)
+		res["count"] = req.Query.Limit
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *stubServer) sleep() {
+	d := s.latency
+	if s.jitter > 0 {
+		s.mu.Lock()
+		d += time.Duration(s.rand.Int63n(int64(s.jitter)))
+		s.mu.Unlock()
+	}
+	if d > 0 {
+		time.Sleep(d)
+	}
+}
+
+func (s *stubServer) documents(limit int) []stubDocument {
+	if limit <= 0 {
+		limit = 10
+	}
+	docs := make([]stubDocument, limit)
+	for i := range docs {
+		docs[i] = stubDocument{Id: fmt.Sprintf("stub-%d", i), Score: 1 / float32(i+1)}
+	}
+	return docs
+}
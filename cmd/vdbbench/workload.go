@@ -0,0 +1,266 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/encoder"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+const (
+	opUpsert = "upsert"
+	opSearch = "search"
+	opHybrid = "hybrid"
+	opQuery  = "query"
+
+	categoryCount = 10
+	sparseTerms   = 8
+	sparseVocab   = 100000
+)
+
+var allOps = []string{opUpsert, opSearch, opHybrid, opQuery}
+
+// record is a document of a file-backed workload, with the id, the vector and optional scalar fields.
+type record struct {
+	Id     string                 `json:"id"`
+	Vector []float32              `json:"vector"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// workload generates the requests of the benchmark.
+type workload struct {
+	cli        tcvectordb.FlatDocumentInterface
+	database   string
+	collection string
+	dimension  int
+	batchSize  int
+	topK       int
+	ef         uint32
+	filter     string
+	sparse     bool
+	records    []record
+
+	mix   []string
+	mu    sync.Mutex
+	rand  *rand.Rand
+	maxId int64
+}
+
+// parseMix parses the weights of the operations, such as "search=8,upsert=1,query=1".
+func parseMix(s string) ([]string, error) {
+	var mix []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, weight := item, 1
+		if i := strings.Index(item, "="); i >= 0 {
+			name = item[:i]
+			w, err := strconv.Atoi(item[i+1:])
+			if err != nil || w < 0 {
+				return nil, fmt.Errorf("invalid weight of %v: %v", name, item[i+1:])
+			}
+			weight = w
+		}
+		if !contains(allOps, name) {
+			return nil, fmt.Errorf("unknown operation %v, expected one of %v", name, strings.Join(allOps, ", "))
+		}
+		for i := 0; i < weight; i++ {
+			mix = append(mix, name)
+		}
+	}
+	if len(mix) == 0 {
+		return nil, fmt.Errorf("no operation in the mix %q", s)
+	}
+	return mix, nil
+}
+
+// loadRecords reads the documents of a file-backed workload, with one JSON [record] per line.
+func loadRecords(path string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var r record
+		if err := json.Unmarshal([]byte(text), &r); err != nil {
+			return nil, fmt.Errorf("%v line %v: %v", path, line, err)
+		}
+		if len(r.Vector) == 0 {
+			return nil, fmt.Errorf("%v line %v: no vector", path, line)
+		}
+		if r.Id == "" {
+			r.Id = strconv.Itoa(line)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%v has no document", path)
+	}
+	return records, nil
+}
+
+func (w *workload) nextOp() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.mix[w.rand.Intn(len(w.mix))]
+}
+
+func (w *workload) intn(n int) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rand.Intn(n)
+}
+
+func (w *workload) vector() []float32 {
+	if len(w.records) != 0 {
+		return w.records[w.intn(len(w.records))].Vector
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	v := make([]float32, w.dimension)
+	for i := range v {
+		v[i] = w.rand.Float32()
+	}
+	return v
+}
+
+func (w *workload) sparseVector() []encoder.SparseVecItem {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	items := make([]encoder.SparseVecItem, 0, sparseTerms)
+	seen := make(map[int64]bool, sparseTerms)
+	for len(items) < sparseTerms {
+		term := int64(w.rand.Intn(sparseVocab))
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		items = append(items, encoder.SparseVecItem{TermId: term, Score: w.rand.Float32()})
+	}
+	return items
+}
+
+// documents returns a batch of documents to upsert, which are taken from the file in turn,
+// or generated with increasing ids.
+func (w *workload) documents() []tcvectordb.Document {
+	docs := make([]tcvectordb.Document, 0, w.batchSize)
+	for i := 0; i < w.batchSize; i++ {
+		w.mu.Lock()
+		id := w.maxId
+		w.maxId++
+		w.mu.Unlock()
+
+		var doc tcvectordb.Document
+		if len(w.records) != 0 {
+			r := w.records[id%int64(len(w.records))]
+			doc.Id = r.Id
+			doc.Vector = r.Vector
+			doc.Fields = make(map[string]tcvectordb.Field, len(r.Fields))
+			for k, v := range r.Fields {
+				doc.Fields[k] = tcvectordb.Field{Val: v}
+			}
+		} else {
+			doc.Id = fmt.Sprintf("bench-%012d", id)
+			doc.Vector = w.vector()
+			doc.Fields = map[string]tcvectordb.Field{
+				"category": {Val: fmt.Sprintf("c%d", id%categoryCount)},
+			}
+		}
+		if w.sparse {
+			doc.SparseVector = w.sparseVector()
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func (w *workload) searchFilter() *tcvectordb.Filter {
+	if w.filter == "" {
+		return nil
+	}
+	return tcvectordb.NewFilter(strings.Replace(w.filter, "{category}", fmt.Sprintf("c%d", w.intn(categoryCount)), -1))
+}
+
+// run sends a request of the operation.
+func (w *workload) run(ctx context.Context, op string) error {
+	var err error
+	switch op {
+	case opUpsert:
+		_, err = w.cli.Upsert(ctx, w.database, w.collection, w.documents())
+	case opSearch:
+		_, err = w.cli.Search(ctx, w.database, w.collection, [][]float32{w.vector()}, &tcvectordb.SearchDocumentParams{
+			Filter:       w.searchFilter(),
+			Params:       &tcvectordb.SearchDocParams{Ef: w.ef},
+			OutputFields: []string{"id"},
+			Limit:        int64(w.topK),
+		})
+	case opHybrid:
+		limit := w.topK
+		_, err = w.cli.HybridSearch(ctx, w.database, w.collection, tcvectordb.HybridSearchDocumentParams{
+			Filter:       w.searchFilter(),
+			Params:       &tcvectordb.SearchDocParams{Ef: w.ef},
+			OutputFields: []string{"id"},
+			Limit:        &limit,
+			AnnParams:    []*tcvectordb.AnnParam{{FieldName: "vector", Data: w.vector(), Limit: &limit}},
+			Match:        []*tcvectordb.MatchOption{{FieldName: "sparse_vector", Data: w.sparseVector(), Limit: &limit}},
+			Rerank:       &tcvectordb.RerankOption{Method: tcvectordb.RerankRrf, RrfK: 60},
+		})
+	case opQuery:
+		_, err = w.cli.Query(ctx, w.database, w.collection, nil, &tcvectordb.QueryDocumentParams{
+			Filter:       w.searchFilter(),
+			OutputFields: []string{"id"},
+			Limit:        int64(w.topK),
+		})
+	}
+	return err
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+var aliasCommands = map[string]command{
+	"list":   {usage: "-db <database>", run: aliasList},
+	"set":    {usage: "-db <database> <collection> <alias>", run: aliasSet},
+	"swap":   {usage: "-db <database> <collection> <alias>", run: aliasSwap},
+	"delete": {usage: "-db <database> <alias>", run: aliasDelete},
+}
+
+func aliasList(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	res, err := e.cli.Database(*db).ListAliases(e.ctx)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, item := range res.Aliases {
+		rows = append(rows, []string{item.Alias, item.Collection})
+	}
+	return e.out.print(res.Aliases, []string{"ALIAS", "COLLECTION"}, rows)
+}
+
+func aliasSet(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	positional, err := parseFlags(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	res, err := e.cli.Database(*db).SetAlias(e.ctx, positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("alias %v set to collection %v", positional[1], positional[0]), res)
+}
+
+func aliasSwap(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	positional, err := parseFlags(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	res, err := e.cli.Database(*db).SwapAlias(e.ctx, positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("alias %v swapped from collection %v to %v", positional[1], res.PreviousCollection,
+		positional[0]), res)
+}
+
+func aliasDelete(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	res, err := e.cli.Database(*db).DeleteAlias(e.ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("alias %v deleted", positional[0]), res)
+}
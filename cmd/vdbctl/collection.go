@@ -0,0 +1,201 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+var collectionCommands = map[string]command{
+	"list":     {usage: "-db <database>", run: collectionList},
+	"describe": {usage: "-db <database> <name> [-spec]", run: collectionDescribe},
+	"create":   {usage: "-db <database> -f <spec.yaml|spec.json> [-name name] [-if-not-exists]", run: collectionCreate},
+	"truncate": {usage: "-db <database> <name> [-yes]", run: collectionTruncate},
+	"drop":     {usage: "-db <database> <name> [-yes]", run: collectionDrop},
+}
+
+// collectionInfo is the description of a collection, with its spec and status.
+type collectionInfo struct {
+	spec.CollectionSpec
+	Alias         []string               `json:"alias,omitempty"`
+	DocumentCount int64                  `json:"documentCount"`
+	Size          uint64                 `json:"size,omitempty"`
+	IndexStatus   tcvectordb.IndexStatus `json:"indexStatus"`
+	CreateTime    time.Time              `json:"createTime"`
+}
+
+func collectionList(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	res, err := e.cli.Database(*db).ListCollection(e.ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		items []collectionInfo
+		rows  [][]string
+	)
+	for _, coll := range res.Collections {
+		info := newCollectionInfo(coll)
+		items = append(items, info)
+		rows = append(rows, []string{coll.CollectionName, strconv.FormatInt(coll.DocumentCount, 10),
+			strings.Join(coll.Alias, ","), coll.IndexStatus.Status, fmt.Sprintf("%v/%v", coll.ShardNum, coll.ReplicasNum),
+			formatTime(coll.CreateTime)})
+	}
+	return e.out.print(items, []string{"NAME", "DOCUMENTS", "ALIAS", "INDEX", "SHARDS/REPLICAS", "CREATED"}, rows)
+}
+
+func collectionDescribe(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	specOnly := fs.Bool("spec", false, "print the spec only, which can be used by collection create")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	res, err := e.cli.Database(*db).DescribeCollection(e.ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	info := newCollectionInfo(&res.Collection)
+	if *specOnly {
+		info.CollectionSpec.Database = ""
+		return e.out.printObject(info.CollectionSpec)
+	}
+	return e.out.printObject(info)
+}
+
+func collectionCreate(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name (defaults to the database of the spec)")
+	file := fs.String("f", "", "the spec file in YAML or JSON")
+	name := fs.String("name", "", "the collection name, overriding the spec")
+	ifNotExists := fs.Bool("if-not-exists", false, "succeed if the collection exists")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-f is required")
+	}
+	var s spec.CollectionSpec
+	if err := spec.ReadFile(*file, &s); err != nil {
+		return err
+	}
+	if *name != "" {
+		s.Name = *name
+	}
+	if *db != "" {
+		s.Database = *db
+	}
+	if s.Database == "" {
+		return fmt.Errorf("no database, set it in the spec or with -db")
+	}
+	if s.ShardNum == 0 {
+		s.ShardNum = 1
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	indexes, err := s.ToIndexes()
+	if err != nil {
+		return err
+	}
+	database := e.cli.Database(s.Database)
+	var coll *tcvectordb.Collection
+	if *ifNotExists {
+		coll, err = database.CreateCollectionIfNotExists(e.ctx, s.Name, s.ShardNum, s.ReplicaNum, s.Description, indexes, s.CreateParams())
+	} else {
+		coll, err = database.CreateCollection(e.ctx, s.Name, s.ShardNum, s.ReplicaNum, s.Description, indexes, s.CreateParams())
+	}
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("collection %v.%v created", s.Database, s.Name), newCollectionInfo(coll))
+}
+
+func collectionTruncate(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	if err := confirm(fmt.Sprintf("delete all documents of collection %v.%v", *db, positional[0]), *yes); err != nil {
+		return err
+	}
+	res, err := e.cli.Database(*db).TruncateCollection(e.ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("collection %v.%v truncated", *db, positional[0]), res)
+}
+
+func collectionDrop(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return fmt.Errorf("-db is required")
+	}
+	if err := confirm(fmt.Sprintf("drop collection %v.%v", *db, positional[0]), *yes); err != nil {
+		return err
+	}
+	res, err := e.cli.Database(*db).DropCollection(e.ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("collection %v.%v dropped", *db, positional[0]), res)
+}
+
+func newCollectionInfo(coll *tcvectordb.Collection) collectionInfo {
+	return collectionInfo{
+		CollectionSpec: spec.FromCollection(coll),
+		Alias:          coll.Alias,
+		DocumentCount:  coll.DocumentCount,
+		Size:           coll.Size,
+		IndexStatus:    coll.IndexStatus,
+		CreateTime:     coll.CreateTime,
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+var databaseCommands = map[string]command{
+	"list":   {usage: "", run: databaseList},
+	"create": {usage: "<name> [-ai]", run: databaseCreate},
+	"drop":   {usage: "<name> [-ai] [-yes]", run: databaseDrop},
+}
+
+type databaseItem struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	CreateTime string `json:"createTime,omitempty"`
+	Count      int64  `json:"count"`
+}
+
+func databaseList(e *env, fs *flag.FlagSet, args []string) error {
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	res, err := e.cli.ListDatabase(e.ctx)
+	if err != nil {
+		return err
+	}
+	var items []databaseItem
+	for _, db := range res.Databases {
+		items = append(items, databaseItem{Name: db.DatabaseName, Type: dbType(db.Info.DbType, "BASE_DB"),
+			CreateTime: db.Info.CreateTime, Count: db.Info.Count})
+	}
+	for _, db := range res.AIDatabases {
+		items = append(items, databaseItem{Name: db.DatabaseName, Type: dbType(db.Info.DbType, "AI_DB"),
+			CreateTime: db.Info.CreateTime, Count: db.Info.Count})
+	}
+	var rows [][]string
+	for _, item := range items {
+		rows = append(rows, []string{item.Name, item.Type, strconv.FormatInt(item.Count, 10), item.CreateTime})
+	}
+	return e.out.print(items, []string{"NAME", "TYPE", "COLLECTIONS", "CREATED"}, rows)
+}
+
+func databaseCreate(e *env, fs *flag.FlagSet, args []string) error {
+	ai := fs.Bool("ai", false, "create an AI database")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	name := positional[0]
+	if *ai {
+		res, err := e.cli.CreateAIDatabase(e.ctx, name)
+		if err != nil {
+			return err
+		}
+		return e.out.done(fmt.Sprintf("AI database %v created", name), map[string]interface{}{"name": name, "affectedCount": res.AffectedCount})
+	}
+	res, err := e.cli.CreateDatabaseIfNotExists(e.ctx, name)
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("database %v created", name), map[string]interface{}{"name": name, "affectedCount": res.AffectedCount})
+}
+
+func databaseDrop(e *env, fs *flag.FlagSet, args []string) error {
+	ai := fs.Bool("ai", false, "drop an AI database")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	name := positional[0]
+	if err := confirm(fmt.Sprintf("drop database %v and all its collections", name), *yes); err != nil {
+		return err
+	}
+	var affected int
+	if *ai {
+		res, err := e.cli.DropAIDatabase(e.ctx, name)
+		if err != nil {
+			return err
+		}
+		affected = int(res.AffectedCount)
+	} else {
+		res, err := e.cli.DropDatabase(e.ctx, name)
+		if err != nil {
+			return err
+		}
+		affected = res.AffectedCount
+	}
+	return e.out.done(fmt.Sprintf("database %v dropped", name), map[string]interface{}{"name": name, "affectedCount": affected})
+}
+
+func dbType(dbType, def string) string {
+	if dbType == "" {
+		return def
+	}
+	return dbType
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+var documentCommands = map[string]command{
+	"query":  {usage: "-db <database> -coll <collection> [-ids a,b] [-filter expr] [-limit n] [-offset n] [-fields f1,f2] [-vector]", run: documentQuery},
+	"count":  {usage: "-db <database> -coll <collection> [-filter expr]", run: documentCount},
+	"delete": {usage: "-db <database> -coll <collection> [-ids a,b] [-filter expr] [-limit n] [-yes]", run: documentDelete},
+	"search": {usage: "-db <database> -coll <collection> -vector-file <vectors.json|vectors.yaml> [-limit n] [-filter expr] [-ef n] [-fields f1,f2]", run: documentSearch},
+}
+
+// documentTarget holds the flags shared by all document commands.
+type documentTarget struct {
+	db     *string
+	coll   *string
+	filter *string
+}
+
+func newDocumentTarget(fs *flag.FlagSet) *documentTarget {
+	return &documentTarget{
+		db:     fs.String("db", "", "the database name"),
+		coll:   fs.String("coll", "", "the collection name"),
+		filter: fs.String("filter", "", `the filter expression, such as 'page > 10'`),
+	}
+}
+
+func (t *documentTarget) check() error {
+	if *t.db == "" || *t.coll == "" {
+		return fmt.Errorf("-db and -coll are required")
+	}
+	return nil
+}
+
+func (t *documentTarget) toFilter() *tcvectordb.Filter {
+	if *t.filter == "" {
+		return nil
+	}
+	return tcvectordb.NewFilter(*t.filter)
+}
+
+func documentQuery(e *env, fs *flag.FlagSet, args []string) error {
+	target := newDocumentTarget(fs)
+	ids := fs.String("ids", "", "the comma separated document ids")
+	limit := fs.Int64("limit", 10, "the maximum number of documents")
+	offset := fs.Int64("offset", 0, "the number of documents to skip")
+	fields := fs.String("fields", "", "the comma separated fields to output")
+	vector := fs.Bool("vector", false, "output the vectors")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := target.check(); err != nil {
+		return err
+	}
+	res, err := e.cli.Query(e.ctx, *target.db, *target.coll, splitList(*ids), &tcvectordb.QueryDocumentParams{
+		Filter:         target.toFilter(),
+		RetrieveVector: *vector,
+		OutputFields:   splitList(*fields),
+		Offset:         *offset,
+		Limit:          *limit,
+	})
+	if err != nil {
+		return err
+	}
+	return printDocuments(e, res.Documents, false)
+}
+
+func documentCount(e *env, fs *flag.FlagSet, args []string) error {
+	target := newDocumentTarget(fs)
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := target.check(); err != nil {
+		return err
+	}
+	res, err := e.cli.Count(e.ctx, *target.db, *target.coll, tcvectordb.CountDocumentParams{CountFilter: target.toFilter()})
+	if err != nil {
+		return err
+	}
+	return e.out.print(map[string]uint64{"count": res.Count}, []string{"COUNT"},
+		[][]string{{strconv.FormatUint(res.Count, 10)}})
+}
+
+func documentDelete(e *env, fs *flag.FlagSet, args []string) error {
+	target := newDocumentTarget(fs)
+	ids := fs.String("ids", "", "the comma separated document ids")
+	limit := fs.Int64("limit", 0, "the maximum number of documents to delete, 0 for no limit")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := target.check(); err != nil {
+		return err
+	}
+	if *ids == "" && *target.filter == "" {
+		return fmt.Errorf("-ids or -filter is required")
+	}
+	if err := confirm(fmt.Sprintf("delete documents from %v.%v", *target.db, *target.coll), *yes); err != nil {
+		return err
+	}
+	res, err := e.cli.Delete(e.ctx, *target.db, *target.coll, tcvectordb.DeleteDocumentParams{
+		DocumentIds: splitList(*ids),
+		Filter:      target.toFilter(),
+		Limit:       *limit,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("%v documents deleted", res.AffectedCount),
+		map[string]int{"affectedCount": res.AffectedCount})
+}
+
+func documentSearch(e *env, fs *flag.FlagSet, args []string) error {
+	target := newDocumentTarget(fs)
+	vectorFile := fs.String("vector-file", "", "a JSON or YAML file with a vector or an array of vectors")
+	limit := fs.Int64("limit", 10, "the number of results for each vector")
+	ef := fs.Uint("ef", 0, "the ef parameter for HNSW indexes")
+	fields := fs.String("fields", "", "the comma separated fields to output")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := target.check(); err != nil {
+		return err
+	}
+	if *vectorFile == "" {
+		return fmt.Errorf("-vector-file is required")
+	}
+	vectors, err := readVectors(*vectorFile)
+	if err != nil {
+		return err
+	}
+	param := &tcvectordb.SearchDocumentParams{
+		Filter:       target.toFilter(),
+		OutputFields: splitList(*fields),
+		Limit:        *limit,
+	}
+	if *ef > 0 {
+		param.Params = &tcvectordb.SearchDocParams{Ef: uint32(*ef)}
+	}
+	res, err := e.cli.Search(e.ctx, *target.db, *target.coll, vectors, param)
+	if err != nil {
+		return err
+	}
+	var documents []tcvectordb.Document
+	for _, docs := range res.Documents {
+		documents = append(documents, docs...)
+	}
+	if e.out.format != "table" {
+		return e.out.printObject(documentItems(res.Documents))
+	}
+	return printDocuments(e, documents, true)
+}
+
+// readVectors reads a single vector or an array of vectors from a JSON or YAML file.
+func readVectors(path string) ([][]float32, error) {
+	var raw interface{}
+	if err := spec.ReadFile(path, &raw); err != nil {
+		return nil, err
+	}
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("%v must contain a vector or an array of vectors", path)
+	}
+	if _, nested := list[0].([]interface{}); !nested {
+		list = []interface{}{list}
+	}
+	vectors := make([][]float32, 0, len(list))
+	for i, item := range list {
+		values, ok := item.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("vector %v in %v is not an array", i, path)
+		}
+		vector := make([]float32, len(values))
+		for j, v := range values {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("vector %v in %v has a non-numeric value at %v", i, path, j)
+			}
+			vector[j] = float32(f)
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+// documentItem is the output form of a document.
+type documentItem struct {
+	Id     string                 `json:"id"`
+	Score  *float32               `json:"score,omitempty"`
+	Vector []float32              `json:"vector,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+func toDocumentItem(doc tcvectordb.Document, withScore bool) documentItem {
+	item := documentItem{Id: doc.Id, Vector: doc.Vector}
+	if withScore {
+		score := doc.Score
+		item.Score = &score
+	}
+	if len(doc.Fields) > 0 {
+		item.Fields = make(map[string]interface{}, len(doc.Fields))
+		for name, field := range doc.Fields {
+			item.Fields[name] = field.Val
+		}
+	}
+	return item
+}
+
+func documentItems(results [][]tcvectordb.Document) [][]documentItem {
+	items := make([][]documentItem, 0, len(results))
+	for _, docs := range results {
+		list := make([]documentItem, 0, len(docs))
+		for _, doc := range docs {
+			list = append(list, toDocumentItem(doc, true))
+		}
+		items = append(items, list)
+	}
+	return items
+}
+
+func printDocuments(e *env, documents []tcvectordb.Document, withScore bool) error {
+	items := make([]documentItem, 0, len(documents))
+	for _, doc := range documents {
+		items = append(items, toDocumentItem(doc, withScore))
+	}
+	header := []string{"ID"}
+	if withScore {
+		header = append(header, "SCORE")
+	}
+	header = append(header, "FIELDS")
+	var rows [][]string
+	for _, item := range items {
+		row := []string{item.Id}
+		if withScore {
+			row = append(row, strconv.FormatFloat(float64(*item.Score), 'f', 4, 32))
+		}
+		row = append(row, formatFields(item.Fields))
+		rows = append(rows, row)
+	}
+	return e.out.print(items, header, rows)
+}
+
+func formatFields(fields map[string]interface{}) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := make([]string, 0, len(names))
+	for _, name := range names {
+		list = append(list, fmt.Sprintf("%v=%v", name, fields[name]))
+	}
+	return strings.Join(list, " ")
+}
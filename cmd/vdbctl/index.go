@@ -0,0 +1,162 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/index"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+var indexCommands = map[string]command{
+	"add":     {usage: "-db <database> -coll <collection> <field> -type <string|uint64|int64|double|array|json> [-elem-type type] [-build-existed]", run: indexAdd},
+	"drop":    {usage: "-db <database> -coll <collection> <field>...", run: indexDrop},
+	"rebuild": {usage: "-db <database> -coll <collection> [-field name] [-drop-before] [-throttle n] [-unlimited-cpu]", run: indexRebuild},
+	"modify":  {usage: "-db <database> -coll <collection> <vector field> [-index-type type] [-metric type] [-M n] [-ef-construction n] [-nlist n] [-bits n]", run: indexModify},
+}
+
+func indexAdd(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	coll := fs.String("coll", "", "the collection name")
+	fieldType := fs.String("type", "", "the type of the field")
+	elemType := fs.String("elem-type", "", "the type of the elements of an array field")
+	buildExisted := fs.Bool("build-existed", true, "build the index for the existing documents")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *db == "" || *coll == "" || *fieldType == "" {
+		return fmt.Errorf("-db, -coll and -type are required")
+	}
+	err = e.cli.AddIndex(e.ctx, *db, *coll, &tcvectordb.AddIndexParams{
+		FilterIndexs: []tcvectordb.FilterIndex{{
+			FieldName: positional[0],
+			FieldType: tcvectordb.FieldType(*fieldType),
+			ElemType:  tcvectordb.FieldType(*elemType),
+			IndexType: tcvectordb.FILTER,
+		}},
+		BuildExistedData: buildExisted,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("index %v added to %v.%v", positional[0], *db, *coll), map[string]string{"field": positional[0]})
+}
+
+func indexDrop(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	coll := fs.String("coll", "", "the collection name")
+	positional, err := parseFlags(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+	if *db == "" || *coll == "" {
+		return fmt.Errorf("-db and -coll are required")
+	}
+	if err := e.cli.DropIndex(e.ctx, *db, *coll, tcvectordb.DropIndexParams{FieldNames: positional}); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("index %v dropped from %v.%v", strings.Join(positional, ", "), *db, *coll),
+		map[string][]string{"fields": positional})
+}
+
+func indexRebuild(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	coll := fs.String("coll", "", "the collection name")
+	field := fs.String("field", "", "the vector field to rebuild (defaults to all)")
+	dropBefore := fs.Bool("drop-before", false, "drop the index before rebuilding, which makes the collection unsearchable meanwhile")
+	throttle := fs.Int("throttle", 0, "the number of the CPU cores per node for rebuilding, 0 for the server default")
+	unlimited := fs.Bool("unlimited-cpu", false, "rebuild without limiting the CPU")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *db == "" || *coll == "" {
+		return fmt.Errorf("-db and -coll are required")
+	}
+	res, err := e.cli.RebuildIndex(e.ctx, *db, *coll, &tcvectordb.RebuildIndexParams{
+		DropBeforeRebuild: *dropBefore,
+		Throttle:          *throttle,
+		UnLimitedCPU:      *unlimited,
+		FieldName:         *field,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("rebuilding %v.%v, tasks: %v", *db, *coll, strings.Join(res.TaskIds, ", ")), res)
+}
+
+func indexModify(e *env, fs *flag.FlagSet, args []string) error {
+	db := fs.String("db", "", "the database name")
+	coll := fs.String("coll", "", "the collection name")
+	fieldType := fs.String("field-type", "", "the type of the vector field, such as vector or float16_vector")
+	indexType := fs.String("index-type", "", "the index type, such as HNSW or IVF_FLAT")
+	metric := fs.String("metric", "", "the metric type, such as COSINE, IP or L2")
+	m := fs.Uint("M", 0, "the M of HNSW or IVF_PQ")
+	efConstruction := fs.Uint("ef-construction", 0, "the efConstruction of HNSW")
+	nlist := fs.Uint("nlist", 0, "the nlist of the IVF indexes")
+	bits := fs.Uint("bits", 0, "the bits of IVF_RABITQ")
+	diskSwap := fs.String("disk-swap", "", "true or false to store a sparse vector field on disk")
+	throttle := fs.Int("throttle", 0, "the number of the CPU cores per node for rebuilding, 0 for the server default")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *db == "" || *coll == "" {
+		return fmt.Errorf("-db and -coll are required")
+	}
+	modify := tcvectordb.ModifyVectorIndex{
+		FieldName:  positional[0],
+		FieldType:  *fieldType,
+		IndexType:  *indexType,
+		MetricType: tcvectordb.MetricType(*metric),
+	}
+	if *diskSwap != "" {
+		enabled, err := strconv.ParseBool(*diskSwap)
+		if err != nil {
+			return fmt.Errorf("invalid -disk-swap %v", *diskSwap)
+		}
+		modify.DiskSwapEnabled = &enabled
+	}
+	if *m != 0 || *efConstruction != 0 || *nlist != 0 || *bits != 0 {
+		if *indexType == "" {
+			return fmt.Errorf("-index-type is required to modify the index parameters")
+		}
+		params := &spec.IndexParamsSpec{M: uint32(*m), EfConstruction: uint32(*efConstruction), NList: uint32(*nlist)}
+		if *bits != 0 {
+			b := uint32(*bits)
+			params.Bits = &b
+		}
+		modify.Params = params.IndexParams(tcvectordb.IndexType(*indexType))
+	}
+	param := tcvectordb.ModifyVectorIndexParam{VectorIndexes: []tcvectordb.ModifyVectorIndex{modify}}
+	if *throttle != 0 {
+		t := int32(*throttle)
+		param.RebuildRules = &index.RebuildRules{Throttle: &t}
+	}
+	if err := e.cli.ModifyVectorIndex(e.ctx, *db, *coll, param); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("index %v of %v.%v modified, the index is being rebuilt", positional[0], *db, *coll),
+		map[string]string{"field": positional[0]})
+}
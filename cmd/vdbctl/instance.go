@@ -0,0 +1,115 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+var instanceCommands = map[string]command{
+	"diff":   {usage: "-f <instance.yaml|instance.json> [-prune]", run: instanceDiff},
+	"apply":  {usage: "-f <instance.yaml|instance.json> [-prune] [-dry-run] [-yes]", run: instanceApply},
+	"export": {usage: "", run: instanceExport},
+}
+
+func readInstanceSpec(file string) (*spec.InstanceSpec, error) {
+	if file == "" {
+		return nil, fmt.Errorf("-f is required")
+	}
+	s := new(spec.InstanceSpec)
+	if err := spec.ReadFile(file, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func printPlan(e *env, plan *spec.Plan) error {
+	if e.out.format == "table" {
+		return plan.WriteDiff(e.out.w)
+	}
+	return e.out.printObject(plan)
+}
+
+func instanceDiff(e *env, fs *flag.FlagSet, args []string) error {
+	file := fs.String("f", "", "the instance spec in YAML or JSON")
+	prune := fs.Bool("prune", false, "also delete what the spec doesn't declare")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	s, err := readInstanceSpec(*file)
+	if err != nil {
+		return err
+	}
+	plan, err := spec.Diff(e.ctx, e.cli, s, *prune)
+	if err != nil {
+		return err
+	}
+	return printPlan(e, plan)
+}
+
+func instanceApply(e *env, fs *flag.FlagSet, args []string) error {
+	file := fs.String("f", "", "the instance spec in YAML or JSON")
+	prune := fs.Bool("prune", false, "also delete what the spec doesn't declare")
+	dryRun := fs.Bool("dry-run", false, "only print the changes")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	s, err := readInstanceSpec(*file)
+	if err != nil {
+		return err
+	}
+	plan, err := spec.Diff(e.ctx, e.cli, s, *prune)
+	if err != nil {
+		return err
+	}
+	if err := printPlan(e, plan); err != nil {
+		return err
+	}
+	if *dryRun || plan.Empty() {
+		return nil
+	}
+	if conflicts := plan.Conflicts(); len(conflicts) > 0 {
+		return fmt.Errorf("%v conflicts must be resolved by hand before applying", len(conflicts))
+	}
+	if err := confirm(fmt.Sprintf("apply %v changes", len(plan.Changes)), *yes); err != nil {
+		return err
+	}
+	if err := plan.Execute(e.ctx); err != nil {
+		return err
+	}
+	if e.out.format == "table" {
+		fmt.Fprintf(e.out.w, "%v changes applied\n", len(plan.Changes))
+	}
+	return nil
+}
+
+func instanceExport(e *env, fs *flag.FlagSet, args []string) error {
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	s, err := spec.Export(e.ctx, e.cli)
+	if err != nil {
+		return err
+	}
+	return e.out.printObject(s)
+}
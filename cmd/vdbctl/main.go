@@ -0,0 +1,316 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Command vdbctl administers Tencent Cloud VectorDB instances from the command line.
+//
+// Usage:
+//
+//	vdbctl [global flags] <resource> <verb> [arguments] [flags]
+//
+// The resources are profile, database, collection, alias, index, user, document and instance. Run
+// "vdbctl <resource>" to list the verbs of a resource, and "vdbctl <resource> <verb> -h" for the flags.
+//
+// The connection is taken from a profile of the config file (~/.vdbctl/config.yaml, or $VDBCTL_CONFIG),
+// which can be overridden by the global flags:
+//
+//	vdbctl profile set dev -url http://10.0.0.1:80 -username root -key-env VDB_KEY
+//	vdbctl -profile dev collection describe -db db1 articles
+//	vdbctl -o json collection create -db db1 -f articles.yaml
+//
+// The whole instance can be described in one file, see InstanceSpec of the tcvectordb/spec package,
+// and applied idempotently:
+//
+//	vdbctl instance export > instance.yaml
+//	vdbctl instance diff -f instance.yaml
+//	vdbctl instance apply -f instance.yaml -prune
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+// adminClient is the client of the commands, implemented by [tcvectordb.Client] and [tcvectordb.RpcClient].
+type adminClient interface {
+	tcvectordb.DatabaseInterface
+	tcvectordb.FlatInterface
+	tcvectordb.FlatIndexInterface
+}
+
+var (
+	_ adminClient = &tcvectordb.Client{}
+	_ adminClient = &tcvectordb.RpcClient{}
+)
+
+// env is the environment of a command.
+type env struct {
+	ctx     context.Context
+	globals globalFlags
+	out     *printer
+	cli     adminClient
+}
+
+type globalFlags struct {
+	config   string
+	profile  string
+	url      string
+	username string
+	key      string
+	protocol string
+	timeout  time.Duration
+	output   string
+	debug    bool
+}
+
+// command is a verb of a resource.
+type command struct {
+	usage string
+	run   func(e *env, fs *flag.FlagSet, args []string) error
+	// local commands do not connect to the instance.
+	local bool
+}
+
+var resources = map[string]map[string]command{
+	"profile":    profileCommands,
+	"database":   databaseCommands,
+	"collection": collectionCommands,
+	"alias":      aliasCommands,
+	"index":      indexCommands,
+	"user":       userCommands,
+	"document":   documentCommands,
+	"instance":   instanceCommands,
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	var g globalFlags
+	fs := flag.NewFlagSet("vdbctl", flag.ContinueOnError)
+	fs.StringVar(&g.config, "config", "", "the config file (defaults to $VDBCTL_CONFIG or ~/.vdbctl/config.yaml)")
+	fs.StringVar(&g.profile, "profile", os.Getenv("VDBCTL_PROFILE"), "the profile to use (defaults to $VDBCTL_PROFILE or the current profile)")
+	fs.StringVar(&g.url, "url", "", "the url of the instance, overriding the profile")
+	fs.StringVar(&g.username, "username", "", "the username, overriding the profile")
+	fs.StringVar(&g.key, "key", "", "the api key, overriding the profile")
+	fs.StringVar(&g.protocol, "protocol", "", "http or grpc, overriding the profile")
+	fs.DurationVar(&g.timeout, "timeout", 0, "the timeout of the requests, overriding the profile")
+	fs.StringVar(&g.output, "o", "table", "the output format: table, json or yaml")
+	fs.BoolVar(&g.debug, "debug", false, "print the requests and the responses")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: vdbctl [global flags] <resource> <verb> [arguments] [flags]")
+		fmt.Fprintln(fs.Output(), "\nResources:")
+		for _, name := range sortedKeys(resources) {
+			fmt.Fprintf(fs.Output(), "  %v\n", name)
+		}
+		fmt.Fprintln(fs.Output(), "\nGlobal flags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	args = fs.Args()
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("no resource given")
+	}
+	verbs, ok := resources[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown resource %v, expected one of %v", args[0], strings.Join(sortedKeys(resources), ", "))
+	}
+	if len(args) < 2 {
+		printVerbs(args[0], verbs)
+		return fmt.Errorf("no verb given")
+	}
+	cmd, ok := verbs[args[1]]
+	if !ok {
+		printVerbs(args[0], verbs)
+		return fmt.Errorf("unknown verb %v of %v", args[1], args[0])
+	}
+
+	out, err := newPrinter(g.output)
+	if err != nil {
+		return err
+	}
+	e := &env{ctx: context.Background(), globals: g, out: out}
+	if !cmd.local {
+		if e.cli, err = connect(g); err != nil {
+			return err
+		}
+		defer e.cli.Close()
+	}
+	return cmd.run(e, newFlagSet(args[0]+" "+args[1], cmd.usage), args[2:])
+}
+
+// connect creates the client of the profile, overridden by the global flags.
+func connect(g globalFlags) (adminClient, error) {
+	cfg, err := loadConfig(g.config)
+	if err != nil {
+		return nil, err
+	}
+	p, err := cfg.resolve(g.profile)
+	if err != nil {
+		return nil, err
+	}
+	if g.url != "" {
+		p.Url = g.url
+	}
+	if g.username != "" {
+		p.Username = g.username
+	}
+	if g.key != "" {
+		p.Key = g.key
+	}
+	if g.protocol != "" {
+		p.Protocol = g.protocol
+	}
+	if g.timeout != 0 {
+		p.Timeout = g.timeout.String()
+	}
+	if p.Url == "" {
+		return nil, fmt.Errorf("no url, set it in a profile or with -url")
+	}
+	if p.Username == "" {
+		p.Username = "root"
+	}
+	option := &tcvectordb.ClientOption{
+		Timeout:            10 * time.Second,
+		CACert:             p.CACert,
+		InsecureSkipVerify: p.InsecureSkipVerify,
+	}
+	if p.Timeout != "" {
+		if option.Timeout, err = time.ParseDuration(p.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid timeout %v: %v", p.Timeout, err)
+		}
+	}
+	var cli adminClient
+	switch p.Protocol {
+	case "", "http":
+		cli, err = tcvectordb.NewClient(p.Url, p.Username, p.key(), option)
+	case "grpc":
+		cli, err = tcvectordb.NewRpcClient(p.Url, p.Username, p.key(), option)
+	default:
+		return nil, fmt.Errorf("unknown protocol %v, expected http or grpc", p.Protocol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cli.Debug(g.debug)
+	return cli, nil
+}
+
+func printVerbs(resource string, verbs map[string]command) {
+	fmt.Fprintf(os.Stderr, "Usage of %v:\n", resource)
+	for _, name := range sortedKeys(verbs) {
+		fmt.Fprintf(os.Stderr, "  vdbctl %v %v %v\n", resource, name, verbs[name].usage)
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case map[string]map[string]command:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]command:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseFlags parses the flags of a verb, which may be interspersed with the positional arguments,
+// and checks the number of the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string, minArgs, maxArgs int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) < minArgs || (maxArgs >= 0 && len(positional) > maxArgs) {
+		fs.Usage()
+		switch {
+		case maxArgs < 0:
+			return nil, fmt.Errorf("expected at least %v arguments, got %v", minArgs, len(positional))
+		case minArgs == maxArgs:
+			return nil, fmt.Errorf("expected %v arguments, got %v", minArgs, len(positional))
+		}
+		return nil, fmt.Errorf("expected %v to %v arguments, got %v", minArgs, maxArgs, len(positional))
+	}
+	return positional, nil
+}
+
+// newFlagSet creates the flag set of a verb, such as "collection create".
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: vdbctl %v %v\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// confirm asks the user to confirm a destructive command, unless -yes is given.
+func confirm(prompt string, yes bool) error {
+	if yes {
+		return nil
+	}
+	if stat, err := os.Stdin.Stat(); err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		return fmt.Errorf("%v: confirm with -yes when not running in a terminal", prompt)
+	}
+	fmt.Fprintf(os.Stderr, "%v? [y/N] ", prompt)
+	var answer string
+	fmt.Fscanln(os.Stdin, &answer)
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+		return fmt.Errorf("canceled")
+	}
+	return nil
+}
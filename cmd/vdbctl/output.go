@@ -0,0 +1,86 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+// printer writes the results of the commands in the output format.
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string) (*printer, error) {
+	switch format {
+	case "table", "json", "yaml":
+		return &printer{format: format, w: os.Stdout}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %v, expected table, json or yaml", format)
+}
+
+// print writes v as JSON or YAML, or the rows as a table.
+func (p *printer) print(v interface{}, header []string, rows [][]string) error {
+	switch p.format {
+	case "json":
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case "yaml":
+		bytes, err := spec.MarshalYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(bytes)
+		return err
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	if len(header) != 0 {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printObject writes v as JSON or YAML, which is YAML for the table format.
+func (p *printer) printObject(v interface{}) error {
+	if p.format == "table" {
+		return (&printer{format: "yaml", w: p.w}).print(v, nil, nil)
+	}
+	return p.print(v, nil, nil)
+}
+
+// done reports the result of a command which changes the instance.
+func (p *printer) done(message string, v interface{}) error {
+	if p.format == "table" {
+		_, err := fmt.Fprintln(p.w, message)
+		return err
+	}
+	return p.print(v, nil, nil)
+}
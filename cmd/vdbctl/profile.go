@@ -0,0 +1,273 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+// profile holds the endpoint and the credentials of an instance.
+type profile struct {
+	Url                string `json:"url"`
+	Username           string `json:"username,omitempty"`
+	Key                string `json:"key,omitempty"`
+	KeyEnv             string `json:"keyEnv,omitempty"`
+	Protocol           string `json:"protocol,omitempty"`
+	Timeout            string `json:"timeout,omitempty"`
+	CACert             string `json:"caCert,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// key returns the key of the profile, which is read from the environment variable KeyEnv if set,
+// so that the key need not be stored in the config file.
+func (p profile) key() string {
+	if p.KeyEnv != "" {
+		if key := os.Getenv(p.KeyEnv); key != "" {
+			return key
+		}
+	}
+	return p.Key
+}
+
+type config struct {
+	path     string
+	Current  string             `json:"current,omitempty"`
+	Profiles map[string]profile `json:"profiles,omitempty"`
+}
+
+func configPath(path string) string {
+	if path != "" {
+		return path
+	}
+	if path = os.Getenv("VDBCTL_CONFIG"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".vdbctl.yaml"
+	}
+	return filepath.Join(home, ".vdbctl", "config.yaml")
+}
+
+// loadConfig reads the config file, which is empty if the file does not exist.
+func loadConfig(path string) (*config, error) {
+	cfg := &config{path: configPath(path)}
+	if _, err := os.Stat(cfg.path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err := spec.ReadFile(cfg.path, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *config) save() error {
+	bytes, err := spec.MarshalYAML(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+	// The file may hold keys, so it is readable by the owner only.
+	return ioutil.WriteFile(c.path, bytes, 0600)
+}
+
+// resolve returns the named profile, or the current one if the name is empty.
+// Without any profile, an empty profile is returned to be filled by the flags.
+func (c *config) resolve(name string) (profile, error) {
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" {
+		return profile{}, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("profile %v not found in %v", name, c.path)
+	}
+	return p, nil
+}
+
+var profileCommands = map[string]command{
+	"list":   {usage: "", run: profileList, local: true},
+	"show":   {usage: "[name]", run: profileShow, local: true},
+	"set":    {usage: "<name> [-url url] [-username name] [-key key | -key-env VAR] [-protocol http|grpc] [-timeout 10s]", run: profileSet, local: true},
+	"use":    {usage: "<name>", run: profileUse, local: true},
+	"delete": {usage: "<name>", run: profileDelete, local: true},
+}
+
+func profileList(e *env, fs *flag.FlagSet, args []string) error {
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(e.globals.config)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, name := range sortedProfiles(cfg.Profiles) {
+		p := cfg.Profiles[name]
+		current := ""
+		if name == cfg.Current {
+			current = "*"
+		}
+		rows = append(rows, []string{current, name, p.Url, p.Username, p.Protocol})
+	}
+	return e.out.print(redact(cfg.Profiles), []string{"CURRENT", "NAME", "URL", "USERNAME", "PROTOCOL"}, rows)
+}
+
+func profileShow(e *env, fs *flag.FlagSet, args []string) error {
+	positional, err := parseFlags(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(e.globals.config)
+	if err != nil {
+		return err
+	}
+	name := e.globals.profile
+	if len(positional) != 0 {
+		name = positional[0]
+	}
+	p, err := cfg.resolve(name)
+	if err != nil {
+		return err
+	}
+	return e.out.printObject(redact(map[string]profile{"": p})[""])
+}
+
+func profileSet(e *env, fs *flag.FlagSet, args []string) error {
+	url := fs.String("url", "", "the url of the instance")
+	username := fs.String("username", "", "the username")
+	key := fs.String("key", "", "the api key, stored in the config file")
+	keyEnv := fs.String("key-env", "", "the environment variable to read the api key from")
+	protocol := fs.String("protocol", "", "http or grpc")
+	timeout := fs.String("timeout", "", "the timeout of the requests, such as 10s")
+	caCert := fs.String("ca-cert", "", "the CA certificate file for https")
+	insecure := fs.String("insecure-skip-verify", "", "true to skip the verification of the certificate")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(e.globals.config)
+	if err != nil {
+		return err
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]profile)
+	}
+	name := positional[0]
+	p := cfg.Profiles[name]
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&p.Url, *url)
+	set(&p.Username, *username)
+	set(&p.Key, *key)
+	set(&p.KeyEnv, *keyEnv)
+	set(&p.Protocol, *protocol)
+	set(&p.Timeout, *timeout)
+	set(&p.CACert, *caCert)
+	if *insecure != "" {
+		if p.InsecureSkipVerify, err = strconv.ParseBool(*insecure); err != nil {
+			return fmt.Errorf("invalid -insecure-skip-verify %v", *insecure)
+		}
+	}
+	cfg.Profiles[name] = p
+	if cfg.Current == "" {
+		cfg.Current = name
+	}
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("profile %v saved to %v", name, cfg.path), redact(map[string]profile{name: p}))
+}
+
+func profileUse(e *env, fs *flag.FlagSet, args []string) error {
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(e.globals.config)
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[positional[0]]; !ok {
+		return fmt.Errorf("profile %v not found in %v", positional[0], cfg.path)
+	}
+	cfg.Current = positional[0]
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("switched to profile %v", cfg.Current), map[string]string{"current": cfg.Current})
+}
+
+func profileDelete(e *env, fs *flag.FlagSet, args []string) error {
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(e.globals.config)
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[positional[0]]; !ok {
+		return fmt.Errorf("profile %v not found in %v", positional[0], cfg.path)
+	}
+	delete(cfg.Profiles, positional[0])
+	if cfg.Current == positional[0] {
+		cfg.Current = ""
+	}
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("profile %v deleted", positional[0]), map[string]string{"deleted": positional[0]})
+}
+
+// redact hides the keys of the profiles before printing them.
+func redact(profiles map[string]profile) map[string]profile {
+	res := make(map[string]profile, len(profiles))
+	for name, p := range profiles {
+		if p.Key != "" {
+			p.Key = "******"
+		}
+		res[name] = p
+	}
+	return res
+}
+
+func sortedProfiles(profiles map[string]profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
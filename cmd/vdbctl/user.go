@@ -0,0 +1,157 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	api_user "github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/user"
+)
+
+var userCommands = map[string]command{
+	"list":     {usage: "", run: userList},
+	"describe": {usage: "<user>", run: userDescribe},
+	"create":   {usage: "<user> -password <password>", run: userCreate},
+	"drop":     {usage: "<user> [-yes]", run: userDrop},
+	"passwd":   {usage: "<user> -password <password>", run: userPasswd},
+	"grant":    {usage: "<user> -resource <db.collection> -actions <action,...>", run: userGrant},
+	"revoke":   {usage: "<user> -resource <db.collection> -actions <action,...>", run: userRevoke},
+}
+
+func userList(e *env, fs *flag.FlagSet, args []string) error {
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	res, err := e.cli.ListUser(e.ctx)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, user := range res.Users {
+		rows = append(rows, []string{user.User, user.CreateTime, formatPrivileges(user.Privileges)})
+	}
+	return e.out.print(res.Users, []string{"USER", "CREATED", "PRIVILEGES"}, rows)
+}
+
+func userDescribe(e *env, fs *flag.FlagSet, args []string) error {
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	res, err := e.cli.DescribeUser(e.ctx, tcvectordb.DescribeUserParams{User: positional[0]})
+	if err != nil {
+		return err
+	}
+	return e.out.printObject(res)
+}
+
+func userCreate(e *env, fs *flag.FlagSet, args []string) error {
+	password := fs.String("password", "", "the password")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *password == "" {
+		return fmt.Errorf("-password is required")
+	}
+	if err := e.cli.CreateUser(e.ctx, tcvectordb.CreateUserParams{User: positional[0], Password: *password}); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("user %v created", positional[0]), map[string]string{"user": positional[0]})
+}
+
+func userDrop(e *env, fs *flag.FlagSet, args []string) error {
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if err := confirm(fmt.Sprintf("drop user %v", positional[0]), *yes); err != nil {
+		return err
+	}
+	if err := e.cli.DropUser(e.ctx, tcvectordb.DropUserParams{User: positional[0]}); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("user %v dropped", positional[0]), map[string]string{"user": positional[0]})
+}
+
+func userPasswd(e *env, fs *flag.FlagSet, args []string) error {
+	password := fs.String("password", "", "the new password")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *password == "" {
+		return fmt.Errorf("-password is required")
+	}
+	if err := e.cli.ChangePassword(e.ctx, tcvectordb.ChangePasswordParams{User: positional[0], Password: *password}); err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("password of user %v changed", positional[0]), map[string]string{"user": positional[0]})
+}
+
+func userGrant(e *env, fs *flag.FlagSet, args []string) error {
+	user, privilege, err := parsePrivilege(fs, args)
+	if err != nil {
+		return err
+	}
+	err = e.cli.GrantToUser(e.ctx, tcvectordb.GrantToUserParams{User: user, Privileges: []*api_user.Privilege{privilege}})
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("granted %v on %v to user %v", strings.Join(privilege.Actions, ","), privilege.Resource, user),
+		map[string]interface{}{"user": user, "privilege": privilege})
+}
+
+func userRevoke(e *env, fs *flag.FlagSet, args []string) error {
+	user, privilege, err := parsePrivilege(fs, args)
+	if err != nil {
+		return err
+	}
+	err = e.cli.RevokeFromUser(e.ctx, tcvectordb.RevokeFromUserParams{User: user, Privileges: []*api_user.Privilege{privilege}})
+	if err != nil {
+		return err
+	}
+	return e.out.done(fmt.Sprintf("revoked %v on %v from user %v", strings.Join(privilege.Actions, ","), privilege.Resource, user),
+		map[string]interface{}{"user": user, "privilege": privilege})
+}
+
+func parsePrivilege(fs *flag.FlagSet, args []string) (string, *api_user.Privilege, error) {
+	resource := fs.String("resource", "", `the resource, such as "db1.*" or "db1.collection1"`)
+	actions := fs.String("actions", "", "the comma separated actions, such as read or readWrite")
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return "", nil, err
+	}
+	if *resource == "" || *actions == "" {
+		return "", nil, fmt.Errorf("-resource and -actions are required")
+	}
+	return positional[0], &api_user.Privilege{Resource: *resource, Actions: splitList(*actions)}, nil
+}
+
+func formatPrivileges(privileges []api_user.Privilege) string {
+	var list []string
+	for _, p := range privileges {
+		list = append(list, fmt.Sprintf("%v:%v", p.Resource, strings.Join(p.Actions, "|")))
+	}
+	return strings.Join(list, " ")
+}
@@ -0,0 +1,532 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAnnEvalSampleSize = 100
+	defaultAnnEvalTopK       = 10
+)
+
+// [AnnGroundTruth] is the way to compute the exact neighbors of the query vectors in [EvaluateAnn].
+type AnnGroundTruth string
+
+const (
+	// GroundTruthExact computes the exact neighbors on the client side over all the exported vectors
+	// of the collection, which are held in memory during the evaluation.
+	GroundTruthExact AnnGroundTruth = "exact"
+	// GroundTruthFlat takes the results of a copy of the collection with the FLAT index as the exact neighbors.
+	GroundTruthFlat AnnGroundTruth = "flat"
+)
+
+// [AnnEvalSetting] is a search setting to evaluate in [EvaluateAnn].
+//
+// Fields:
+//   - Name: (Optional) The name of the setting in the report (defaults to the search parameters).
+//   - CollectionName: (Optional) The collection to search with the setting, which is useful to compare
+//     the index parameters such as [IVFRabitQParams].Bits with copies of the collection (defaults to
+//     the evaluated collection).
+//   - Params: (Optional) The search parameters, such as Ef and Nprobe.
+type AnnEvalSetting struct {
+	Name           string
+	CollectionName string
+	Params         *SearchDocParams
+}
+
+// [AnnEvalParams] holds the parameters for evaluating the recall and latency of the searches.
+//
+// Fields:
+//   - Settings: (Required) The search settings to sweep.
+//   - QueryVectors: (Optional) The query vectors. The vectors of the collection are sampled if not set.
+//   - SampleSize: (Optional) The number of the query vectors to sample (defaults to 100).
+//   - Seed: (Optional) The seed of the sampling, which makes the evaluation repeatable.
+//   - TopK: (Optional) The number of the neighbors to evaluate the recall with (defaults to 10).
+//   - Filter: (Optional) Filter documents by [Filter] conditions in the ground truth and the searches.
+//   - GroundTruth: (Optional) The way to compute the exact neighbors (defaults to GroundTruthExact).
+//   - FlatCollectionName: (Optional) The copy of the collection with the FLAT index, required by GroundTruthFlat.
+//   - MetricType: (Optional) The metric type for GroundTruthExact (defaults to the metric type of the vector index).
+//   - Concurrency: (Optional) The number of the concurrent searches when measuring the latency and QPS (defaults to 1).
+//   - RecallTarget: (Optional) The recall target to recommend a setting with, ranging from (0, 1].
+type AnnEvalParams struct {
+	Settings           []AnnEvalSetting
+	QueryVectors       [][]float32
+	SampleSize         int
+	Seed               int64
+	TopK               int
+	Filter             *Filter
+	GroundTruth        AnnGroundTruth
+	FlatCollectionName string
+	MetricType         MetricType
+	Concurrency        int
+	RecallTarget       float64
+}
+
+// [AnnEvalSettingResult] holds the recall and latency of a search setting.
+//
+// Fields:
+//   - Name: The name of the setting.
+//   - CollectionName: The collection searched with the setting.
+//   - Ef: The ef of the setting.
+//   - Nprobe: The nprobe of the setting.
+//   - Recall: The mean recall@K over the query vectors.
+//   - MinRecall: The lowest recall@K of a query vector.
+//   - LatencyMeanMs, LatencyP50Ms, LatencyP95Ms, LatencyP99Ms: The latencies of the searches in milliseconds.
+//   - QPS: The number of the searches per second at the concurrency of the evaluation.
+//   - Errors: The number of the failed searches, which are excluded from the recall.
+type AnnEvalSettingResult struct {
+	Name           string  `json:"name"`
+	CollectionName string  `json:"collectionName"`
+	Ef             uint32  `json:"ef,omitempty"`
+	Nprobe         uint32  `json:"nprobe,omitempty"`
+	Recall         float64 `json:"recall"`
+	MinRecall      float64 `json:"minRecall"`
+	LatencyMeanMs  float64 `json:"latencyMeanMs"`
+	LatencyP50Ms   float64 `json:"latencyP50Ms"`
+	LatencyP95Ms   float64 `json:"latencyP95Ms"`
+	LatencyP99Ms   float64 `json:"latencyP99Ms"`
+	QPS            float64 `json:"qps"`
+	Errors         int     `json:"errors,omitempty"`
+}
+
+// [AnnEvalReport] holds the result of [EvaluateAnn].
+//
+// Fields:
+//   - DatabaseName: The name of the database.
+//   - CollectionName: The name of the evaluated collection.
+//   - Time: The time the evaluation started.
+//   - GroundTruth: The way the exact neighbors were computed.
+//   - TopK: The number of the neighbors the recall was evaluated with.
+//   - QueryCount: The number of the query vectors.
+//   - Concurrency: The number of the concurrent searches.
+//   - RecallTarget: The recall target of the recommendation.
+//   - Results: The results of the settings, in the order of the settings.
+//   - Recommended: The result of the setting with the lowest mean latency which meets the recall target,
+//     or nil if no setting meets it or no target is set.
+type AnnEvalReport struct {
+	DatabaseName   string                 `json:"databaseName"`
+	CollectionName string                 `json:"collectionName"`
+	Time           time.Time              `json:"time"`
+	GroundTruth    AnnGroundTruth         `json:"groundTruth"`
+	TopK           int                    `json:"topK"`
+	QueryCount     int                    `json:"queryCount"`
+	Concurrency    int                    `json:"concurrency"`
+	RecallTarget   float64                `json:"recallTarget,omitempty"`
+	Results        []AnnEvalSettingResult `json:"results"`
+	Recommended    *AnnEvalSettingResult  `json:"recommended,omitempty"`
+}
+
+// [WriteJSON] writes the report as indented JSON.
+func (r *AnnEvalReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// [WriteCSV] writes the results of the settings as CSV with a header row, one row per setting.
+// The recommended setting is marked in the last column.
+func (r *AnnEvalReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"time", "database", "collection", "setting", "setting_collection", "ef", "nprobe",
+		"top_k", "queries", "concurrency", "recall", "min_recall", "latency_mean_ms", "latency_p50_ms",
+		"latency_p95_ms", "latency_p99_ms", "qps", "errors", "recommended"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 4, 64)
+	}
+	for _, res := range r.Results {
+		recommended := r.Recommended != nil && r.Recommended.Name == res.Name
+		record := []string{r.Time.Format(time.RFC3339), r.DatabaseName, r.CollectionName, res.Name, res.CollectionName,
+			strconv.FormatUint(uint64(res.Ef), 10), strconv.FormatUint(uint64(res.Nprobe), 10),
+			strconv.Itoa(r.TopK), strconv.Itoa(r.QueryCount), strconv.Itoa(r.Concurrency),
+			formatFloat(res.Recall), formatFloat(res.MinRecall), formatFloat(res.LatencyMeanMs),
+			formatFloat(res.LatencyP50Ms), formatFloat(res.LatencyP95Ms), formatFloat(res.LatencyP99Ms),
+			formatFloat(res.QPS), strconv.Itoa(res.Errors), strconv.FormatBool(recommended)}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// [EvaluateAnn] evaluates the recall@K, latency and QPS of the search settings of a collection,
+// and recommends the cheapest setting which meets the recall target.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - param: A [AnnEvalParams] object that includes the other parameters for the evaluation.
+//     See [AnnEvalParams] for more information.
+//
+// Notes: The query vectors are sampled from the vectors of the collection, so each query finds
+// its own document among the neighbors. The searches of the settings run one after another, so
+// the latency of a setting is not affected by the others.
+//
+// Returns a pointer to a [AnnEvalReport] object or an error.
+func EvaluateAnn(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	param AnnEvalParams) (*AnnEvalReport, error) {
+	if len(param.Settings) == 0 {
+		return nil, fmt.Errorf("no search settings to evaluate")
+	}
+	if param.TopK <= 0 {
+		param.TopK = defaultAnnEvalTopK
+	}
+	if param.SampleSize <= 0 {
+		param.SampleSize = defaultAnnEvalSampleSize
+	}
+	if param.Concurrency <= 0 {
+		param.Concurrency = 1
+	}
+	if param.GroundTruth == "" {
+		param.GroundTruth = GroundTruthExact
+	}
+	if param.RecallTarget < 0 || param.RecallTarget > 1 {
+		return nil, fmt.Errorf("recall target must be in (0, 1], got %v", param.RecallTarget)
+	}
+	report := &AnnEvalReport{
+		DatabaseName:   databaseName,
+		CollectionName: collectionName,
+		Time:           time.Now(),
+		GroundTruth:    param.GroundTruth,
+		TopK:           param.TopK,
+		Concurrency:    param.Concurrency,
+		RecallTarget:   param.RecallTarget,
+	}
+
+	var (
+		queries [][]float32
+		truth   [][]string
+		err     error
+	)
+	switch param.GroundTruth {
+	case GroundTruthExact:
+		queries, truth, err = exactGroundTruth(ctx, cli, databaseName, collectionName, param)
+	case GroundTruthFlat:
+		if param.FlatCollectionName == "" {
+			return nil, fmt.Errorf("FlatCollectionName is required by ground truth %v", GroundTruthFlat)
+		}
+		queries, truth, err = flatGroundTruth(ctx, cli, databaseName, collectionName, param)
+	default:
+		return nil, fmt.Errorf("unsupported ground truth %v", param.GroundTruth)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("no query vectors to evaluate, the collection %v may be empty", collectionName)
+	}
+	report.QueryCount = len(queries)
+
+	for _, setting := range param.Settings {
+		res, err := evaluateAnnSetting(ctx, cli, databaseName, collectionName, setting, queries, truth, param)
+		if err != nil {
+			return nil, err
+		}
+		report.Results = append(report.Results, *res)
+	}
+	report.Recommended = recommendAnnSetting(report.Results, param.RecallTarget)
+	return report, nil
+}
+
+// sampleVectors samples the vectors of the collection with reservoir sampling. If keepAll is set,
+// all the documents with vectors are returned as well.
+func sampleVectors(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	param AnnEvalParams, keepAll bool) ([][]float32, []Document, error) {
+	random := rand.New(rand.NewSource(param.Seed))
+	it := NewDocumentIterator(cli, databaseName, collectionName, &DocumentIteratorParams{
+		Filter:         param.Filter,
+		OutputFields:   []string{"id"},
+		RetrieveVector: true,
+	})
+	var (
+		samples [][]float32
+		all     []Document
+		seen    int
+	)
+	for {
+		docs, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, doc := range docs {
+			if len(doc.Vector) == 0 {
+				continue
+			}
+			if keepAll {
+				all = append(all, Document{Id: doc.Id, Vector: doc.Vector})
+			}
+			if len(param.QueryVectors) != 0 {
+				continue
+			}
+			seen++
+			if len(samples) < param.SampleSize {
+				samples = append(samples, doc.Vector)
+			} else if j := random.Intn(seen); j < param.SampleSize {
+				samples[j] = doc.Vector
+			}
+		}
+	}
+	if len(param.QueryVectors) != 0 {
+		samples = param.QueryVectors
+	}
+	return samples, all, nil
+}
+
+func exactGroundTruth(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	param AnnEvalParams) ([][]float32, [][]string, error) {
+	metricType := param.MetricType
+	if metricType == "" {
+		coll, err := describeCollection(ctx, cli, databaseName, collectionName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(coll.Indexes.VectorIndex) == 0 {
+			return nil, nil, fmt.Errorf("collection %v has no vector index", collectionName)
+		}
+		metricType = coll.Indexes.VectorIndex[0].MetricType
+	}
+	queries, docs, err := sampleVectors(ctx, cli, databaseName, collectionName, param, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	truth := make([][]string, len(queries))
+	scores := make([]float64, len(docs))
+	order := make([]int, len(docs))
+	for i, query := range queries {
+		for j := range docs {
+			scores[j] = vectorSimilarity(query, docs[j].Vector, metricType)
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return scores[order[a]] > scores[order[b]]
+		})
+		k := param.TopK
+		if k > len(order) {
+			k = len(order)
+		}
+		for _, j := range order[:k] {
+			truth[i] = append(truth[i], docs[j].Id)
+		}
+	}
+	return queries, truth, nil
+}
+
+func flatGroundTruth(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	param AnnEvalParams) ([][]float32, [][]string, error) {
+	queries, _, err := sampleVectors(ctx, cli, databaseName, collectionName, param, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	truth := make([][]string, 0, len(queries))
+	for start := 0; start < len(queries); start += defaultMaxSearchVectors {
+		end := start + defaultMaxSearchVectors
+		if end > len(queries) {
+			end = len(queries)
+		}
+		res, err := cli.Search(ctx, databaseName, param.FlatCollectionName, queries[start:end], &SearchDocumentParams{
+			Filter:       param.Filter,
+			OutputFields: []string{"id"},
+			Limit:        int64(param.TopK),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("search flat collection %v failed. err: %v", param.FlatCollectionName, err)
+		}
+		for i := range queries[start:end] {
+			var ids []string
+			if i < len(res.Documents) {
+				for _, doc := range res.Documents[i] {
+					ids = append(ids, doc.Id)
+				}
+			}
+			truth = append(truth, ids)
+		}
+	}
+	return queries, truth, nil
+}
+
+func evaluateAnnSetting(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	setting AnnEvalSetting, queries [][]float32, truth [][]string, param AnnEvalParams) (*AnnEvalSettingResult, error) {
+	res := &AnnEvalSettingResult{
+		Name:           setting.Name,
+		CollectionName: setting.CollectionName,
+	}
+	if res.CollectionName == "" {
+		res.CollectionName = collectionName
+	}
+	if setting.Params != nil {
+		res.Ef = setting.Params.Ef
+		res.Nprobe = setting.Params.Nprobe
+	}
+	if res.Name == "" {
+		res.Name = fmt.Sprintf("%v ef=%v nprobe=%v", res.CollectionName, res.Ef, res.Nprobe)
+	}
+
+	latencies := make([]time.Duration, len(queries))
+	recalls := make([]float64, len(queries))
+	failed := make([]bool, len(queries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < param.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				begin := time.Now()
+				result, err := cli.Search(ctx, databaseName, res.CollectionName, queries[i:i+1], &SearchDocumentParams{
+					Filter:       param.Filter,
+					Params:       setting.Params,
+					OutputFields: []string{"id"},
+					Limit:        int64(param.TopK),
+				})
+				latencies[i] = time.Since(begin)
+				if err != nil || len(result.Documents) == 0 {
+					failed[i] = true
+					continue
+				}
+				recalls[i] = recallAtK(result.Documents[0], truth[i])
+			}
+		}()
+	}
+	for i := range queries {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start)
+
+	var (
+		total     time.Duration
+		recallSum float64
+		succeeded []time.Duration
+	)
+	res.MinRecall = 1
+	for i := range queries {
+		if failed[i] {
+			res.Errors++
+			continue
+		}
+		total += latencies[i]
+		succeeded = append(succeeded, latencies[i])
+		recallSum += recalls[i]
+		if recalls[i] < res.MinRecall {
+			res.MinRecall = recalls[i]
+		}
+	}
+	if len(succeeded) == 0 {
+		return nil, fmt.Errorf("all searches of setting %v failed", res.Name)
+	}
+	sort.Slice(succeeded, func(a, b int) bool { return succeeded[a] < succeeded[b] })
+	res.Recall = recallSum / float64(len(succeeded))
+	res.LatencyMeanMs = durationMs(total / time.Duration(len(succeeded)))
+	res.LatencyP50Ms = durationMs(percentile(succeeded, 0.50))
+	res.LatencyP95Ms = durationMs(percentile(succeeded, 0.95))
+	res.LatencyP99Ms = durationMs(percentile(succeeded, 0.99))
+	if elapsed > 0 {
+		res.QPS = float64(len(queries)) / elapsed.Seconds()
+	}
+	return res, nil
+}
+
+// recallAtK returns the fraction of the exact neighbors found by the search.
+func recallAtK(docs []Document, truth []string) float64 {
+	if len(truth) == 0 {
+		return 1
+	}
+	expected := make(map[string]bool, len(truth))
+	for _, id := range truth {
+		expected[id] = true
+	}
+	hit := 0
+	for _, doc := range docs {
+		if expected[doc.Id] {
+			hit++
+			delete(expected, doc.Id)
+		}
+	}
+	return float64(hit) / float64(len(truth))
+}
+
+// recommendAnnSetting returns the setting with the lowest mean latency which meets the recall target.
+func recommendAnnSetting(results []AnnEvalSettingResult, target float64) *AnnEvalSettingResult {
+	if target <= 0 {
+		return nil
+	}
+	var best *AnnEvalSettingResult
+	for i := range results {
+		res := &results[i]
+		if res.Recall < target {
+			continue
+		}
+		if best == nil || res.LatencyMeanMs < best.LatencyMeanMs {
+			best = res
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	recommended := *best
+	return &recommended
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
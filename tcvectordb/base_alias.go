@@ -20,6 +20,7 @@ package tcvectordb
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/alias"
 )
@@ -34,6 +35,16 @@ type AliasInterface interface {
 
 	// [DeleteAlias] deletes the alias in the database.
 	DeleteAlias(ctx context.Context, aliasName string) (result *DeleteAliasResult, err error)
+
+	// [ListAliases] retrieves all the aliases in the database and the collections they point to.
+	ListAliases(ctx context.Context) (result *ListAliasesResult, err error)
+
+	// [GetAlias] retrieves the collection that the alias points to.
+	GetAlias(ctx context.Context, aliasName string) (result *GetAliasResult, err error)
+
+	// [SwapAlias] points the alias to the collection, and returns the collection it pointed to before.
+	// It is not atomic, so the previous collection may be wrong if the alias is changed by others meanwhile.
+	SwapAlias(ctx context.Context, collectionName, aliasName string) (result *SwapAliasResult, err error)
 }
 
 type implementerAlias struct {
@@ -108,3 +119,137 @@ func (i *implementerAlias) DeleteAlias(ctx context.Context, aliasName string) (*
 	result.AffectedCount = res.AffectedCount
 	return result, nil
 }
+
+// [AliasItem] holds an alias and the collection it points to.
+//
+// Fields:
+//   - Alias: The name of the alias.
+//   - Collection: The name of the collection that the alias points to.
+type AliasItem struct {
+	Alias      string
+	Collection string
+}
+
+// [ListAliasesResult] holds the results for listing the aliases of a database.
+//
+// Fields:
+//   - Aliases: The aliases and the collections they point to. See [AliasItem] for more information.
+type ListAliasesResult struct {
+	Aliases []AliasItem
+}
+
+// [GetAliasResult] holds the results for retrieving an alias, with the collection it points to.
+// See [AliasItem] for more information.
+type GetAliasResult struct {
+	AliasItem
+}
+
+// [SwapAliasResult] holds the results for swapping an alias.
+//
+// Fields:
+//   - AffectedCount: The number of aliases affected.
+//   - PreviousCollection: The collection that the alias pointed to before, which is empty for a new alias.
+type SwapAliasResult struct {
+	AffectedCount      int
+	PreviousCollection string
+}
+
+// [ListAliases] retrieves all the aliases in the database and the collections they point to.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//
+// Notes: The name of the database is from the field of [implementerAlias].
+//
+// Returns a pointer to a [ListAliasesResult] object or an error.
+func (i *implementerAlias) ListAliases(ctx context.Context) (*ListAliasesResult, error) {
+	if i.database.IsAIDatabase() {
+		return nil, AIDbTypeError
+	}
+	req := new(alias.ListReq)
+	res := new(alias.ListRes)
+
+	req.Database = i.database.DatabaseName
+
+	err := i.Request(ctx, req, &res)
+	if err != nil {
+		return nil, err
+	}
+	result := new(ListAliasesResult)
+	for _, item := range res.Aliases {
+		if item == nil {
+			continue
+		}
+		result.Aliases = append(result.Aliases, AliasItem{Alias: item.Alias, Collection: item.Collection})
+	}
+	return result, nil
+}
+
+// [GetAlias] retrieves the collection that the alias points to.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - aliasName: The alias name to retrieve.
+//
+// Notes: The name of the database is from the field of [implementerAlias].
+//
+// Returns a pointer to a [GetAliasResult] object or an error if the alias doesn't exist.
+func (i *implementerAlias) GetAlias(ctx context.Context, aliasName string) (*GetAliasResult, error) {
+	if i.database.IsAIDatabase() {
+		return nil, AIDbTypeError
+	}
+	req := new(alias.DescribeReq)
+	res := new(alias.DescribeRes)
+
+	req.Database = i.database.DatabaseName
+	req.Alias = aliasName
+
+	err := i.Request(ctx, req, &res)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range res.Aliases {
+		if item != nil && item.Alias == aliasName {
+			return &GetAliasResult{AliasItem{Alias: item.Alias, Collection: item.Collection}}, nil
+		}
+	}
+	return nil, fmt.Errorf("alias %v does not exist in database %v", aliasName, i.database.DatabaseName)
+}
+
+// [SwapAlias] points the alias to the collection, and returns the collection it pointed to before.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - collectionName: The name of the collection that the alias will point to.
+//   - aliasName: The alias name to swap. It is created if it doesn't exist.
+//
+// Notes: It is not atomic. The previous collection is read by [ListAliases] before [SetAlias], so it may be
+// wrong if the alias is changed by others in between. The name of the database is from the field of [implementerAlias].
+//
+// Returns a pointer to a [SwapAliasResult] object or an error.
+func (i *implementerAlias) SwapAlias(ctx context.Context, collectionName, aliasName string) (*SwapAliasResult, error) {
+	return swapAlias(ctx, i, collectionName, aliasName)
+}
+
+func swapAlias(ctx context.Context, impl AliasInterface, collectionName, aliasName string) (*SwapAliasResult, error) {
+	list, err := impl.ListAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := new(SwapAliasResult)
+	for _, item := range list.Aliases {
+		if item.Alias == aliasName {
+			result.PreviousCollection = item.Collection
+			break
+		}
+	}
+	res, err := impl.SetAlias(ctx, collectionName, aliasName)
+	if err != nil {
+		return nil, err
+	}
+	result.AffectedCount = res.AffectedCount
+	return result, nil
+}
@@ -166,6 +166,9 @@ func (i *implementerCollection) CreateCollection(ctx context.Context, name strin
 	if i.database.IsAIDatabase() {
 		return nil, AIDbTypeError
 	}
+	if err := indexes.Validate(); err != nil {
+		return nil, err
+	}
 	req := new(collection.CreateReq)
 	req.Database = i.database.DatabaseName
 	req.Collection = name
@@ -475,12 +478,14 @@ func (i *implementerCollection) toCollection(collectionItem *collection.Describe
 				switch vector.IndexType {
 				case HNSW:
 					vector.Params = &HNSWParam{M: index.Params.M, EfConstruction: index.Params.EfConstruction}
+				case BIN_HNSW:
+					vector.Params = &BINHNSWParams{M: index.Params.M, EfConstruction: index.Params.EfConstruction}
 				case IVF_FLAT:
 					vector.Params = &IVFFLATParams{NList: index.Params.Nlist}
 				case IVF_PQ:
 					vector.Params = &IVFPQParams{M: index.Params.M, NList: index.Params.Nlist}
 				case IVF_SQ4, IVF_SQ8, IVF_SQ16:
-					vector.Params = &IVFSQParams{NList: index.Params.Nlist}
+					vector.Params = &IVFSQParams{NList: index.Params.Nlist, IndexType: vector.IndexType}
 				case IVF_RABITQ:
 					vector.Params = &IVFRabitQParams{NList: index.Params.Nlist, Bits: index.Params.Bits}
 				}
@@ -545,6 +550,11 @@ func optionParams(column *api.IndexColumn, v VectorIndex) {
 			column.Params.M = param.M
 			column.Params.EfConstruction = param.EfConstruction
 		}
+	case BIN_HNSW:
+		if param, ok := v.Params.(*BINHNSWParams); ok && param != nil {
+			column.Params.M = param.M
+			column.Params.EfConstruction = param.EfConstruction
+		}
 	case IVF_FLAT:
 		if param, ok := v.Params.(*IVFFLATParams); ok && param != nil {
 			column.Params.Nlist = param.NList
@@ -577,6 +587,11 @@ func optionParamsFromIndexParams(column *api.IndexColumn, v IndexParams) {
 			column.Params.M = param.M
 			column.Params.EfConstruction = param.EfConstruction
 		}
+	case *BINHNSWParams:
+		if param != nil {
+			column.Params.M = param.M
+			column.Params.EfConstruction = param.EfConstruction
+		}
 	case *IVFFLATParams:
 		if param != nil {
 			column.Params.Nlist = param.NList
@@ -590,6 +605,12 @@ func optionParamsFromIndexParams(column *api.IndexColumn, v IndexParams) {
 			column.Params.M = param.M
 			column.Params.Nlist = param.NList
 		}
+	case *IVFRabitQParams:
+		if param != nil {
+			column.Params.Nlist = param.NList
+			column.Params.Bits = param.Bits
+		}
+	case *FLATParams, *BINFLATParams, *DiskFLATParams:
 	default:
 		log.Printf("[Warning] unknown type: %v", reflect.TypeOf(v))
 	}
@@ -411,7 +411,8 @@ type UpdateDocumentParams struct {
 }
 
 type UpdateDocumentResult struct {
-	AffectedCount int
+	AffectedCount      int
+	EmbeddingExtraInfo *document.EmbeddingExtraInfo
 }
 
 // [Update] updates documents by conditions.
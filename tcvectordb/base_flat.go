@@ -42,6 +42,25 @@ import (
 	"github.com/tencentyun/cos-go-sdk-v5"
 )
 
+// [FlatDocumentInterface] provides the document apis shared by [Client], [RpcClient] and [VdbClient],
+// which the client-side helpers of this package are built on.
+type FlatDocumentInterface interface {
+	Upsert(ctx context.Context, databaseName, collectionName string, documents interface{}, params ...*UpsertDocumentParams) (result *UpsertDocumentResult, err error)
+	Query(ctx context.Context, databaseName, collectionName string, documentIds []string, params ...*QueryDocumentParams) (result *QueryDocumentResult, err error)
+	Search(ctx context.Context, databaseName, collectionName string, vectors [][]float32, params ...*SearchDocumentParams) (result *SearchDocumentResult, err error)
+	HybridSearch(ctx context.Context, databaseName, collectionName string, params HybridSearchDocumentParams) (result *SearchDocumentResult, err error)
+	FullTextSearch(ctx context.Context, databaseName, collectionName string, params FullTextSearchParams) (result *SearchDocumentResult, err error)
+	SearchById(ctx context.Context, databaseName, collectionName string, documentIds []string, params ...*SearchDocumentParams) (result *SearchDocumentResult, err error)
+	SearchByText(ctx context.Context, databaseName, collectionName string, text map[string][]string, params ...*SearchDocumentParams) (result *SearchDocumentResult, err error)
+	Delete(ctx context.Context, databaseName, collectionName string, param DeleteDocumentParams) (result *DeleteDocumentResult, err error)
+	Update(ctx context.Context, databaseName, collectionName string, param UpdateDocumentParams) (result *UpdateDocumentResult, err error)
+	Count(ctx context.Context, databaseName, collectionName string, params ...CountDocumentParams) (*CountDocumentResult, error)
+}
+
+var _ FlatDocumentInterface = &Client{}
+var _ FlatDocumentInterface = &RpcClient{}
+var _ FlatDocumentInterface = VdbClient(nil)
+
 type FlatInterface interface {
 	// [Upsert] upserts documents into a collection.
 	Upsert(ctx context.Context, databaseName, collectionName string, documents interface{}, params ...*UpsertDocumentParams) (result *UpsertDocumentResult, err error)
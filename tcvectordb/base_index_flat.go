@@ -139,6 +139,11 @@ func (i *implementerFlatIndex) RebuildIndex(ctx context.Context, databaseName, c
 //
 // Returns an error if the addition fails.
 func (i *implementerFlatIndex) AddIndex(ctx context.Context, databaseName, collectionName string, params ...*AddIndexParams) error {
+	if len(params) != 0 {
+		if err := params[0].Validate(); err != nil {
+			return err
+		}
+	}
 	req := new(index.AddReq)
 	req.Database = databaseName
 	req.Collection = collectionName
@@ -188,6 +193,11 @@ func (i *implementerFlatIndex) DropIndex(ctx context.Context, databaseName, coll
 //
 // Returns an error if the modification fails.
 func (i *implementerFlatIndex) ModifyVectorIndex(ctx context.Context, databaseName, collectionName string, param ModifyVectorIndexParam) error {
+	for idx := range param.VectorIndexes {
+		if err := param.VectorIndexes[idx].Validate(); err != nil {
+			return err
+		}
+	}
 	req := new(index.ModifyVectorIndexReq)
 	req.Database = databaseName
 	req.Collection = collectionName
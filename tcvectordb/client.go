@@ -68,6 +68,15 @@ type ClientOption struct {
 	// HTTPS configuration
 	CACert             string // CA certificate content or file path for HTTPS connections
 	InsecureSkipVerify bool   // If true, skip TLS certificate verification
+	// AutoSplit splits the oversized Search, Query, Delete and Update requests of the flat document
+	// methods into sub-requests within the server limits. It is disabled when nil.
+	AutoSplit *AutoSplitOption
+	// SearchCache caches the results of the flat read methods, and invalidates them on the writes
+	// of the client. It is disabled when nil.
+	SearchCache *SearchCacheOption
+	// ClientEmbedding embeds the texts of the collections keyed by "database/collection" on the client
+	// side. See [ClientEmbeddingConfig] for more information.
+	ClientEmbedding map[string]*ClientEmbeddingConfig
 }
 type Client struct {
 	DatabaseInterface
@@ -170,8 +179,9 @@ func newClient(url, username, key string, option ClientOption) (*Client, error)
 	flatIndexImpl := new(implementerFlatIndex)
 	flatIndexImpl.SdkClient = cli
 
-	cli.DatabaseInterface = databaseImpl
-	cli.FlatInterface = flatImpl
+	cli.FlatInterface = withSearchCache(withClientEmbedding(withAutoSplit(flatImpl, cli.option.AutoSplit),
+		cli.option.ClientEmbedding), cli.option.SearchCache)
+	cli.DatabaseInterface = withSearchCacheDatabase(databaseImpl, cli.FlatInterface)
 	cli.FlatIndexInterface = flatIndexImpl
 	return cli, nil
 }
@@ -266,6 +276,12 @@ func (c *Client) Options() ClientOption {
 	return c.option
 }
 
+// DescribeCollection retrieves information about a specific collection of the database.
+// See [Collection] for more information.
+func (c *Client) DescribeCollection(ctx context.Context, databaseName, collectionName string) (*DescribeCollectionResult, error) {
+	return c.Database(databaseName).DescribeCollection(ctx, collectionName)
+}
+
 func optionMerge(option ClientOption) ClientOption {
 	if option.Timeout == 0 {
 		option.Timeout = defaultOption.Timeout
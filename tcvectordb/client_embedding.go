@@ -0,0 +1,305 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+)
+
+const (
+	defaultClientEmbeddingBatchSize = 32
+	defaultClientEmbeddingCacheSize = 1024
+)
+
+// [ClientEmbeddingConfig] holds the embedding of a collection on the client side, which fills the vectors
+// of the upserted documents from a text field, and embeds the texts of SearchByText and HybridSearch.
+//
+// Fields:
+//   - Embedder: (Required) The [Embedder] to embed the texts.
+//   - Field: (Required) The text field of the documents to embed, such as "text".
+//   - VectorField: (Optional) The vector field to fill (defaults to "vector").
+//   - BatchSize: (Optional) The maximum number of texts in a call of the embedder (defaults to 32).
+//   - CacheSize: (Optional) The number of the recently embedded texts whose vectors are kept, so the
+//     repeated texts are not embedded again (defaults to 1024). A negative size disables the cache.
+//
+// Notes: The documents with the vector already set are upserted as they are. The collection is created
+// without the server-side [Embedding], and the dimension of the vector index must match the embedder.
+type ClientEmbeddingConfig struct {
+	Embedder    Embedder
+	Field       string
+	VectorField string
+	BatchSize   int
+	CacheSize   int
+}
+
+var _ FlatInterface = &clientEmbeddingFlatDocument{}
+
+// clientEmbeddingFlatDocument wraps the flat document methods to embed the texts of the configured
+// collections, which are keyed by "database/collection".
+type clientEmbeddingFlatDocument struct {
+	FlatInterface
+	collections map[string]*clientEmbedding
+}
+
+type clientEmbedding struct {
+	config ClientEmbeddingConfig
+	cache  *embeddingCache
+}
+
+func withClientEmbedding(flat FlatInterface, configs map[string]*ClientEmbeddingConfig) FlatInterface {
+	if len(configs) == 0 {
+		return flat
+	}
+	wrapped := &clientEmbeddingFlatDocument{FlatInterface: flat, collections: make(map[string]*clientEmbedding)}
+	for key, config := range configs {
+		if config == nil || config.Embedder == nil {
+			continue
+		}
+		e := &clientEmbedding{config: *config}
+		if e.config.VectorField == "" {
+			e.config.VectorField = "vector"
+		}
+		if e.config.BatchSize <= 0 {
+			e.config.BatchSize = defaultClientEmbeddingBatchSize
+		}
+		if e.config.CacheSize == 0 {
+			e.config.CacheSize = defaultClientEmbeddingCacheSize
+		}
+		if e.config.CacheSize > 0 {
+			e.cache = newEmbeddingCache(e.config.CacheSize)
+		}
+		wrapped.collections[key] = e
+	}
+	return wrapped
+}
+
+func (c *clientEmbeddingFlatDocument) lookup(databaseName, collectionName string) *clientEmbedding {
+	return c.collections[databaseName+"/"+collectionName]
+}
+
+func (e *clientEmbedding) embed(ctx context.Context, texts []string) ([][]float32, int64, error) {
+	return embedTexts(ctx, e.config.Embedder, e.cache, e.config.BatchSize, texts)
+}
+
+func addTokenUsed(info **document.EmbeddingExtraInfo, tokens int64) {
+	if tokens == 0 {
+		return
+	}
+	if *info == nil {
+		*info = new(document.EmbeddingExtraInfo)
+	}
+	(*info).TokenUsed += uint64(tokens)
+}
+
+// [Upsert] upserts documents into a collection, and fills the vectors of the documents from their texts first.
+func (c *clientEmbeddingFlatDocument) Upsert(ctx context.Context, databaseName, collectionName string,
+	documents interface{}, params ...*UpsertDocumentParams) (*UpsertDocumentResult, error) {
+	e := c.lookup(databaseName, collectionName)
+	if e == nil {
+		return c.FlatInterface.Upsert(ctx, databaseName, collectionName, documents, params...)
+	}
+	var texts []string
+	var tokens int64
+	switch docs := documents.(type) {
+	case []Document:
+		copied := append([]Document(nil), docs...)
+		var targets []int
+		for i, doc := range copied {
+			_, hasVector := doc.Fields[e.config.VectorField]
+			if (e.config.VectorField == "vector" && len(doc.Vector) != 0) || (e.config.VectorField != "vector" && hasVector) {
+				continue
+			}
+			if text, ok := doc.Fields[e.config.Field].Val.(string); ok {
+				texts = append(texts, text)
+				targets = append(targets, i)
+			}
+		}
+		vectors, used, err := e.embed(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		tokens = used
+		for j, i := range targets {
+			if e.config.VectorField == "vector" {
+				copied[i].Vector = vectors[j]
+				continue
+			}
+			fields := make(map[string]Field, len(copied[i].Fields)+1)
+			for k, v := range copied[i].Fields {
+				fields[k] = v
+			}
+			fields[e.config.VectorField] = Field{Val: vectors[j]}
+			copied[i].Fields = fields
+		}
+		documents = copied
+	case []map[string]interface{}:
+		copied := make([]map[string]interface{}, len(docs))
+		var targets []int
+		for i, doc := range docs {
+			copied[i] = make(map[string]interface{}, len(doc)+1)
+			for k, v := range doc {
+				copied[i][k] = v
+			}
+			if _, ok := doc[e.config.VectorField]; ok {
+				continue
+			}
+			if text, ok := doc[e.config.Field].(string); ok {
+				texts = append(texts, text)
+				targets = append(targets, i)
+			}
+		}
+		vectors, used, err := e.embed(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		tokens = used
+		for j, i := range targets {
+			copied[i][e.config.VectorField] = vectors[j]
+		}
+		documents = copied
+	}
+	result, err := c.FlatInterface.Upsert(ctx, databaseName, collectionName, documents, params...)
+	if err != nil {
+		return nil, err
+	}
+	addTokenUsed(&result.EmbeddingExtraInfo, tokens)
+	return result, nil
+}
+
+// [Update] updates documents by conditions, and embeds the updated text into the vector if the vector is not updated.
+func (c *clientEmbeddingFlatDocument) Update(ctx context.Context, databaseName, collectionName string,
+	param UpdateDocumentParams) (*UpdateDocumentResult, error) {
+	e := c.lookup(databaseName, collectionName)
+	if e == nil {
+		return c.FlatInterface.Update(ctx, databaseName, collectionName, param)
+	}
+	var text interface{}
+	var hasVector bool
+	switch fields := param.UpdateFields.(type) {
+	case map[string]Field:
+		text = fields[e.config.Field].Val
+		_, hasVector = fields[e.config.VectorField]
+	case map[string]interface{}:
+		text = fields[e.config.Field]
+		_, hasVector = fields[e.config.VectorField]
+	}
+	s, ok := text.(string)
+	if !ok || hasVector || (e.config.VectorField == "vector" && len(param.UpdateVector) != 0) {
+		return c.FlatInterface.Update(ctx, databaseName, collectionName, param)
+	}
+	vectors, tokens, err := e.embed(ctx, []string{s})
+	if err != nil {
+		return nil, err
+	}
+	if e.config.VectorField == "vector" {
+		param.UpdateVector = vectors[0]
+	} else {
+		switch fields := param.UpdateFields.(type) {
+		case map[string]Field:
+			copied := make(map[string]Field, len(fields)+1)
+			for k, v := range fields {
+				copied[k] = v
+			}
+			copied[e.config.VectorField] = Field{Val: vectors[0]}
+			param.UpdateFields = copied
+		case map[string]interface{}:
+			copied := make(map[string]interface{}, len(fields)+1)
+			for k, v := range fields {
+				copied[k] = v
+			}
+			copied[e.config.VectorField] = vectors[0]
+			param.UpdateFields = copied
+		}
+	}
+	result, err := c.FlatInterface.Update(ctx, databaseName, collectionName, param)
+	if err != nil {
+		return nil, err
+	}
+	addTokenUsed(&result.EmbeddingExtraInfo, tokens)
+	return result, nil
+}
+
+// [SearchByText] embeds the texts on the client side, and returns the most similar topK vectors of them.
+// The texts must be of the configured field.
+func (c *clientEmbeddingFlatDocument) SearchByText(ctx context.Context, databaseName, collectionName string,
+	text map[string][]string, params ...*SearchDocumentParams) (*SearchDocumentResult, error) {
+	e := c.lookup(databaseName, collectionName)
+	if e == nil {
+		return c.FlatInterface.SearchByText(ctx, databaseName, collectionName, text, params...)
+	}
+	texts, ok := text[e.config.Field]
+	if !ok {
+		return nil, fmt.Errorf("search by text failed. err: the texts of the embedding field %v are missing", e.config.Field)
+	}
+	vectors, tokens, err := e.embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.FlatInterface.Search(ctx, databaseName, collectionName, vectors, params...)
+	if err != nil {
+		return nil, err
+	}
+	addTokenUsed(&result.EmbeddingExtraInfo, tokens)
+	return result, nil
+}
+
+// [HybridSearch] embeds the texts of the ann params of the vector field on the client side, and retrieves
+// both dense and sparse vectors to return the most similar topK vectors.
+func (c *clientEmbeddingFlatDocument) HybridSearch(ctx context.Context, databaseName, collectionName string,
+	params HybridSearchDocumentParams) (*SearchDocumentResult, error) {
+	e := c.lookup(databaseName, collectionName)
+	if e == nil {
+		return c.FlatInterface.HybridSearch(ctx, databaseName, collectionName, params)
+	}
+	var tokens int64
+	annParams := make([]*AnnParam, 0, len(params.AnnParams))
+	for _, ann := range params.AnnParams {
+		if ann == nil {
+			annParams = append(annParams, ann)
+			continue
+		}
+		fieldName := ann.FieldName
+		if fieldName == "" {
+			fieldName = "vector"
+		}
+		text, ok := ann.Data.(string)
+		if !ok || fieldName != e.config.VectorField {
+			annParams = append(annParams, ann)
+			continue
+		}
+		vectors, used, err := e.embed(ctx, []string{text})
+		if err != nil {
+			return nil, err
+		}
+		tokens += used
+		copied := *ann
+		copied.Data = vectors[0]
+		annParams = append(annParams, &copied)
+	}
+	params.AnnParams = annParams
+	result, err := c.FlatInterface.HybridSearch(ctx, databaseName, collectionName, params)
+	if err != nil {
+		return nil, err
+	}
+	addTokenUsed(&result.EmbeddingExtraInfo, tokens)
+	return result, nil
+}
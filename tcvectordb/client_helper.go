@@ -0,0 +1,63 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"fmt"
+)
+
+// [CollectionDescriber] describes a collection by the names of the database and the collection,
+// implemented by [Client], [RpcClient], [RpcClientPool] and [VdbClient].
+type CollectionDescriber interface {
+	DescribeCollection(ctx context.Context, databaseName, collectionName string) (*DescribeCollectionResult, error)
+}
+
+var (
+	_ CollectionDescriber = &Client{}
+	_ CollectionDescriber = &RpcClient{}
+	_ CollectionDescriber = &RpcClientPool{}
+)
+
+// describeCollection describes the collection with the client, which may be a [Client], [RpcClient] or [VdbClient].
+func describeCollection(ctx context.Context, cli interface{}, databaseName, collectionName string) (*DescribeCollectionResult, error) {
+	if c, ok := cli.(CollectionDescriber); ok {
+		return c.DescribeCollection(ctx, databaseName, collectionName)
+	}
+	db, err := databaseOf(cli, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	return db.DescribeCollection(ctx, collectionName)
+}
+
+// databaseOf returns the [Database] of the client, which may be a [Client], [RpcClient] or [RpcClientPool].
+func databaseOf(cli interface{}, databaseName string) (*Database, error) {
+	switch c := cli.(type) {
+	case *RpcClientPool:
+		client, err := c.getRpcClient()
+		if err != nil {
+			return nil, fmt.Errorf("get rpc client failed. err: %v", err.Error())
+		}
+		return client.Database(databaseName), nil
+	case interface{ Database(name string) *Database }:
+		return c.Database(databaseName), nil
+	}
+	return nil, fmt.Errorf("the client %T does not support the database apis", cli)
+}
@@ -157,3 +157,10 @@ const (
 	RerankWeighted RerankMethod = "weighted"
 	RerankRrf      RerankMethod = "rrf"
 )
+
+const (
+	IndexStatusReady    = "ready"
+	IndexStatusTraining = "training"
+	IndexStatusBuilding = "building"
+	IndexStatusFailed   = "failed"
+)
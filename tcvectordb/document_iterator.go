@@ -0,0 +1,110 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"io"
+)
+
+const defaultIteratorBatchSize = 500
+
+// [DocumentIteratorParams] holds the parameters for scanning documents in a collection.
+//
+// Fields:
+//   - Filter: (Optional) Filter documents by [Filter] conditions before returning the results.
+//   - OutputFields: (Optional) Return columns specified by the list of column names (defaults to all columns).
+//   - RetrieveVector: (Optional) Specify whether to return vector values or not (defaults to false).
+//   - BatchSize: (Optional) The number of documents returned by each call of Next (defaults to 500).
+type DocumentIteratorParams struct {
+	Filter         *Filter
+	OutputFields   []string
+	RetrieveVector bool
+	BatchSize      int64
+}
+
+// [DocumentIterator] scans the documents of a collection batch by batch with [Query].
+//
+// Notes: The documents are paged by offset without a stable order, so the documents written during the
+// scan may be missed or returned twice, and a deleted document can make other documents missed. Keep the
+// collection quiescent while scanning it when every document must be returned once.
+type DocumentIterator struct {
+	cli            FlatDocumentInterface
+	databaseName   string
+	collectionName string
+	params         DocumentIteratorParams
+	offset         int64
+	done           bool
+}
+
+// [NewDocumentIterator] creates a [DocumentIterator] to scan the documents of a collection.
+//
+// Parameters:
+//   - cli: The client to query with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - params: A pointer to a [DocumentIteratorParams] object that includes the other parameters for scanning documents.
+//     See [DocumentIteratorParams] for more information.
+//
+// Returns a pointer to a [DocumentIterator] object.
+func NewDocumentIterator(cli FlatDocumentInterface, databaseName, collectionName string,
+	params ...*DocumentIteratorParams) *DocumentIterator {
+	it := &DocumentIterator{
+		cli:            cli,
+		databaseName:   databaseName,
+		collectionName: collectionName,
+	}
+	if len(params) != 0 && params[0] != nil {
+		it.params = *params[0]
+	}
+	if it.params.BatchSize <= 0 {
+		it.params.BatchSize = defaultIteratorBatchSize
+	}
+	return it
+}
+
+// [Next] returns the next batch of documents, and io.EOF after all the documents are returned.
+func (it *DocumentIterator) Next(ctx context.Context) ([]Document, error) {
+	if it.done {
+		return nil, io.EOF
+	}
+	res, err := it.cli.Query(ctx, it.databaseName, it.collectionName, nil, &QueryDocumentParams{
+		Filter:         it.params.Filter,
+		RetrieveVector: it.params.RetrieveVector,
+		OutputFields:   it.params.OutputFields,
+		Offset:         it.offset,
+		Limit:          it.params.BatchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	it.offset += int64(len(res.Documents))
+	if int64(len(res.Documents)) < it.params.BatchSize {
+		it.done = true
+	}
+	if len(res.Documents) == 0 {
+		return nil, io.EOF
+	}
+	return res.Documents, nil
+}
+
+// [Offset] returns the number of documents returned so far.
+func (it *DocumentIterator) Offset() int64 {
+	return it.offset
+}
@@ -0,0 +1,235 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/ai_service"
+)
+
+const (
+	defaultEmbedAllBatchSize     = 32
+	defaultEmbedAllBatchTokens   = 8192
+	defaultEmbedAllConcurrency   = 4
+	defaultEmbedAllMaxRetries    = 3
+	defaultEmbedAllRetryInterval = time.Second
+	defaultEmbedAllCharsPerToken = 4
+)
+
+// [EmbedAllOption] holds the options for embedding a large number of texts by [EmbedAll].
+//
+// Fields:
+//   - ModelParams: (Optional) A pointer to ModelParams object containing additional model-specific parameters.
+//   - BatchSize: (Optional) The maximum number of texts in an [Embedding] call (defaults to 32).
+//   - MaxBatchTokens: (Optional) The maximum number of the estimated tokens in an [Embedding] call
+//     (defaults to 8192). A text estimated over the limit is embedded alone.
+//   - Concurrency: (Optional) The maximum number of the concurrent [Embedding] calls (defaults to 4).
+//   - MaxRetries: (Optional) The maximum number of times a failed batch is retried (defaults to 3).
+//     A negative number disables the retries.
+//   - RetryInterval: (Optional) The interval before the first retry, which is doubled for each of
+//     the next retries (defaults to 1s).
+type EmbedAllOption struct {
+	ModelParams    *ai_service.ModelParams
+	BatchSize      int
+	MaxBatchTokens int
+	Concurrency    int
+	MaxRetries     int
+	RetryInterval  time.Duration
+}
+
+// [EmbedAll] embeds any number of texts using the specified model, by splitting them into batches by the
+// number of texts and the estimated tokens, and embedding the batches concurrently.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to embed with, such as [Client], [RpcClient] or [VdbClient].
+//   - model: The name of the embedding model to use for generating vectors.
+//   - texts: The list of text strings to be embedded into vectors.
+//   - option: (Optional) A pointer to an [EmbedAllOption] object. See [EmbedAllOption] for more information.
+//
+// Notes: The failed batches are retried, and the other batches are canceled once a batch fails after
+// the retries. The tokens are estimated from the lengths of the texts, one token for each non-ASCII
+// character and for each 4 ASCII characters.
+//
+// Returns a pointer to an [EmbeddingResult] object with the vectors in the order of the texts and the
+// total tokens used, or an error.
+func EmbedAll(ctx context.Context, cli FlatInterface, model string, texts []string,
+	option ...*EmbedAllOption) (*EmbeddingResult, error) {
+	opt := EmbedAllOption{}
+	if len(option) != 0 && option[0] != nil {
+		opt = *option[0]
+	}
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = defaultEmbedAllBatchSize
+	}
+	if opt.MaxBatchTokens <= 0 {
+		opt.MaxBatchTokens = defaultEmbedAllBatchTokens
+	}
+	if opt.Concurrency <= 0 {
+		opt.Concurrency = defaultEmbedAllConcurrency
+	}
+	if opt.MaxRetries == 0 {
+		opt.MaxRetries = defaultEmbedAllMaxRetries
+	}
+	if opt.RetryInterval <= 0 {
+		opt.RetryInterval = defaultEmbedAllRetryInterval
+	}
+
+	batches := splitEmbeddingBatches(texts, opt.BatchSize, opt.MaxBatchTokens)
+	results := make([]*EmbeddingResult, len(batches))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	// A fixed pool of workers takes the batches in turn, so a large input does not start a goroutine per batch.
+	workers := opt.Concurrency
+	if workers > len(batches) {
+		workers = len(batches)
+	}
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				result, err := embedBatch(ctx, cli, model, texts[batches[i][0]:batches[i][1]], &opt)
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("embed texts [%v, %v) failed. err: %v", batches[i][0], batches[i][1], err)
+						cancel()
+					})
+					continue
+				}
+				results[i] = result
+			}
+		}()
+	}
+	for i := range batches {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	merged := new(EmbeddingResult)
+	for i, result := range results {
+		merged.TokenUsed += result.TokenUsed
+		if len(result.Vectors) != 0 {
+			if merged.Vectors == nil {
+				merged.Vectors = make([][]float32, len(texts))
+			}
+			copy(merged.Vectors[batches[i][0]:], result.Vectors)
+		}
+		if len(result.SparseVectors) != 0 {
+			if merged.SparseVectors == nil {
+				merged.SparseVectors = make([]map[string]float32, len(texts))
+			}
+			copy(merged.SparseVectors[batches[i][0]:], result.SparseVectors)
+		}
+	}
+	return merged, nil
+}
+
+// embedBatch embeds a batch of texts, and retries with an exponential backoff when it fails.
+func embedBatch(ctx context.Context, cli FlatInterface, model string, texts []string,
+	opt *EmbedAllOption) (*EmbeddingResult, error) {
+	interval := opt.RetryInterval
+	for retry := 0; ; retry++ {
+		result, err := cli.Embedding(ctx, EmbeddingParams{
+			Model:       model,
+			ModelParams: opt.ModelParams,
+			DataType:    "text",
+			Data:        texts,
+		})
+		if err == nil {
+			if (len(result.Vectors) == 0 || len(result.Vectors) == len(texts)) &&
+				(len(result.SparseVectors) == 0 || len(result.SparseVectors) == len(texts)) {
+				return result, nil
+			}
+			err = fmt.Errorf("%v dense and %v sparse vectors returned for %v texts",
+				len(result.Vectors), len(result.SparseVectors), len(texts))
+		}
+		if retry >= opt.MaxRetries || ctx.Err() != nil || errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+}
+
+// splitEmbeddingBatches splits the texts into the ranges [start, end) of at most batchSize texts and
+// maxTokens estimated tokens.
+func splitEmbeddingBatches(texts []string, batchSize, maxTokens int) [][2]int {
+	var batches [][2]int
+	start, tokens := 0, 0
+	for i, text := range texts {
+		estimated := estimateTokens(text)
+		if i > start && (i-start >= batchSize || tokens+estimated > maxTokens) {
+			batches = append(batches, [2]int{start, i})
+			start, tokens = i, 0
+		}
+		tokens += estimated
+	}
+	if start < len(texts) {
+		batches = append(batches, [2]int{start, len(texts)})
+	}
+	return batches
+}
+
+// estimateTokens estimates the tokens of a text, counting a token for each non-ASCII character,
+// such as a Chinese character, and for each 4 ASCII characters.
+func estimateTokens(text string) int {
+	ascii, others := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			others++
+		}
+	}
+	return others + (ascii+defaultEmbedAllCharsPerToken-1)/defaultEmbedAllCharsPerToken
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/ai_service"
+)
+
+// [Embedder] embeds texts into dense vectors on the client side, such as with a model served by
+// the vectordb instance, an OpenAI-compatible endpoint, or a local sidecar.
+type Embedder interface {
+	// Embed returns the vectors of the texts in the same order, and the tokens used.
+	Embed(ctx context.Context, texts []string) (*EmbeddingResult, error)
+}
+
+// [ServerEmbedder] is an [Embedder] calling the Embedding api of the vectordb instance.
+//
+// Fields:
+//   - Client: (Required) The client to call Embedding, such as a [Client] or a [RpcClient].
+//   - Model: (Required) The name of the embedding model, such as "bge-base-zh".
+//   - ModelParams: (Optional) The model-specific parameters.
+type ServerEmbedder struct {
+	Client      FlatInterface
+	Model       string
+	ModelParams *ai_service.ModelParams
+}
+
+// [Embed] embeds the texts with the model of the instance.
+func (s *ServerEmbedder) Embed(ctx context.Context, texts []string) (*EmbeddingResult, error) {
+	result, err := s.Client.Embedding(ctx, EmbeddingParams{
+		Model:       s.Model,
+		ModelParams: s.ModelParams,
+		DataType:    "text",
+		Data:        texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Vectors) != len(texts) {
+		return nil, fmt.Errorf("embedding returned %v vectors for %v texts", len(result.Vectors), len(texts))
+	}
+	return result, nil
+}
+
+// [OpenAIEmbedder] is an [Embedder] calling an OpenAI-compatible embeddings endpoint.
+//
+// Fields:
+//   - BaseURL: (Required) The base url of the api, such as "https://api.openai.com/v1", to which
+//     "/embeddings" is appended.
+//   - APIKey: (Optional) The api key sent as the bearer token.
+//   - Model: (Required) The name of the embedding model.
+//   - Dimensions: (Optional) The dimension of the vectors, for the models supporting shortened vectors.
+//   - HTTPClient: (Optional) The http client to send the requests (defaults to a client with a timeout of 1 minute).
+type OpenAIEmbedder struct {
+	BaseURL    string
+	APIKey     string
+	Model      string
+	Dimensions int
+	HTTPClient *http.Client
+}
+
+type openAIEmbeddingReq struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type openAIEmbeddingRes struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Usage struct {
+		TotalTokens int64 `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+var defaultEmbedderHTTPClient = &http.Client{Timeout: time.Minute}
+
+// [Embed] embeds the texts with the endpoint.
+func (o *OpenAIEmbedder) Embed(ctx context.Context, texts []string) (*EmbeddingResult, error) {
+	body, err := json.Marshal(&openAIEmbeddingReq{Model: o.Model, Input: texts, Dimensions: o.Dimensions})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(o.BaseURL, "/")+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+	client := o.HTTPClient
+	if client == nil {
+		client = defaultEmbedderHTTPClient
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	res := new(openAIEmbeddingRes)
+	if err := json.Unmarshal(data, res); err != nil || response.StatusCode/100 != 2 {
+		if res.Error != nil {
+			return nil, fmt.Errorf("embedding failed. code: %d, message: %s", response.StatusCode, res.Error.Message)
+		}
+		return nil, fmt.Errorf("embedding failed. code: %d, body: %s", response.StatusCode, data)
+	}
+	if len(res.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding returned %v vectors for %v texts", len(res.Data), len(texts))
+	}
+	sort.SliceStable(res.Data, func(i, j int) bool { return res.Data[i].Index < res.Data[j].Index })
+	result := &EmbeddingResult{TokenUsed: res.Usage.TotalTokens}
+	for _, item := range res.Data {
+		result.Vectors = append(result.Vectors, item.Embedding)
+	}
+	return result, nil
+}
+
+// embeddingCache keeps the vectors of the recently embedded texts.
+type embeddingCache struct {
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	vectors map[string]*list.Element
+}
+
+type embeddingCacheItem struct {
+	text   string
+	vector []float32
+}
+
+func newEmbeddingCache(size int) *embeddingCache {
+	return &embeddingCache{size: size, lru: list.New(), vectors: make(map[string]*list.Element)}
+}
+
+func (c *embeddingCache) get(text string) ([]float32, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.vectors[text]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*embeddingCacheItem).vector, true
+}
+
+func (c *embeddingCache) put(text string, vector []float32) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.vectors[text]; ok {
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.vectors[text] = c.lru.PushFront(&embeddingCacheItem{text: text, vector: vector})
+	for c.lru.Len() > c.size {
+		item := c.lru.Remove(c.lru.Back()).(*embeddingCacheItem)
+		delete(c.vectors, item.text)
+	}
+}
+
+// embedTexts embeds the texts missing from the cache in batches of batchSize, and returns the vectors
+// of all the texts with the tokens used. The same texts are embedded once.
+func embedTexts(ctx context.Context, embedder Embedder, cache *embeddingCache, batchSize int,
+	texts []string) ([][]float32, int64, error) {
+	vectors := make([][]float32, len(texts))
+	positions := make(map[string][]int)
+	var missing []string
+	for i, text := range texts {
+		if vector, ok := cache.get(text); ok {
+			vectors[i] = vector
+			continue
+		}
+		if _, ok := positions[text]; !ok {
+			missing = append(missing, text)
+		}
+		positions[text] = append(positions[text], i)
+	}
+	var tokens int64
+	for start := 0; start < len(missing); start += batchSize {
+		end := start + batchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+		result, err := embedder.Embed(ctx, missing[start:end])
+		if err != nil {
+			return nil, tokens, fmt.Errorf("embed texts failed. err: %v", err)
+		}
+		if len(result.Vectors) != end-start {
+			return nil, tokens, fmt.Errorf("embed texts failed. err: %v vectors returned for %v texts", len(result.Vectors), end-start)
+		}
+		tokens += result.TokenUsed
+		for i, text := range missing[start:end] {
+			cache.put(text, result.Vectors[i])
+			for _, pos := range positions[text] {
+				vectors[pos] = result.Vectors[i]
+			}
+		}
+	}
+	return vectors, tokens, nil
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eval
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultK           = 10
+	defaultConcurrency = 4
+)
+
+// [Qrels] holds the relevance judgments, which map the query ids to the grades of the relevant
+// document ids. A grade of 0 or below means the document is judged not relevant.
+type Qrels map[string]map[string]int
+
+// [Add] adds the grade of a document for a query.
+func (q Qrels) Add(queryId, documentId string, grade int) {
+	grades, ok := q[queryId]
+	if !ok {
+		grades = make(map[string]int)
+		q[queryId] = grades
+	}
+	grades[documentId] = grade
+}
+
+// [ReadQrels] reads the relevance judgments in the TREC format, with one "query-id iteration document-id grade"
+// line per judgment. Empty lines and lines starting with "#" are skipped.
+func ReadQrels(r io.Reader) (Qrels, error) {
+	qrels := make(Qrels)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("qrels line %v: expected 4 fields, got %v", line, len(fields))
+		}
+		grade, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("qrels line %v: invalid grade %v", line, fields[3])
+		}
+		qrels.Add(fields[0], fields[2], grade)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return qrels, nil
+}
+
+// [Config] is a retrieval configuration to evaluate.
+//
+// Fields:
+//   - Name: (Required) The name of the configuration in the report.
+//   - Retriever: (Required) The retriever of the configuration, such as [NewHybridSearchRetriever].
+type Config struct {
+	Name      string
+	Retriever Retriever
+}
+
+// [EvaluateParams] holds the parameters for evaluating the retrieval quality.
+//
+// Fields:
+//   - K: (Optional) The cutoff of the rankings, which is also the limit of the retrievals (defaults to 10).
+//   - RelevanceThreshold: (Optional) The lowest grade of a relevant document for MRR, MAP and recall (defaults to 1).
+//     nDCG uses the grades directly.
+//   - Concurrency: (Optional) The number of the concurrent queries of a configuration (defaults to 4).
+type EvaluateParams struct {
+	K                  int
+	RelevanceThreshold int
+	Concurrency        int
+}
+
+// [QueryResult] holds the metrics of a query under a configuration.
+type QueryResult struct {
+	QueryId   string   `json:"queryId"`
+	NDCG      float64  `json:"ndcg"`
+	RR        float64  `json:"rr"`
+	AP        float64  `json:"ap"`
+	Recall    float64  `json:"recall"`
+	Retrieved []string `json:"retrieved,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// [ConfigResult] holds the metrics of a configuration, averaged over the queries.
+// The failed queries score 0 and are counted in Errors.
+type ConfigResult struct {
+	Name    string        `json:"name"`
+	NDCG    float64       `json:"ndcg"`
+	MRR     float64       `json:"mrr"`
+	MAP     float64       `json:"map"`
+	Recall  float64       `json:"recall"`
+	Errors  int           `json:"errors,omitempty"`
+	Queries []QueryResult `json:"queries"`
+}
+
+// [Report] holds the result of [Evaluate].
+//
+// Fields:
+//   - K: The cutoff of the rankings.
+//   - QueryCount: The number of the evaluated queries.
+//   - SkippedQueries: The ids of the queries without any relevant document in the qrels, which are not evaluated.
+//   - Results: The results of the configurations, in the order of the configurations.
+type Report struct {
+	K              int            `json:"k"`
+	QueryCount     int            `json:"queryCount"`
+	SkippedQueries []string       `json:"skippedQueries,omitempty"`
+	Results        []ConfigResult `json:"results"`
+}
+
+// [Best] returns the result of the configuration with the highest nDCG, or nil if there is no result.
+func (r *Report) Best() *ConfigResult {
+	var best *ConfigResult
+	for i := range r.Results {
+		if best == nil || r.Results[i].NDCG > best.NDCG {
+			best = &r.Results[i]
+		}
+	}
+	return best
+}
+
+// [WriteJSON] writes the report with the per-query breakdown as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// [WriteCSV] writes the metrics as CSV with a header row. Each configuration has a row with the query
+// "*" for its averages, followed by a row per query if perQuery is set.
+func (r *Report) WriteCSV(w io.Writer, perQuery bool) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"config", "query", "k", "ndcg", "mrr", "map", "recall", "error"}); err != nil {
+		return err
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 4, 64)
+	}
+	k := strconv.Itoa(r.K)
+	for _, res := range r.Results {
+		record := []string{res.Name, "*", k, formatFloat(res.NDCG), formatFloat(res.MRR), formatFloat(res.MAP),
+			formatFloat(res.Recall), ""}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		if !perQuery {
+			continue
+		}
+		for _, q := range res.Queries {
+			record := []string{res.Name, q.QueryId, k, formatFloat(q.NDCG), formatFloat(q.RR), formatFloat(q.AP),
+				formatFloat(q.Recall), q.Error}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// [Evaluate] runs the queries through the configurations, and computes nDCG@K, MRR, MAP and recall@K
+// of each configuration against the relevance judgments.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - queries: The queries to evaluate.
+//   - qrels: The relevance judgments of the queries.
+//   - configs: The retrieval configurations to evaluate.
+//   - params: A pointer to a [EvaluateParams] object that includes the other parameters for the evaluation.
+//     See [EvaluateParams] for more information.
+//
+// Notes: The queries without any relevant document in the qrels are skipped, as they score 0 with
+// any configuration. A failed query is recorded in its [QueryResult] rather than failing the evaluation.
+//
+// Returns a pointer to a [Report] object or an error.
+func Evaluate(ctx context.Context, queries []Query, qrels Qrels, configs []Config,
+	params ...*EvaluateParams) (*Report, error) {
+	param := EvaluateParams{}
+	if len(params) != 0 && params[0] != nil {
+		param = *params[0]
+	}
+	if param.K <= 0 {
+		param.K = defaultK
+	}
+	if param.RelevanceThreshold <= 0 {
+		param.RelevanceThreshold = 1
+	}
+	if param.Concurrency <= 0 {
+		param.Concurrency = defaultConcurrency
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no configuration to evaluate")
+	}
+	for _, config := range configs {
+		if config.Retriever == nil {
+			return nil, fmt.Errorf("configuration %v has no retriever", config.Name)
+		}
+	}
+
+	report := &Report{K: param.K}
+	var judged []Query
+	for _, query := range queries {
+		if countRelevant(qrels[query.Id], param.RelevanceThreshold) == 0 {
+			report.SkippedQueries = append(report.SkippedQueries, query.Id)
+			continue
+		}
+		judged = append(judged, query)
+	}
+	if len(judged) == 0 {
+		return nil, fmt.Errorf("no query has relevant documents in the qrels")
+	}
+	report.QueryCount = len(judged)
+
+	for _, config := range configs {
+		res := evaluateConfig(ctx, config, judged, qrels, param)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report, nil
+}
+
+func evaluateConfig(ctx context.Context, config Config, queries []Query, qrels Qrels, param EvaluateParams) ConfigResult {
+	results := make([]QueryResult, len(queries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < param.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				query := queries[i]
+				result := QueryResult{QueryId: query.Id}
+				retrieved, err := config.Retriever.Retrieve(ctx, query, param.K)
+				if err != nil {
+					result.Error = err.Error()
+					results[i] = result
+					continue
+				}
+				grades := qrels[query.Id]
+				result.Retrieved = retrieved
+				result.NDCG = NDCG(retrieved, grades, param.K)
+				result.RR = ReciprocalRank(retrieved, grades, param.K, param.RelevanceThreshold)
+				result.AP = AveragePrecision(retrieved, grades, param.K, param.RelevanceThreshold)
+				result.Recall = Recall(retrieved, grades, param.K, param.RelevanceThreshold)
+				results[i] = result
+			}
+		}()
+	}
+	for i := range queries {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	res := ConfigResult{Name: config.Name, Queries: results}
+	for _, q := range results {
+		if q.Error != "" {
+			res.Errors++
+		}
+		res.NDCG += q.NDCG
+		res.MRR += q.RR
+		res.MAP += q.AP
+		res.Recall += q.Recall
+	}
+	n := float64(len(results))
+	res.NDCG /= n
+	res.MRR /= n
+	res.MAP /= n
+	res.Recall /= n
+	return res
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eval
+
+import (
+	"math"
+	"sort"
+)
+
+// [NDCG] returns the normalized discounted cumulative gain of the top k retrieved documents, with the
+// gain 2^grade-1 and the discount log2(rank+1). It returns 0 if there is no relevant document.
+//
+// Parameters:
+//   - retrieved: The ids of the retrieved documents in rank order.
+//   - grades: The relevance grades of the judged documents by their ids.
+//   - k: The cutoff of the ranking. All retrieved documents are used if k <= 0.
+func NDCG(retrieved []string, grades map[string]int, k int) float64 {
+	retrieved = cutoff(retrieved, k)
+	var dcg float64
+	seen := make(map[string]bool, len(retrieved))
+	for i, id := range retrieved {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		dcg += gain(grades[id]) / math.Log2(float64(i+2))
+	}
+
+	ideal := make([]int, 0, len(grades))
+	for _, grade := range grades {
+		if grade > 0 {
+			ideal = append(ideal, grade)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ideal)))
+	n := len(retrieved)
+	if k > 0 {
+		n = k
+	}
+	var idcg float64
+	for i, grade := range ideal {
+		if i >= n {
+			break
+		}
+		idcg += gain(grade) / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+	return dcg / idcg
+}
+
+// [ReciprocalRank] returns 1/rank of the first relevant document in the top k retrieved documents,
+// or 0 if none is relevant. A document is relevant if its grade is at least threshold.
+func ReciprocalRank(retrieved []string, grades map[string]int, k, threshold int) float64 {
+	for i, id := range cutoff(retrieved, k) {
+		if relevant(grades, id, threshold) {
+			return 1 / float64(i+1)
+		}
+	}
+	return 0
+}
+
+// [AveragePrecision] returns the average of the precisions at the ranks of the relevant documents in
+// the top k retrieved documents, divided by the smaller of k and the number of the relevant documents,
+// so that a perfect ranking scores 1. A document is relevant if its grade is at least threshold.
+func AveragePrecision(retrieved []string, grades map[string]int, k, threshold int) float64 {
+	total := countRelevant(grades, threshold)
+	if k > 0 && k < total {
+		total = k
+	}
+	if total == 0 {
+		return 0
+	}
+	var (
+		hits int
+		sum  float64
+	)
+	seen := make(map[string]bool, len(retrieved))
+	for i, id := range cutoff(retrieved, k) {
+		if seen[id] || !relevant(grades, id, threshold) {
+			continue
+		}
+		seen[id] = true
+		hits++
+		sum += float64(hits) / float64(i+1)
+	}
+	return sum / float64(total)
+}
+
+// [Recall] returns the fraction of the relevant documents found in the top k retrieved documents.
+// A document is relevant if its grade is at least threshold.
+func Recall(retrieved []string, grades map[string]int, k, threshold int) float64 {
+	total := countRelevant(grades, threshold)
+	if total == 0 {
+		return 0
+	}
+	hits := 0
+	seen := make(map[string]bool, len(retrieved))
+	for _, id := range cutoff(retrieved, k) {
+		if seen[id] || !relevant(grades, id, threshold) {
+			continue
+		}
+		seen[id] = true
+		hits++
+	}
+	return float64(hits) / float64(total)
+}
+
+func cutoff(retrieved []string, k int) []string {
+	if k > 0 && len(retrieved) > k {
+		return retrieved[:k]
+	}
+	return retrieved
+}
+
+func gain(grade int) float64 {
+	if grade <= 0 {
+		return 0
+	}
+	return math.Pow(2, float64(grade)) - 1
+}
+
+func relevant(grades map[string]int, id string, threshold int) bool {
+	grade, ok := grades[id]
+	return ok && grade >= threshold
+}
+
+func countRelevant(grades map[string]int, threshold int) int {
+	n := 0
+	for _, grade := range grades {
+		if grade >= threshold {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/encoder"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+// [Query] is a query of the evaluation. The retrievers use the fields they need, such as the
+// Vector for [NewSearchRetriever] and the Text for [NewSearchByTextRetriever].
+//
+// Fields:
+//   - Id: (Required) The id of the query, which is the key of the query in the [Qrels].
+//   - Text: (Optional) The text of the query.
+//   - Vector: (Optional) The dense vector of the query.
+//   - SparseVector: (Optional) The sparse vector of the query. It is encoded from the Text by the
+//     encoder of the retriever if not set.
+type Query struct {
+	Id           string
+	Text         string
+	Vector       []float32
+	SparseVector []encoder.SparseVecItem
+}
+
+// [Retriever] retrieves the ids of the documents for a query in rank order.
+type Retriever interface {
+	Retrieve(ctx context.Context, query Query, limit int) ([]string, error)
+}
+
+// [RetrieverFunc] is an adapter to use a function as a [Retriever].
+type RetrieverFunc func(ctx context.Context, query Query, limit int) ([]string, error)
+
+// [Retrieve] calls f(ctx, query, limit).
+func (f RetrieverFunc) Retrieve(ctx context.Context, query Query, limit int) ([]string, error) {
+	return f(ctx, query, limit)
+}
+
+// [NewSearchRetriever] creates a [Retriever] with [tcvectordb.FlatDocumentInterface].Search by the Vector of the query.
+//
+// Parameters:
+//   - cli: The client to search with, such as [tcvectordb.Client] or [tcvectordb.RpcClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - params: (Optional) The parameters of the search, such as Filter and Params. The Limit is set by the evaluation.
+func NewSearchRetriever(cli tcvectordb.FlatDocumentInterface, databaseName, collectionName string,
+	params *tcvectordb.SearchDocumentParams) Retriever {
+	return RetrieverFunc(func(ctx context.Context, query Query, limit int) ([]string, error) {
+		if len(query.Vector) == 0 {
+			return nil, fmt.Errorf("query %v has no vector", query.Id)
+		}
+		param := new(tcvectordb.SearchDocumentParams)
+		if params != nil {
+			*param = *params
+		}
+		param.Limit = int64(limit)
+		param.OutputFields = []string{"id"}
+		res, err := cli.Search(ctx, databaseName, collectionName, [][]float32{query.Vector}, param)
+		if err != nil {
+			return nil, err
+		}
+		return documentIds(res), nil
+	})
+}
+
+// [NewSearchByTextRetriever] creates a [Retriever] with [tcvectordb.FlatDocumentInterface].SearchByText
+// by the Text of the query, which is embedded by the server.
+//
+// Parameters:
+//   - cli: The client to search with, such as [tcvectordb.Client] or [tcvectordb.RpcClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - fieldName: The name of the text field of the embedding.
+//   - params: (Optional) The parameters of the search, such as Filter and Params. The Limit is set by the evaluation.
+func NewSearchByTextRetriever(cli tcvectordb.FlatDocumentInterface, databaseName, collectionName, fieldName string,
+	params *tcvectordb.SearchDocumentParams) Retriever {
+	return RetrieverFunc(func(ctx context.Context, query Query, limit int) ([]string, error) {
+		if query.Text == "" {
+			return nil, fmt.Errorf("query %v has no text", query.Id)
+		}
+		param := new(tcvectordb.SearchDocumentParams)
+		if params != nil {
+			*param = *params
+		}
+		param.Limit = int64(limit)
+		param.OutputFields = []string{"id"}
+		res, err := cli.SearchByText(ctx, databaseName, collectionName, map[string][]string{fieldName: {query.Text}}, param)
+		if err != nil {
+			return nil, err
+		}
+		return documentIds(res), nil
+	})
+}
+
+// [NewFullTextSearchRetriever] creates a [Retriever] with [tcvectordb.FlatDocumentInterface].FullTextSearch
+// by the SparseVector of the query.
+//
+// Parameters:
+//   - cli: The client to search with, such as [tcvectordb.Client] or [tcvectordb.RpcClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - params: The parameters of the search. The Match.Data and the Limit are set by the evaluation.
+//   - sparseEncoder: (Optional) The encoder to encode the Text of the queries without a SparseVector,
+//     such as a BM25 encoder with the parameters to evaluate.
+func NewFullTextSearchRetriever(cli tcvectordb.FlatDocumentInterface, databaseName, collectionName string,
+	params tcvectordb.FullTextSearchParams, sparseEncoder encoder.SparseEncoder) Retriever {
+	return RetrieverFunc(func(ctx context.Context, query Query, limit int) ([]string, error) {
+		sparseVector, err := querySparseVector(query, sparseEncoder)
+		if err != nil {
+			return nil, err
+		}
+		param := params
+		param.Match = new(tcvectordb.FullTextSearchMatchOption)
+		if params.Match != nil {
+			*param.Match = *params.Match
+		}
+		param.Match.Data = [][]encoder.SparseVecItem{sparseVector}
+		param.Limit = &limit
+		param.OutputFields = []string{"id"}
+		res, err := cli.FullTextSearch(ctx, databaseName, collectionName, param)
+		if err != nil {
+			return nil, err
+		}
+		return documentIds(res), nil
+	})
+}
+
+// [NewHybridSearchRetriever] creates a [Retriever] with [tcvectordb.FlatDocumentInterface].HybridSearch.
+//
+// Parameters:
+//   - cli: The client to search with, such as [tcvectordb.Client] or [tcvectordb.RpcClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - params: The parameters of the search, such as the Rerank to evaluate. The Data of the AnnParams
+//     is set to the Vector of the query, or the Text if the query has no Vector. The Data of the Match
+//     is set to the SparseVector of the query. The Limit is set by the evaluation.
+//   - sparseEncoder: (Optional) The encoder to encode the Text of the queries without a SparseVector.
+func NewHybridSearchRetriever(cli tcvectordb.FlatDocumentInterface, databaseName, collectionName string,
+	params tcvectordb.HybridSearchDocumentParams, sparseEncoder encoder.SparseEncoder) Retriever {
+	return RetrieverFunc(func(ctx context.Context, query Query, limit int) ([]string, error) {
+		param := params
+		param.AnnParams = nil
+		for _, ann := range params.AnnParams {
+			annParam := new(tcvectordb.AnnParam)
+			if ann != nil {
+				*annParam = *ann
+			}
+			if len(query.Vector) != 0 {
+				annParam.Data = query.Vector
+			} else if query.Text != "" {
+				annParam.Data = query.Text
+			} else {
+				return nil, fmt.Errorf("query %v has neither vector nor text", query.Id)
+			}
+			param.AnnParams = append(param.AnnParams, annParam)
+		}
+		param.Match = nil
+		if len(params.Match) != 0 {
+			sparseVector, err := querySparseVector(query, sparseEncoder)
+			if err != nil {
+				return nil, err
+			}
+			for _, match := range params.Match {
+				matchParam := new(tcvectordb.MatchOption)
+				if match != nil {
+					*matchParam = *match
+				}
+				matchParam.Data = sparseVector
+				param.Match = append(param.Match, matchParam)
+			}
+		}
+		param.Limit = &limit
+		param.OutputFields = []string{"id"}
+		res, err := cli.HybridSearch(ctx, databaseName, collectionName, param)
+		if err != nil {
+			return nil, err
+		}
+		return documentIds(res), nil
+	})
+}
+
+func querySparseVector(query Query, sparseEncoder encoder.SparseEncoder) ([]encoder.SparseVecItem, error) {
+	if len(query.SparseVector) != 0 {
+		return query.SparseVector, nil
+	}
+	if sparseEncoder == nil || query.Text == "" {
+		return nil, fmt.Errorf("query %v has no sparse vector", query.Id)
+	}
+	sparseVector, err := sparseEncoder.EncodeQuery(query.Text)
+	if err != nil {
+		return nil, fmt.Errorf("encode query %v failed. err: %v", query.Id, err)
+	}
+	return sparseVector, nil
+}
+
+func documentIds(res *tcvectordb.SearchDocumentResult) []string {
+	if res == nil || len(res.Documents) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(res.Documents[0]))
+	for _, doc := range res.Documents[0] {
+		ids = append(ids, doc.Id)
+	}
+	return ids
+}
@@ -0,0 +1,278 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultMaxSearchVectors    = 20
+	defaultMaxDocumentIds      = 20
+	defaultMaxMessageBytes     = 100 << 20
+	defaultAutoSplitConcurrent = 4
+	// estimatedFloatBytes is the estimated size of a float in the request, which is close to
+	// the size of a float formatted in json.
+	estimatedFloatBytes = 12
+)
+
+// [AutoSplitOption] holds the limits for splitting the oversized document requests into sub-requests.
+// The sub-requests run in parallel, and their results are merged in the order of the input.
+//
+// Fields:
+//   - MaxSearchVectors: (Optional) The maximum number of vectors in a Search request (defaults to 20).
+//   - MaxDocumentIds: (Optional) The maximum number of document ids in a Query, Delete or Update request (defaults to 20).
+//   - MaxMessageBytes: (Optional) The maximum estimated size of the vectors in a Search request (defaults to 100MB).
+//   - Concurrency: (Optional) The maximum number of the concurrent sub-requests of one request (defaults to 4).
+//
+// Notes: A Query request with Offset or Sort and a Delete request with Limit are not split, because their
+// results depend on all the documents.
+type AutoSplitOption struct {
+	MaxSearchVectors int
+	MaxDocumentIds   int
+	MaxMessageBytes  int
+	Concurrency      int
+}
+
+var _ FlatInterface = &autoSplitFlatDocument{}
+
+// autoSplitFlatDocument wraps the flat document methods to split the oversized requests.
+type autoSplitFlatDocument struct {
+	FlatInterface
+	option AutoSplitOption
+}
+
+func withAutoSplit(flat FlatInterface, option *AutoSplitOption) FlatInterface {
+	if option == nil {
+		return flat
+	}
+	opt := *option
+	if opt.MaxSearchVectors <= 0 {
+		opt.MaxSearchVectors = defaultMaxSearchVectors
+	}
+	if opt.MaxDocumentIds <= 0 {
+		opt.MaxDocumentIds = defaultMaxDocumentIds
+	}
+	if opt.MaxMessageBytes <= 0 {
+		opt.MaxMessageBytes = defaultMaxMessageBytes
+	}
+	if opt.Concurrency <= 0 {
+		opt.Concurrency = defaultAutoSplitConcurrent
+	}
+	return &autoSplitFlatDocument{FlatInterface: flat, option: opt}
+}
+
+// [Search] returns the most similar topK vectors by the given vectors, and splits the vectors into
+// sub-requests when they exceed the limits.
+func (a *autoSplitFlatDocument) Search(ctx context.Context, databaseName, collectionName string,
+	vectors [][]float32, params ...*SearchDocumentParams) (*SearchDocumentResult, error) {
+	chunks := a.splitVectors(vectors)
+	if len(chunks) <= 1 {
+		return a.FlatInterface.Search(ctx, databaseName, collectionName, vectors, params...)
+	}
+	results := make([]*SearchDocumentResult, len(chunks))
+	err := a.runSplit(ctx, len(chunks), func(ctx context.Context, i int) (err error) {
+		results[i], err = a.FlatInterface.Search(ctx, databaseName, collectionName, chunks[i], params...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(SearchDocumentResult)
+	var warnings []string
+	for _, res := range results {
+		warnings = append(warnings, res.Warning)
+		result.Documents = append(result.Documents, res.Documents...)
+		if res.EmbeddingExtraInfo != nil {
+			result.EmbeddingExtraInfo = res.EmbeddingExtraInfo
+		}
+	}
+	result.Warning = joinWarnings(warnings)
+	return result, nil
+}
+
+// [Query] queries documents that satisfies the condition from the collection, and splits the document ids
+// into sub-requests when they exceed the limit.
+func (a *autoSplitFlatDocument) Query(ctx context.Context, databaseName, collectionName string,
+	documentIds []string, params ...*QueryDocumentParams) (*QueryDocumentResult, error) {
+	var param *QueryDocumentParams
+	if len(params) != 0 {
+		param = params[0]
+	}
+	if len(documentIds) <= a.option.MaxDocumentIds ||
+		(param != nil && (param.Offset != 0 || len(param.Sort) != 0)) {
+		return a.FlatInterface.Query(ctx, databaseName, collectionName, documentIds, params...)
+	}
+	chunks := splitIds(documentIds, a.option.MaxDocumentIds)
+	results := make([]*QueryDocumentResult, len(chunks))
+	err := a.runSplit(ctx, len(chunks), func(ctx context.Context, i int) (err error) {
+		results[i], err = a.FlatInterface.Query(ctx, databaseName, collectionName, chunks[i], params...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(QueryDocumentResult)
+	var warnings []string
+	for _, res := range results {
+		warnings = append(warnings, res.Warning)
+		result.Documents = append(result.Documents, res.Documents...)
+		result.AffectedCount += res.AffectedCount
+		result.Total += res.Total
+	}
+	if param != nil && param.Limit > 0 && int64(len(result.Documents)) > param.Limit {
+		result.Documents = result.Documents[:param.Limit]
+	}
+	result.Warning = joinWarnings(warnings)
+	return result, nil
+}
+
+// [Delete] deletes documents by conditions, and splits the document ids into sub-requests when
+// they exceed the limit.
+func (a *autoSplitFlatDocument) Delete(ctx context.Context, databaseName, collectionName string,
+	param DeleteDocumentParams) (*DeleteDocumentResult, error) {
+	if len(param.DocumentIds) <= a.option.MaxDocumentIds || param.Limit != 0 {
+		return a.FlatInterface.Delete(ctx, databaseName, collectionName, param)
+	}
+	chunks := splitIds(param.DocumentIds, a.option.MaxDocumentIds)
+	results := make([]*DeleteDocumentResult, len(chunks))
+	err := a.runSplit(ctx, len(chunks), func(ctx context.Context, i int) (err error) {
+		subParam := param
+		subParam.DocumentIds = chunks[i]
+		results[i], err = a.FlatInterface.Delete(ctx, databaseName, collectionName, subParam)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(DeleteDocumentResult)
+	for _, res := range results {
+		result.AffectedCount += res.AffectedCount
+	}
+	return result, nil
+}
+
+// [Update] updates documents by conditions, and splits the document ids into sub-requests when
+// they exceed the limit.
+func (a *autoSplitFlatDocument) Update(ctx context.Context, databaseName, collectionName string,
+	param UpdateDocumentParams) (*UpdateDocumentResult, error) {
+	if len(param.QueryIds) <= a.option.MaxDocumentIds {
+		return a.FlatInterface.Update(ctx, databaseName, collectionName, param)
+	}
+	chunks := splitIds(param.QueryIds, a.option.MaxDocumentIds)
+	results := make([]*UpdateDocumentResult, len(chunks))
+	err := a.runSplit(ctx, len(chunks), func(ctx context.Context, i int) (err error) {
+		subParam := param
+		subParam.QueryIds = chunks[i]
+		results[i], err = a.FlatInterface.Update(ctx, databaseName, collectionName, subParam)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := new(UpdateDocumentResult)
+	for _, res := range results {
+		result.AffectedCount += res.AffectedCount
+	}
+	return result, nil
+}
+
+// splitVectors splits the vectors by the number of vectors and the estimated size.
+func (a *autoSplitFlatDocument) splitVectors(vectors [][]float32) [][][]float32 {
+	var (
+		chunks [][][]float32
+		start  int
+		size   int
+	)
+	for i, vector := range vectors {
+		vectorSize := len(vector) * estimatedFloatBytes
+		if i > start && (i-start >= a.option.MaxSearchVectors || size+vectorSize > a.option.MaxMessageBytes) {
+			chunks = append(chunks, vectors[start:i])
+			start, size = i, 0
+		}
+		size += vectorSize
+	}
+	if start < len(vectors) {
+		chunks = append(chunks, vectors[start:])
+	}
+	return chunks
+}
+
+// runSplit runs the sub-requests in parallel, and cancels the others when one of them fails.
+func (a *autoSplitFlatDocument) runSplit(ctx context.Context, n int, do func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, a.option.Concurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			if err := do(ctx, i); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
+
+func splitIds(ids []string, size int) [][]string {
+	chunks := make([][]string, 0, (len(ids)+size-1)/size)
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
+
+// joinWarnings joins the distinct non-empty warnings in order.
+func joinWarnings(warnings []string) string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, warning := range warnings {
+		if warning == "" || seen[warning] {
+			continue
+		}
+		seen[warning] = true
+		result = append(result, warning)
+	}
+	return strings.Join(result, "; ")
+}
@@ -0,0 +1,232 @@
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// splitRecorder answers the flat document requests from the ids and the vectors, so that the
+// merged results show the order of the sub-requests.
+type splitRecorder struct {
+	FlatInterface
+	mu       sync.Mutex
+	chunks   []int
+	calls    int32
+	failWith error
+}
+
+func (s *splitRecorder) record(n int) {
+	atomic.AddInt32(&s.calls, 1)
+	s.mu.Lock()
+	s.chunks = append(s.chunks, n)
+	s.mu.Unlock()
+}
+
+func (s *splitRecorder) Search(ctx context.Context, databaseName, collectionName string,
+	vectors [][]float32, params ...*SearchDocumentParams) (*SearchDocumentResult, error) {
+	s.record(len(vectors))
+	// The later chunks finish first.
+	time.Sleep(time.Duration(100-int(vectors[0][0])) * 100 * time.Microsecond)
+	result := &SearchDocumentResult{Warning: "chunk " + strconv.Itoa(int(vectors[0][0])/2%2)}
+	for _, vector := range vectors {
+		result.Documents = append(result.Documents, []Document{{Id: strconv.Itoa(int(vector[0]))}})
+	}
+	return result, nil
+}
+
+func (s *splitRecorder) Query(ctx context.Context, databaseName, collectionName string,
+	documentIds []string, params ...*QueryDocumentParams) (*QueryDocumentResult, error) {
+	s.record(len(documentIds))
+	result := &QueryDocumentResult{AffectedCount: len(documentIds), Total: uint64(len(documentIds))}
+	for _, id := range documentIds {
+		result.Documents = append(result.Documents, Document{Id: id})
+	}
+	return result, nil
+}
+
+func (s *splitRecorder) Delete(ctx context.Context, databaseName, collectionName string,
+	param DeleteDocumentParams) (*DeleteDocumentResult, error) {
+	s.record(len(param.DocumentIds))
+	if s.failWith != nil {
+		if param.DocumentIds[0] == "id_2" {
+			return nil, s.failWith
+		}
+		// The other sub-requests only finish when they are canceled.
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &DeleteDocumentResult{AffectedCount: len(param.DocumentIds)}, nil
+}
+
+func (s *splitRecorder) Update(ctx context.Context, databaseName, collectionName string,
+	param UpdateDocumentParams) (*UpdateDocumentResult, error) {
+	s.record(len(param.QueryIds))
+	if s.failWith != nil {
+		return nil, s.failWith
+	}
+	return &UpdateDocumentResult{AffectedCount: len(param.QueryIds)}, nil
+}
+
+func splitTestIds(n int) []string {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, "id_"+strconv.Itoa(i))
+	}
+	return ids
+}
+
+func TestAutoSplitSearchKeepsOrder(t *testing.T) {
+	stub := &splitRecorder{}
+	flat := withAutoSplit(stub, &AutoSplitOption{MaxSearchVectors: 2})
+	vectors := make([][]float32, 0, 7)
+	for i := 0; i < 7; i++ {
+		vectors = append(vectors, []float32{float32(i), 0})
+	}
+	result, err := flat.Search(context.Background(), "db", "coll", vectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stub.chunks) != 4 {
+		t.Fatalf("searched %v chunks, want 4", stub.chunks)
+	}
+	if len(result.Documents) != len(vectors) {
+		t.Fatalf("got %v results, want %v", len(result.Documents), len(vectors))
+	}
+	for i, docs := range result.Documents {
+		if docs[0].Id != strconv.Itoa(i) {
+			t.Fatalf("result %v is of vector %v", i, docs[0].Id)
+		}
+	}
+	if result.Warning != "chunk 0; chunk 1" {
+		t.Fatalf("unexpected warning: %q", result.Warning)
+	}
+
+	// The vectors within the limits are searched in one request.
+	stub.chunks = nil
+	if _, err = flat.Search(context.Background(), "db", "coll", vectors[:2]); err != nil || len(stub.chunks) != 1 {
+		t.Fatalf("searched %v chunks, err: %v", stub.chunks, err)
+	}
+}
+
+func TestAutoSplitSumsAffectedCount(t *testing.T) {
+	stub := &splitRecorder{}
+	flat := withAutoSplit(stub, &AutoSplitOption{MaxDocumentIds: 20})
+	ids := splitTestIds(50)
+
+	deleteResult, err := flat.Delete(context.Background(), "db", "coll", DeleteDocumentParams{DocumentIds: ids})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleteResult.AffectedCount != 50 || len(stub.chunks) != 3 {
+		t.Fatalf("deleted %v in %v chunks, want 50 in 3", deleteResult.AffectedCount, stub.chunks)
+	}
+
+	updateResult, err := flat.Update(context.Background(), "db", "coll", UpdateDocumentParams{QueryIds: ids})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updateResult.AffectedCount != 50 {
+		t.Fatalf("updated %v, want 50", updateResult.AffectedCount)
+	}
+
+	queryResult, err := flat.Query(context.Background(), "db", "coll", ids, &QueryDocumentParams{Limit: 45})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queryResult.AffectedCount != 50 || queryResult.Total != 50 || len(queryResult.Documents) != 45 {
+		t.Fatalf("unexpected query result: affected %v, total %v, %v documents",
+			queryResult.AffectedCount, queryResult.Total, len(queryResult.Documents))
+	}
+	for i, doc := range queryResult.Documents {
+		if doc.Id != ids[i] {
+			t.Fatalf("document %v is %v, want %v", i, doc.Id, ids[i])
+		}
+	}
+
+	// A Delete with Limit is not split.
+	stub.chunks = nil
+	if _, err = flat.Delete(context.Background(), "db", "coll", DeleteDocumentParams{DocumentIds: ids, Limit: 10}); err != nil ||
+		len(stub.chunks) != 1 {
+		t.Fatalf("deleted %v chunks, err: %v", stub.chunks, err)
+	}
+}
+
+func TestAutoSplitCancelsOnFirstError(t *testing.T) {
+	failure := errors.New("update failed")
+	stub := &splitRecorder{failWith: failure}
+	flat := withAutoSplit(stub, &AutoSplitOption{MaxDocumentIds: 2, Concurrency: 1})
+	_, err := flat.Update(context.Background(), "db", "coll", UpdateDocumentParams{QueryIds: splitTestIds(10)})
+	if err != failure {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The batches waiting for their turn are not sent after the first error.
+	if calls := atomic.LoadInt32(&stub.calls); calls != 1 {
+		t.Fatalf("sent %v batches, want 1", calls)
+	}
+
+	// The running batches are canceled by the first error.
+	stub = &splitRecorder{failWith: failure}
+	flat = withAutoSplit(stub, &AutoSplitOption{MaxDocumentIds: 2, Concurrency: 5})
+	done := make(chan error, 1)
+	go func() {
+		_, err := flat.Delete(context.Background(), "db", "coll", DeleteDocumentParams{DocumentIds: splitTestIds(10)})
+		done <- err
+	}()
+	select {
+	case err = <-done:
+		if err != failure {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the running batches are not canceled")
+	}
+}
+
+func TestSplitVectors(t *testing.T) {
+	a := &autoSplitFlatDocument{option: AutoSplitOption{MaxSearchVectors: 3, MaxMessageBytes: 4 * estimatedFloatBytes}}
+	vectors := [][]float32{{1, 2}, {3, 4}, {5}, {6, 7, 8, 9, 10}, {11}}
+	chunks := a.splitVectors(vectors)
+	// The size limit splits after 4 floats, and an oversized vector is sent alone.
+	want := []int{2, 1, 1, 1}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %v chunks, want %v", len(chunks), len(want))
+	}
+	for i, chunk := range chunks {
+		if len(chunk) != want[i] {
+			t.Fatalf("chunk %v has %v vectors, want %v", i, len(chunk), want[i])
+		}
+	}
+
+	a.option.MaxMessageBytes = defaultMaxMessageBytes
+	chunks = a.splitVectors(vectors)
+	if len(chunks) != 2 || len(chunks[0]) != 3 || len(chunks[1]) != 2 {
+		t.Fatalf("unexpected chunks by count: %v", chunks)
+	}
+	if chunks = a.splitVectors(nil); len(chunks) != 0 {
+		t.Fatalf("unexpected chunks of no vectors: %v", chunks)
+	}
+}
+
+func TestSplitIds(t *testing.T) {
+	chunks := splitIds(splitTestIds(5), 2)
+	if len(chunks) != 3 || len(chunks[0]) != 2 || len(chunks[2]) != 1 || chunks[2][0] != "id_4" {
+		t.Fatalf("unexpected chunks: %v", chunks)
+	}
+	if chunks = splitIds(splitTestIds(4), 2); len(chunks) != 2 || chunks[1][1] != "id_3" {
+		t.Fatalf("unexpected chunks: %v", chunks)
+	}
+}
+
+func TestJoinWarnings(t *testing.T) {
+	if warning := joinWarnings([]string{"", "a", "b", "a", "", "c"}); warning != "a; b; c" {
+		t.Fatalf("unexpected warning: %q", warning)
+	}
+	if warning := joinWarnings([]string{"", ""}); warning != "" {
+		t.Fatalf("unexpected warning: %q", warning)
+	}
+}
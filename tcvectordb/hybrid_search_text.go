@@ -0,0 +1,154 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/encoder"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+)
+
+const (
+	defaultHybridSearchByTextLimit = 10
+	defaultHybridSearchByTextRrfK  = 60
+)
+
+// [SparseQueryEncoder] encodes a query text into a sparse vector, such as the [encoder.SparseEncoder]
+// and the BM25Encoder of tcvdbtext.
+type SparseQueryEncoder interface {
+	EncodeQuery(text string) ([]encoder.SparseVecItem, error)
+}
+
+var _ SparseQueryEncoder = encoder.SparseEncoder(nil)
+
+// [HybridSearchByTextParams] holds the parameters for searching documents by a text with both dense
+// and sparse vectors.
+//
+// Fields:
+//   - Embedder: (Optional) The [Embedder] to embed the text on the client. Without it, the text is
+//     embedded by the [Embedding] of the collection, or the [ClientEmbeddingConfig] of the client.
+//   - SparseEncoder: (Optional) The encoder of the sparse vector of the text, such as the BM25Encoder
+//     of tcvdbtext. Without it, or if the text has no terms, only the dense vector is searched.
+//   - VectorField: (Optional) The field of the dense vectors (defaults to "vector").
+//   - SparseVectorField: (Optional) The field of the sparse vectors (defaults to "sparse_vector").
+//   - Filter: (Optional) Filter documents by [Filter] conditions before searching the results.
+//   - Params: (Optional) A pointer to a [SearchDocParams] object for searching the dense vectors.
+//   - Limit: (Optional) The number of documents returned (defaults to 10).
+//   - CandidateLimit: (Optional) The number of documents retrieved by each of the dense and sparse
+//     vectors before reranking (defaults to Limit).
+//   - Rerank: (Optional) A pointer to a [RerankOption] object to fuse the results (defaults to
+//     [RerankRrf] with RrfK of 60, which needs no tuning of the weights).
+//   - RetrieveVector: (Optional) Specify whether to return vector values or not (default to false).
+//   - OutputFields: (Optional) Return columns specified by the list of column names.
+type HybridSearchByTextParams struct {
+	Embedder          Embedder
+	SparseEncoder     SparseQueryEncoder
+	VectorField       string
+	SparseVectorField string
+	Filter            *Filter
+	Params            *SearchDocParams
+	Limit             int
+	CandidateLimit    int
+	Rerank            *RerankOption
+	RetrieveVector    bool
+	OutputFields      []string
+}
+
+// [HybridSearchByText] searches the documents by a text, with the dense vector embedded from it and the
+// sparse vector encoded from it, and fuses the results of both.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - text: The text to search.
+//   - params: (Optional) A pointer to a [HybridSearchByTextParams] object. See [HybridSearchByTextParams]
+//     for more information.
+//
+// Returns a pointer to a [SearchDocumentResult] object with the documents of the text, or an error.
+func HybridSearchByText(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName, text string,
+	params ...*HybridSearchByTextParams) (*SearchDocumentResult, error) {
+	if text == "" {
+		return nil, errors.New("hybrid search text is empty")
+	}
+	param := HybridSearchByTextParams{}
+	if len(params) != 0 && params[0] != nil {
+		param = *params[0]
+	}
+	if param.VectorField == "" {
+		param.VectorField = "vector"
+	}
+	if param.SparseVectorField == "" {
+		param.SparseVectorField = "sparse_vector"
+	}
+	if param.Limit <= 0 {
+		param.Limit = defaultHybridSearchByTextLimit
+	}
+	if param.CandidateLimit <= 0 {
+		param.CandidateLimit = param.Limit
+	}
+	candidateLimit := &param.CandidateLimit
+
+	var tokens int64
+	ann := &AnnParam{FieldName: param.VectorField, Data: text, Params: param.Params, Limit: candidateLimit}
+	if param.Embedder != nil {
+		vectors, used, err := embedTexts(ctx, param.Embedder, nil, 1, []string{text})
+		if err != nil {
+			return nil, err
+		}
+		ann.Data, tokens = vectors[0], used
+	}
+	search := HybridSearchDocumentParams{
+		Filter:         param.Filter,
+		RetrieveVector: param.RetrieveVector,
+		OutputFields:   param.OutputFields,
+		Limit:          &param.Limit,
+		AnnParams:      []*AnnParam{ann},
+	}
+	if param.SparseEncoder != nil {
+		sparse, err := param.SparseEncoder.EncodeQuery(text)
+		if err != nil {
+			return nil, fmt.Errorf("encode sparse vector failed. err: %v", err)
+		}
+		if len(sparse) != 0 {
+			search.Match = []*MatchOption{{FieldName: param.SparseVectorField, Data: sparse, Limit: candidateLimit}}
+			search.Rerank = param.Rerank
+			if search.Rerank == nil {
+				search.Rerank = &RerankOption{Method: RerankRrf, RrfK: defaultHybridSearchByTextRrfK}
+			}
+		}
+	}
+
+	result, err := cli.HybridSearch(ctx, databaseName, collectionName, search)
+	if err != nil {
+		return nil, err
+	}
+	if tokens != 0 {
+		if result.EmbeddingExtraInfo == nil {
+			result.EmbeddingExtraInfo = new(document.EmbeddingExtraInfo)
+		}
+		result.EmbeddingExtraInfo.TokenUsed += uint64(tokens)
+	}
+	return result, nil
+}
@@ -70,6 +70,10 @@ var _ IndexParams = &IVFFLATParams{}
 var _ IndexParams = &IVFSQParams{}
 var _ IndexParams = &IVFPQParams{}
 var _ IndexParams = &IVFRabitQParams{}
+var _ IndexParams = &BINHNSWParams{}
+var _ IndexParams = &FLATParams{}
+var _ IndexParams = &BINFLATParams{}
+var _ IndexParams = &DiskFLATParams{}
 
 type IVFRabitQParams struct {
 	NList uint32
@@ -109,8 +113,15 @@ func (p *IVFFLATParams) Name() string {
 	return string(IVF_FLAT)
 }
 
+// [IVFSQParams] holds the parameters of the IVF_SQ4, IVF_SQ8 and IVF_SQ16 indexes.
+//
+// Fields:
+//   - NList: The number of the cluster units.
+//   - IndexType: (Optional) The type of the index returned by Name, which is IVF_SQ4, IVF_SQ8 or IVF_SQ16
+//     (defaults to IVF_SQ8).
 type IVFSQParams struct {
-	NList uint32
+	NList     uint32
+	IndexType IndexType `json:"-"`
 }
 
 func (p *IVFSQParams) MarshalJson() ([]byte, error) {
@@ -118,6 +129,9 @@ func (p *IVFSQParams) MarshalJson() ([]byte, error) {
 }
 
 func (p *IVFSQParams) Name() string {
+	if p.IndexType != "" {
+		return string(p.IndexType)
+	}
 	return string(IVF_SQ8)
 }
 
@@ -133,3 +147,54 @@ func (p *IVFPQParams) MarshalJson() ([]byte, error) {
 func (p *IVFPQParams) Name() string {
 	return string(IVF_PQ)
 }
+
+// [BINHNSWParams] holds the parameters of the BIN_HNSW index for binary vectors.
+//
+// Fields:
+//   - M: The maximum number of the neighbors of each node, ranging from [4, 64].
+//   - EfConstruction: The size of the dynamic candidate list when building the index, ranging from [8, 512].
+type BINHNSWParams struct {
+	M              uint32
+	EfConstruction uint32
+}
+
+func (p *BINHNSWParams) MarshalJson() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *BINHNSWParams) Name() string {
+	return string(BIN_HNSW)
+}
+
+// [FLATParams] holds the parameters of the FLAT index, which has no parameters.
+type FLATParams struct{}
+
+func (p *FLATParams) MarshalJson() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *FLATParams) Name() string {
+	return string(FLAT)
+}
+
+// [BINFLATParams] holds the parameters of the BIN_FLAT index for binary vectors, which has no parameters.
+type BINFLATParams struct{}
+
+func (p *BINFLATParams) MarshalJson() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *BINFLATParams) Name() string {
+	return string(BIN_FLAT)
+}
+
+// [DiskFLATParams] holds the parameters of the DISK_FLAT index, which has no parameters.
+type DiskFLATParams struct{}
+
+func (p *DiskFLATParams) MarshalJson() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *DiskFLATParams) Name() string {
+	return string(DISK_FLAT)
+}
@@ -0,0 +1,331 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import "fmt"
+
+const (
+	minHNSWM              = 4
+	maxHNSWM              = 64
+	minHNSWEfConstruction = 8
+	maxHNSWEfConstruction = 512
+	minIVFNList           = 1
+	maxIVFNList           = 65536
+	minIVFPQM             = 1
+	maxIVFPQM             = 4096
+)
+
+// [Validate] checks the indexes on the client side before they are sent to the server.
+//
+// Notes: It checks the vector indexes, the sparse vector indexes and the filter indexes with their own
+// Validate, and that field names are unique and at most one primary key is defined.
+// Zero-valued index parameters are treated as unset and left to the server defaults.
+//
+// Returns an error describing the first invalid index, or nil.
+func (i *Indexes) Validate() error {
+	if i == nil {
+		return nil
+	}
+	names := make(map[string]struct{})
+	checkName := func(name string) error {
+		if name == "" {
+			return fmt.Errorf("index field name is empty")
+		}
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("index field %v is defined more than once", name)
+		}
+		names[name] = struct{}{}
+		return nil
+	}
+	for idx := range i.VectorIndex {
+		if err := checkName(i.VectorIndex[idx].FieldName); err != nil {
+			return err
+		}
+		if err := i.VectorIndex[idx].Validate(); err != nil {
+			return err
+		}
+	}
+	for idx := range i.SparseVectorIndex {
+		if err := checkName(i.SparseVectorIndex[idx].FieldName); err != nil {
+			return err
+		}
+		if err := i.SparseVectorIndex[idx].Validate(); err != nil {
+			return err
+		}
+	}
+	primaryKeys := 0
+	for _, v := range i.FilterIndex {
+		if err := checkName(v.FieldName); err != nil {
+			return err
+		}
+		if err := v.Validate(); err != nil {
+			return err
+		}
+		if v.IsPrimaryKey() {
+			primaryKeys++
+		}
+	}
+	if primaryKeys > 1 {
+		return fmt.Errorf("only one primary key index is allowed, got %v", primaryKeys)
+	}
+	return nil
+}
+
+// [Validate] checks the field type, dimension, metric type and index parameters of the vector index.
+// A zero dimension is allowed, as the server takes it from the embedding model when embedding is set.
+// An empty index type is taken from the params, as it is when the collection is created.
+//
+// Returns an error describing the invalid setting, or nil.
+func (v *VectorIndex) Validate() error {
+	if v == nil {
+		return nil
+	}
+	indexType := v.IndexType
+	if indexType == "" && v.Params != nil {
+		indexType = IndexType(v.Params.Name())
+	}
+	if indexType == "" {
+		return fmt.Errorf("vector index %v: index type is empty", v.FieldName)
+	}
+	return validateVectorIndex(v.FieldName, v.FieldType, indexType, v.MetricType, v.Dimension, v.Params)
+}
+
+// [Validate] checks the field type, index type and metric type of the sparse vector index.
+//
+// Returns an error describing the invalid setting, or nil.
+func (s *SparseVectorIndex) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.FieldType != "" && s.FieldType != SparseVector {
+		return fmt.Errorf("sparse vector index %v: field type must be %v, got %v", s.FieldName, SparseVector, s.FieldType)
+	}
+	if s.IndexType != "" && s.IndexType != SPARSE_INVERTED {
+		return fmt.Errorf("sparse vector index %v: index type must be %v, got %v", s.FieldName, SPARSE_INVERTED, s.IndexType)
+	}
+	if s.MetricType != "" && s.MetricType != IP {
+		return fmt.Errorf("sparse vector index %v: metric type must be %v, got %v", s.FieldName, IP, s.MetricType)
+	}
+	return nil
+}
+
+// [Validate] checks the field type, metric type and index parameters of the vector index to modify.
+// The dimension is not part of the modification, so the divisibility of IVF_PQ is not checked,
+// and an empty index type is taken from the params.
+//
+// Returns an error describing the invalid setting, or nil.
+func (m *ModifyVectorIndex) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if FieldType(m.FieldType) == SparseVector {
+		if m.Params != nil {
+			return fmt.Errorf("sparse vector index %v: params %T are not supported by index type %v",
+				m.FieldName, m.Params, SPARSE_INVERTED)
+		}
+		s := SparseVectorIndex{FieldName: m.FieldName, FieldType: SparseVector, IndexType: IndexType(m.IndexType),
+			MetricType: m.MetricType}
+		return s.Validate()
+	}
+	indexType := IndexType(m.IndexType)
+	if indexType == "" && m.Params != nil {
+		indexType = IndexType(m.Params.Name())
+	}
+	return validateVectorIndex(m.FieldName, FieldType(m.FieldType), indexType, m.MetricType, 0, m.Params)
+}
+
+// [Validate] checks the field type and index type of the filter index.
+//
+// Returns an error describing the invalid setting, or nil.
+func (f *FilterIndex) Validate() error {
+	if f == nil {
+		return nil
+	}
+	switch f.FieldType {
+	case Uint64, String, Array, Json, Double, Int64:
+	default:
+		return fmt.Errorf("filter index %v: unsupported field type %v", f.FieldName, f.FieldType)
+	}
+	if f.IndexType != PRIMARY && f.IndexType != FILTER {
+		return fmt.Errorf("filter index %v: index type must be %v or %v, got %v", f.FieldName, PRIMARY, FILTER, f.IndexType)
+	}
+	if f.FieldType != Array && f.ElemType != "" {
+		return fmt.Errorf("filter index %v: element type is only supported by field type %v", f.FieldName, Array)
+	}
+	return nil
+}
+
+// [Validate] checks the filter indexes to add, which must not be the primary key.
+//
+// Returns an error describing the first invalid index, or nil.
+func (p *AddIndexParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for idx := range p.FilterIndexs {
+		if p.FilterIndexs[idx].IsPrimaryKey() {
+			return fmt.Errorf("filter index %v: the primary key can not be added to an existing collection",
+				p.FilterIndexs[idx].FieldName)
+		}
+	}
+	indexes := Indexes{FilterIndex: p.FilterIndexs}
+	return indexes.Validate()
+}
+
+func isBinaryIndexType(indexType IndexType) bool {
+	return indexType == BIN_FLAT || indexType == BIN_HNSW
+}
+
+// validateVectorIndex checks a vector index definition.
+// A zero dimension means the dimension is unknown, and an empty index type skips the index type checks.
+func validateVectorIndex(name string, fieldType FieldType, indexType IndexType, metricType MetricType,
+	dimension uint32, params IndexParams) error {
+	switch fieldType {
+	case "", Vector, Float16Vector, BFloat16Vector, BinaryVector:
+	default:
+		return fmt.Errorf("vector index %v: unsupported field type %v", name, fieldType)
+	}
+
+	switch indexType {
+	case "", FLAT, HNSW, IVF_FLAT, IVF_PQ, IVF_SQ4, IVF_SQ8, IVF_SQ16, IVF_RABITQ, BIN_FLAT, BIN_HNSW, DISK_FLAT:
+	default:
+		return fmt.Errorf("vector index %v: unsupported index type %v", name, indexType)
+	}
+
+	if fieldType == BinaryVector {
+		if indexType != "" && !isBinaryIndexType(indexType) {
+			return fmt.Errorf("vector index %v: field type %v only supports index type %v or %v, got %v",
+				name, BinaryVector, BIN_FLAT, BIN_HNSW, indexType)
+		}
+		if metricType != "" && metricType != HAMMING {
+			return fmt.Errorf("vector index %v: field type %v only supports metric type %v, got %v",
+				name, BinaryVector, HAMMING, metricType)
+		}
+		if dimension%8 != 0 {
+			return fmt.Errorf("vector index %v: dimension of %v must be a multiple of 8, got %v", name, BinaryVector, dimension)
+		}
+	} else {
+		if isBinaryIndexType(indexType) {
+			return fmt.Errorf("vector index %v: index type %v requires field type %v, got %v",
+				name, indexType, BinaryVector, fieldType)
+		}
+		switch metricType {
+		case "", L2, IP, COSINE:
+		case HAMMING:
+			return fmt.Errorf("vector index %v: metric type %v is only supported by field type %v", name, HAMMING, BinaryVector)
+		default:
+			return fmt.Errorf("vector index %v: unsupported metric type %v", name, metricType)
+		}
+	}
+
+	if params == nil {
+		return nil
+	}
+	mismatch := func() error {
+		return fmt.Errorf("vector index %v: params %T do not match index type %v", name, params, indexType)
+	}
+	switch param := params.(type) {
+	case *HNSWParam:
+		if indexType != HNSW {
+			return mismatch()
+		}
+		if param != nil {
+			return validateHNSWParams(name, param.M, param.EfConstruction)
+		}
+	case *BINHNSWParams:
+		if indexType != BIN_HNSW {
+			return mismatch()
+		}
+		if param != nil {
+			return validateHNSWParams(name, param.M, param.EfConstruction)
+		}
+	case *IVFFLATParams:
+		if indexType != IVF_FLAT {
+			return mismatch()
+		}
+		if param != nil {
+			return validateNList(name, param.NList)
+		}
+	case *IVFSQParams:
+		if indexType != IVF_SQ4 && indexType != IVF_SQ8 && indexType != IVF_SQ16 {
+			return mismatch()
+		}
+		if param != nil {
+			if param.IndexType != "" && param.IndexType != indexType {
+				return fmt.Errorf("vector index %v: params index type %v do not match index type %v", name, param.IndexType, indexType)
+			}
+			return validateNList(name, param.NList)
+		}
+	case *IVFPQParams:
+		if indexType != IVF_PQ {
+			return mismatch()
+		}
+		if param != nil {
+			if err := validateNList(name, param.NList); err != nil {
+				return err
+			}
+			if param.M < minIVFPQM || param.M > maxIVFPQM {
+				return fmt.Errorf("vector index %v: M of %v must be in [%v, %v], got %v", name, IVF_PQ, minIVFPQM, maxIVFPQM, param.M)
+			}
+			if dimension != 0 && dimension%param.M != 0 {
+				return fmt.Errorf("vector index %v: dimension %v must be divisible by M %v of %v", name, dimension, param.M, IVF_PQ)
+			}
+		}
+	case *IVFRabitQParams:
+		if indexType != IVF_RABITQ {
+			return mismatch()
+		}
+		if param != nil {
+			return validateNList(name, param.NList)
+		}
+	case *FLATParams:
+		if indexType != FLAT {
+			return mismatch()
+		}
+	case *BINFLATParams:
+		if indexType != BIN_FLAT {
+			return mismatch()
+		}
+	case *DiskFLATParams:
+		if indexType != DISK_FLAT {
+			return mismatch()
+		}
+	default:
+		return fmt.Errorf("vector index %v: unknown params type %T", name, params)
+	}
+	return nil
+}
+
+func validateHNSWParams(name string, m, efConstruction uint32) error {
+	if m != 0 && (m < minHNSWM || m > maxHNSWM) {
+		return fmt.Errorf("vector index %v: M must be in [%v, %v], got %v", name, minHNSWM, maxHNSWM, m)
+	}
+	if efConstruction != 0 && (efConstruction < minHNSWEfConstruction || efConstruction > maxHNSWEfConstruction) {
+		return fmt.Errorf("vector index %v: efConstruction must be in [%v, %v], got %v",
+			name, minHNSWEfConstruction, maxHNSWEfConstruction, efConstruction)
+	}
+	return nil
+}
+
+func validateNList(name string, nlist uint32) error {
+	if nlist != 0 && (nlist < minIVFNList || nlist > maxIVFNList) {
+		return fmt.Errorf("vector index %v: nlist must be in [%v, %v], got %v", name, minIVFNList, maxIVFNList, nlist)
+	}
+	return nil
+}
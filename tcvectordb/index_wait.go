@@ -0,0 +1,240 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWaitInitialInterval = time.Second
+	defaultWaitMaxInterval     = 30 * time.Second
+	defaultWaitMultiplier      = 1.5
+	defaultWaitStartTimeout    = time.Minute
+)
+
+// [WaitIndexOptions] holds the options for waiting for the index of a collection to be ready.
+//
+// Fields:
+//   - InitialInterval: (Optional) The interval before the second poll (defaults to 1s).
+//   - MaxInterval: (Optional) The maximum interval between polls (defaults to 30s).
+//   - Multiplier: (Optional) The factor by which the interval grows after each poll (defaults to 1.5).
+//   - InitialDelay: (Optional) The delay before the first poll, which gives the server time to start the build
+//     that was just submitted.
+//   - PreviousStartTime: (Optional) The start time of the latest index build described right before the index
+//     change was submitted. A ready status is only accepted once the server reports a different start time, or a
+//     status other than ready has been seen, so that the ready status of the previous build is not mistaken for
+//     the one of the change. The start times are compared as reported by the server, so the time zones of the
+//     client and the server don't matter.
+//   - StartTimeout: (Optional) The maximum time to wait for the server to report the build of the change,
+//     after which a ready status is accepted, such as when the change needs no build (defaults to 1m).
+//   - Timeout: (Optional) The maximum time to wait. 0 means waiting until the context is done.
+//   - Progress: (Optional) The callback called after each poll. See [IndexProgress] for more information.
+type WaitIndexOptions struct {
+	InitialInterval   time.Duration
+	MaxInterval       time.Duration
+	Multiplier        float64
+	InitialDelay      time.Duration
+	PreviousStartTime *time.Time
+	StartTimeout      time.Duration
+	Timeout           time.Duration
+	Progress          func(progress IndexProgress)
+}
+
+// [IndexProgress] holds the progress of building the index of a collection.
+//
+// Fields:
+//   - Status: The index status of the collection, such as ready, training, building and failed.
+//   - StartTime: The start time of the latest index build.
+//   - DocumentCount: The number of documents in the collection.
+//   - IndexedCount: The number of indexed documents of each vector index, keyed by the field name.
+//   - Elapsed: The time elapsed since the wait started.
+type IndexProgress struct {
+	Status        string
+	StartTime     time.Time
+	DocumentCount int64
+	IndexedCount  map[string]uint64
+	Elapsed       time.Duration
+}
+
+// [IndexBuildError] is returned when the index status of the collection is failed.
+type IndexBuildError struct {
+	DatabaseName   string
+	CollectionName string
+	Status         string
+	StartTime      time.Time
+	TaskIds        []string
+}
+
+func (e *IndexBuildError) Error() string {
+	msg := fmt.Sprintf("index of collection %v.%v is %v, start time: %v", e.DatabaseName, e.CollectionName,
+		e.Status, e.StartTime.Format("2006-01-02 15:04:05"))
+	if len(e.TaskIds) != 0 {
+		msg += ", tasks: " + strings.Join(e.TaskIds, ",")
+	}
+	return msg
+}
+
+// [WaitForIndexReady] polls [DescribeCollection] with backoff until the index of the collection is ready.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to describe the collection with. See [CollectionDescriber] for more information.
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - opts: A pointer to a [WaitIndexOptions] object that includes the options for waiting.
+//     See [WaitIndexOptions] for more information.
+//
+// Notes: It returns an [IndexBuildError] if the index status is failed, and the error of the context
+// if the wait times out. When waiting right after [AddIndex], [ModifyVectorIndex] or [RebuildIndex],
+// set [WaitIndexOptions].PreviousStartTime to the start time described before the call, or the index
+// may still be ready from the previous build.
+//
+// Returns an error if the index is not ready.
+func WaitForIndexReady(ctx context.Context, cli CollectionDescriber, databaseName, collectionName string,
+	opts ...*WaitIndexOptions) error {
+	return waitForIndexReady(ctx, cli, databaseName, collectionName, nil, opts...)
+}
+
+// [WaitForTasks] waits for the index tasks returned from [RebuildIndex] or [ModifyVectorIndex] to finish.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to describe the collection with. See [CollectionDescriber] for more information.
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - taskIds: The ids of the tasks, such as [RebuildIndexResult].TaskIds.
+//   - opts: A pointer to a [WaitIndexOptions] object that includes the options for waiting.
+//     See [WaitIndexOptions] for more information.
+//
+// Notes: The server reports the index status per collection rather than per task, so the tasks are
+// finished when the index of the collection is ready. No build was started if taskIds is empty, and it
+// returns nil right away. Otherwise a ready status is only accepted after the build of the tasks has been
+// seen, and if [WaitIndexOptions].PreviousStartTime is not set, a build finished before the first poll is
+// only accepted after [WaitIndexOptions].StartTimeout. The task ids are reported in the [IndexBuildError].
+//
+// Returns an error if the tasks are not finished.
+func WaitForTasks(ctx context.Context, cli CollectionDescriber, databaseName, collectionName string,
+	taskIds []string, opts ...*WaitIndexOptions) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	return waitForIndexReady(ctx, cli, databaseName, collectionName, taskIds, opts...)
+}
+
+func waitForIndexReady(ctx context.Context, cli interface{}, databaseName, collectionName string,
+	taskIds []string, opts ...*WaitIndexOptions) error {
+	opt := new(WaitIndexOptions)
+	if len(opts) != 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	interval := opt.InitialInterval
+	if interval <= 0 {
+		interval = defaultWaitInitialInterval
+	}
+	maxInterval := opt.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultWaitMaxInterval
+	}
+	multiplier := opt.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultWaitMultiplier
+	}
+	startTimeout := opt.StartTimeout
+	if startTimeout <= 0 {
+		startTimeout = defaultWaitStartTimeout
+	}
+	// The server reports the start time in its local time without the zone, so it is only compared with
+	// the start time reported before, never with the clock of the client.
+	pending := opt.PreviousStartTime != nil || len(taskIds) != 0
+	if opt.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opt.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	if err := sleepContext(ctx, opt.InitialDelay); err != nil {
+		return err
+	}
+	for {
+		coll, err := describeCollection(ctx, cli, databaseName, collectionName)
+		if err != nil {
+			return err
+		}
+		if opt.Progress != nil {
+			progress := IndexProgress{
+				Status:        coll.IndexStatus.Status,
+				StartTime:     coll.IndexStatus.StartTime,
+				DocumentCount: coll.DocumentCount,
+				IndexedCount:  make(map[string]uint64, len(coll.Indexes.VectorIndex)),
+				Elapsed:       time.Since(start),
+			}
+			for _, vector := range coll.Indexes.VectorIndex {
+				progress.IndexedCount[vector.FieldName] = vector.IndexedCount
+			}
+			opt.Progress(progress)
+		}
+		status := coll.IndexStatus.Status
+		if pending && (status != "" && status != IndexStatusReady ||
+			opt.PreviousStartTime != nil && !coll.IndexStatus.StartTime.Equal(*opt.PreviousStartTime)) {
+			pending = false
+		}
+		switch status {
+		case "", IndexStatusReady:
+			if !pending || time.Since(start) >= startTimeout {
+				return nil
+			}
+		case IndexStatusFailed:
+			return &IndexBuildError{
+				DatabaseName:   databaseName,
+				CollectionName: collectionName,
+				Status:         coll.IndexStatus.Status,
+				StartTime:      coll.IndexStatus.StartTime,
+				TaskIds:        taskIds,
+			}
+		}
+		if err = sleepContext(ctx, interval); err != nil {
+			return err
+		}
+		interval = time.Duration(float64(interval) * multiplier)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+module github.com/tencent/vectordatabase-sdk-go/tcvectordb/langchaingo
+
+go 1.24.4
+
+require (
+	github.com/tencent/vectordatabase-sdk-go v1.8.4
+	github.com/tmc/langchaingo v0.1.14
+)
+
+require (
+	github.com/clbanning/mxj v1.8.4 // indirect
+	github.com/dlclark/regexp2 v1.10.0 // indirect
+	github.com/go-ego/gse v0.80.3 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/mozillazg/go-httpheader v0.2.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pkoukk/tiktoken-go v0.1.6 // indirect
+	github.com/spaolacci/murmur3 v1.1.0 // indirect
+	github.com/tencentyun/cos-go-sdk-v5 v0.7.63 // indirect
+	github.com/vcaesar/cedar v0.20.2 // indirect
+	golang.org/x/net v0.43.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250122153221-138b5a5a4fd4 // indirect
+	google.golang.org/grpc v1.70.0 // indirect
+	google.golang.org/protobuf v1.36.3 // indirect
+)
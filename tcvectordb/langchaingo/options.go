@@ -0,0 +1,106 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package langchaingo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+const defaultTextKey = "text"
+
+var (
+	// ErrMissingClient is returned by [New] if the client is not set.
+	ErrMissingClient = errors.New("missing client")
+	// ErrMissingCollection is returned by [New] if the database or the collection is not set.
+	ErrMissingCollection = errors.New("missing database or collection")
+	// ErrEmbedderWrongNumberVectors is returned if the embedder returns a number of vectors
+	// not equal to the number of the documents.
+	ErrEmbedderWrongNumberVectors = errors.New("number of vectors from embedder does not match number of documents")
+	// ErrUnsupportedFilters is returned if the filters are not a string, a *tcvectordb.Filter or a map.
+	ErrUnsupportedFilters = errors.New("filters should be a string, a *tcvectordb.Filter or a map[string]any")
+)
+
+// Client is the part of the SDK client used by the [Store], which [tcvectordb.Client] and
+// [tcvectordb.RpcClient] implement.
+type Client interface {
+	Upsert(ctx context.Context, databaseName, collectionName string, documents interface{},
+		params ...*tcvectordb.UpsertDocumentParams) (*tcvectordb.UpsertDocumentResult, error)
+	Search(ctx context.Context, databaseName, collectionName string, vectors [][]float32,
+		params ...*tcvectordb.SearchDocumentParams) (*tcvectordb.SearchDocumentResult, error)
+	SearchByText(ctx context.Context, databaseName, collectionName string, text map[string][]string,
+		params ...*tcvectordb.SearchDocumentParams) (*tcvectordb.SearchDocumentResult, error)
+}
+
+// Option configures a [Store].
+type Option func(s *Store)
+
+// WithClient sets the client, such as a [tcvectordb.Client] or a [tcvectordb.RpcClient].
+func WithClient(cli Client) Option {
+	return func(s *Store) {
+		s.client = cli
+	}
+}
+
+// WithDatabase sets the database of the collections.
+func WithDatabase(databaseName string) Option {
+	return func(s *Store) {
+		s.databaseName = databaseName
+	}
+}
+
+// WithCollection sets the collection used when the namespace is not given.
+func WithCollection(collectionName string) Option {
+	return func(s *Store) {
+		s.collectionName = collectionName
+	}
+}
+
+// WithEmbedder sets the embedder of the documents and the queries. Without an embedder, the documents
+// are upserted without vectors and the queries are searched by SearchByText, so the collection should
+// embed the text field by its [tcvectordb.Embedding], or the client should embed it on the client side.
+func WithEmbedder(embedder embeddings.Embedder) Option {
+	return func(s *Store) {
+		s.embedder = embedder
+	}
+}
+
+// WithTextKey sets the field of the page content of the documents (defaults to "text").
+func WithTextKey(textKey string) Option {
+	return func(s *Store) {
+		s.textKey = textKey
+	}
+}
+
+func applyOptions(opts ...Option) (Store, error) {
+	s := Store{textKey: defaultTextKey}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.client == nil {
+		return Store{}, ErrMissingClient
+	}
+	if s.databaseName == "" || s.collectionName == "" {
+		return Store{}, ErrMissingCollection
+	}
+	return s, nil
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package langchaingo adapts Tencent VectorDB to a vectorstores.VectorStore of LangChainGo:
+//
+//	store, err := langchaingo.New(
+//		langchaingo.WithClient(cli),
+//		langchaingo.WithDatabase("db"),
+//		langchaingo.WithCollection("docs"),
+//		langchaingo.WithEmbedder(embedder),
+//	)
+//	docs, err := store.SimilaritySearch(ctx, "question", 5,
+//		vectorstores.WithScoreThreshold(0.8),
+//		vectorstores.WithFilters(map[string]any{"source": "manual.md"}),
+//	)
+//
+// The page content of a document is stored in the text field, and its metadata in the other fields.
+// The namespace selects the collection in the database. The score threshold is the radius of the
+// search, so it is the minimum score for the IP and COSINE metrics and the maximum distance for L2.
+//
+// It is a separate module, so the SDK does not depend on LangChainGo.
+package langchaingo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// Store is a vectorstores.VectorStore on the collections of a database.
+type Store struct {
+	client         Client
+	databaseName   string
+	collectionName string
+	embedder       embeddings.Embedder
+	textKey        string
+}
+
+var _ vectorstores.VectorStore = Store{}
+
+// New creates a Store with the options. WithClient, WithDatabase and WithCollection must be set.
+func New(opts ...Option) (Store, error) {
+	return applyOptions(opts...)
+}
+
+// AddDocuments upserts the documents into the collection of the namespace, and returns their ids.
+// The id of a document is its "id" metadata if it is a string, or a random one.
+func (s Store) AddDocuments(ctx context.Context, docs []schema.Document,
+	options ...vectorstores.Option) ([]string, error) {
+	opts := s.getOptions(options...)
+	if opts.Deduplicater != nil {
+		kept := make([]schema.Document, 0, len(docs))
+		for _, doc := range docs {
+			if !opts.Deduplicater(ctx, doc) {
+				kept = append(kept, doc)
+			}
+		}
+		docs = kept
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var vectors [][]float32
+	if embedder := s.getEmbedder(opts); embedder != nil {
+		texts := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			texts = append(texts, doc.PageContent)
+		}
+		var err error
+		vectors, err = embedder.EmbedDocuments(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != len(docs) {
+			return nil, ErrEmbedderWrongNumberVectors
+		}
+	}
+
+	ids := make([]string, 0, len(docs))
+	documents := make([]tcvectordb.Document, 0, len(docs))
+	for i, doc := range docs {
+		id, _ := doc.Metadata["id"].(string)
+		if id == "" {
+			id = newId()
+		}
+		fields := make(map[string]tcvectordb.Field, len(doc.Metadata)+1)
+		for k, v := range doc.Metadata {
+			if k == "id" || k == "vector" || k == "sparse_vector" {
+				continue
+			}
+			fields[k] = tcvectordb.Field{Val: v}
+		}
+		fields[s.textKey] = tcvectordb.Field{Val: doc.PageContent}
+		document := tcvectordb.Document{Id: id, Fields: fields}
+		if vectors != nil {
+			document.Vector = vectors[i]
+		}
+		ids = append(ids, id)
+		documents = append(documents, document)
+	}
+	if _, err := s.client.Upsert(ctx, s.databaseName, s.getCollection(opts), documents); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SimilaritySearch searches the collection of the namespace for the documents most similar to the query.
+// The query is embedded by the embedder, or searched by SearchByText without an embedder. The filters
+// are a [tcvectordb.Filter], a filter string, or a map of the fields to the values, which are equal to
+// the scalar values or in the slices. The score of a document is the score of the search.
+func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int,
+	options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	filter, err := toFilter(opts.Filters)
+	if err != nil {
+		return nil, err
+	}
+	params := &tcvectordb.SearchDocumentParams{Filter: filter, Limit: int64(numDocuments)}
+	if opts.ScoreThreshold != 0 {
+		radius := opts.ScoreThreshold
+		params.Radius = &radius
+	}
+
+	collectionName := s.getCollection(opts)
+	var res *tcvectordb.SearchDocumentResult
+	if embedder := s.getEmbedder(opts); embedder != nil {
+		vector, err := embedder.EmbedQuery(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		res, err = s.client.Search(ctx, s.databaseName, collectionName, [][]float32{vector}, params)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		res, err = s.client.SearchByText(ctx, s.databaseName, collectionName, map[string][]string{s.textKey: {query}}, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(res.Documents) == 0 {
+		return nil, nil
+	}
+	docs := make([]schema.Document, 0, len(res.Documents[0]))
+	for _, document := range res.Documents[0] {
+		metadata := make(map[string]any, len(document.Fields)+1)
+		for k, v := range document.Fields {
+			if k != s.textKey {
+				metadata[k] = v.Val
+			}
+		}
+		metadata["id"] = document.Id
+		text, _ := document.Fields[s.textKey].Val.(string)
+		docs = append(docs, schema.Document{PageContent: text, Metadata: metadata, Score: document.Score})
+	}
+	return docs, nil
+}
+
+func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+func (s Store) getCollection(opts vectorstores.Options) string {
+	if opts.NameSpace != "" {
+		return opts.NameSpace
+	}
+	return s.collectionName
+}
+
+func (s Store) getEmbedder(opts vectorstores.Options) embeddings.Embedder {
+	if opts.Embedder != nil {
+		return opts.Embedder
+	}
+	return s.embedder
+}
+
+// toFilter converts the filters of the options into a filter.
+func toFilter(filters any) (*tcvectordb.Filter, error) {
+	switch f := filters.(type) {
+	case nil:
+		return nil, nil
+	case *tcvectordb.Filter:
+		return f, nil
+	case string:
+		if f == "" {
+			return nil, nil
+		}
+		return tcvectordb.NewFilter(f), nil
+	case map[string]any:
+		keys := make([]string, 0, len(f))
+		for k := range f {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var filter *tcvectordb.Filter
+		for _, k := range keys {
+			var cond string
+			switch v := f[k].(type) {
+			case string:
+				cond = fmt.Sprintf(`%s = "%s"`, k, strings.ReplaceAll(v, `"`, `\"`))
+			case []string, []any, []int, []int64, []uint64, []float64:
+				cond = tcvectordb.In(k, v)
+			default:
+				cond = fmt.Sprintf("%s = %v", k, v)
+			}
+			if filter == nil {
+				filter = tcvectordb.NewFilter(cond)
+			} else {
+				filter.And(cond)
+			}
+		}
+		return filter, nil
+	}
+	return nil, ErrUnsupportedFilters
+}
+
+func newId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
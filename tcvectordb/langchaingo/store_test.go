@@ -0,0 +1,227 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package langchaingo_test
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/langchaingo"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var fruitAxes = map[string]int{"apple": 0, "banana": 1, "cherry": 2}
+
+func embedFruit(text string) []float32 {
+	vector := make([]float32, 4)
+	axis, ok := fruitAxes[text]
+	if !ok {
+		axis = 3
+	}
+	vector[axis] = 1
+	return vector
+}
+
+// fruitEmbedder embeds the known fruits into the axes.
+type fruitEmbedder struct{}
+
+func (fruitEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, embedFruit(text))
+	}
+	return vectors, nil
+}
+
+func (fruitEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return embedFruit(text), nil
+}
+
+// memoryClient keeps the upserted documents of each collection in memory, and scores them by the
+// inner product. It records the filters and the texts searched instead of applying them.
+type memoryClient struct {
+	collections map[string][]tcvectordb.Document
+	filters     []string
+	texts       []map[string][]string
+}
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{collections: make(map[string][]tcvectordb.Document)}
+}
+
+func (m *memoryClient) Upsert(ctx context.Context, databaseName, collectionName string, documents interface{},
+	params ...*tcvectordb.UpsertDocumentParams) (*tcvectordb.UpsertDocumentResult, error) {
+	key := databaseName + "." + collectionName
+	docs := documents.([]tcvectordb.Document)
+	m.collections[key] = append(m.collections[key], docs...)
+	return &tcvectordb.UpsertDocumentResult{AffectedCount: len(docs)}, nil
+}
+
+func (m *memoryClient) Search(ctx context.Context, databaseName, collectionName string, vectors [][]float32,
+	params ...*tcvectordb.SearchDocumentParams) (*tcvectordb.SearchDocumentResult, error) {
+	param := params[0]
+	filter := ""
+	if param.Filter != nil {
+		filter = param.Filter.Cond()
+	}
+	m.filters = append(m.filters, filter)
+
+	var found []tcvectordb.Document
+	for _, doc := range m.collections[databaseName+"."+collectionName] {
+		var score float32
+		for i, v := range doc.Vector {
+			score += v * vectors[0][i]
+		}
+		if param.Radius != nil && score < *param.Radius {
+			continue
+		}
+		doc.Score = score
+		found = append(found, doc)
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Score > found[j].Score })
+	if int64(len(found)) > param.Limit {
+		found = found[:param.Limit]
+	}
+	return &tcvectordb.SearchDocumentResult{Documents: [][]tcvectordb.Document{found}}, nil
+}
+
+func (m *memoryClient) SearchByText(ctx context.Context, databaseName, collectionName string, text map[string][]string,
+	params ...*tcvectordb.SearchDocumentParams) (*tcvectordb.SearchDocumentResult, error) {
+	m.texts = append(m.texts, text)
+	var found []tcvectordb.Document
+	for _, doc := range m.collections[databaseName+"."+collectionName] {
+		for field, values := range text {
+			if content, _ := doc.Fields[field].Val.(string); len(values) != 0 && content == values[0] {
+				doc.Score = 1
+				found = append(found, doc)
+			}
+		}
+	}
+	return &tcvectordb.SearchDocumentResult{Documents: [][]tcvectordb.Document{found}}, nil
+}
+
+func fruitDocuments() []schema.Document {
+	return []schema.Document{
+		{PageContent: "apple", Metadata: map[string]any{"id": "a", "color": "red"}},
+		{PageContent: "banana", Metadata: map[string]any{"color": "yellow"}},
+		{PageContent: "cherry", Metadata: map[string]any{"id": "c", "color": "red"}},
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	cli := newMemoryClient()
+
+	if _, err := langchaingo.New(langchaingo.WithClient(cli)); err != langchaingo.ErrMissingCollection {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store, err := langchaingo.New(
+		langchaingo.WithClient(cli),
+		langchaingo.WithDatabase("db"),
+		langchaingo.WithCollection("fruits"),
+		langchaingo.WithEmbedder(fruitEmbedder{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := store.AddDocuments(ctx, fruitDocuments())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != "a" || ids[1] == "" || ids[2] != "c" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+	upserted := cli.collections["db.fruits"]
+	if len(upserted) != 3 || upserted[1].Id != ids[1] || upserted[0].Fields["text"].Val != "apple" ||
+		upserted[0].Fields["color"].Val != "red" || upserted[0].Vector[0] != 1 {
+		t.Fatalf("unexpected upserted documents: %+v", upserted)
+	}
+	if _, ok := upserted[0].Fields["id"]; ok {
+		t.Fatalf("the id metadata should not be a field: %+v", upserted[0])
+	}
+
+	docs, err := store.SimilaritySearch(ctx, "cherry", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 || docs[0].PageContent != "cherry" || docs[0].Score != 1 ||
+		docs[0].Metadata["id"] != "c" || docs[0].Metadata["color"] != "red" {
+		t.Fatalf("unexpected documents: %+v", docs)
+	}
+
+	docs, err = store.SimilaritySearch(ctx, "cherry", 3, vectorstores.WithScoreThreshold(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].PageContent != "cherry" {
+		t.Fatalf("the score threshold is not applied: %+v", docs)
+	}
+
+	if _, err = store.SimilaritySearch(ctx, "cherry", 3,
+		vectorstores.WithFilters(map[string]any{"color": "yellow", "tags": []string{"sweet"}})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.SimilaritySearch(ctx, "cherry", 3, vectorstores.WithFilters(`color in ("red")`)); err != nil {
+		t.Fatal(err)
+	}
+	filters := cli.filters[len(cli.filters)-2:]
+	if filters[0] != `color = "yellow" and (tags in ("sweet"))` || filters[1] != `color in ("red")` {
+		t.Fatalf("unexpected filters: %q", filters)
+	}
+	if _, err := store.SimilaritySearch(ctx, "cherry", 3, vectorstores.WithFilters(1)); err != langchaingo.ErrUnsupportedFilters {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := store.AddDocuments(ctx, fruitDocuments()[:1], vectorstores.WithNameSpace("others")); err != nil {
+		t.Fatal(err)
+	}
+	docs, err = store.SimilaritySearch(ctx, "banana", 3, vectorstores.WithNameSpace("others"))
+	if err != nil || len(docs) != 1 || docs[0].PageContent != "apple" {
+		t.Fatalf("the namespace is not applied: %+v, %v", docs, err)
+	}
+}
+
+func TestStoreSearchByText(t *testing.T) {
+	ctx := context.Background()
+	cli := newMemoryClient()
+	store, err := langchaingo.New(langchaingo.WithClient(cli), langchaingo.WithDatabase("db"), langchaingo.WithCollection("fruits"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddDocuments(ctx, fruitDocuments()); err != nil {
+		t.Fatal(err)
+	}
+	if vector := cli.collections["db.fruits"][0].Vector; vector != nil {
+		t.Fatalf("the documents should be embedded by the collection: %v", vector)
+	}
+	docs, err := store.SimilaritySearch(ctx, "banana", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].PageContent != "banana" {
+		t.Fatalf("unexpected documents: %+v", docs)
+	}
+	if len(cli.texts) != 1 || strings.Join(cli.texts[0]["text"], ",") != "banana" {
+		t.Fatalf("unexpected texts: %v", cli.texts)
+	}
+}
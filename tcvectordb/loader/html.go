@@ -0,0 +1,234 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// [HTMLLoader] loads an HTML document as a Markdown document, keeping the headings, paragraphs, lists,
+// tables and preformatted blocks, and removing the scripts, styles and navigation. The title and the
+// description and keywords meta tags are the metadata.
+type HTMLLoader struct{}
+
+// [Load] loads the HTML document from the reader.
+func (l *HTMLLoader) Load(r io.Reader) ([]Document, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	metadata := map[string]interface{}{MetadataFormat: string(FormatHTML)}
+	walkHTML(root, func(n *html.Node) bool {
+		switch n.Data {
+		case "title":
+			if title := strings.TrimSpace(nodeText(n)); title != "" {
+				metadata[MetadataTitle] = title
+			}
+		case "meta":
+			name, content := htmlAttr(n, "name"), htmlAttr(n, "content")
+			if (name == "description" || name == "keywords" || name == "author") && content != "" {
+				metadata[name] = content
+			}
+		case "body":
+			return false
+		}
+		return true
+	})
+	w := &markdownWriter{}
+	w.render(root)
+	return []Document{{Text: w.String(), Format: FormatMarkdown, Metadata: metadata}}, nil
+}
+
+var htmlSkippedTags = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true, "nav": true, "iframe": true,
+}
+
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "dd": true, "div": true, "dl": true,
+	"dt": true, "figcaption": true, "figure": true, "footer": true, "form": true, "header": true, "main": true,
+	"p": true, "section": true, "ol": true, "ul": true,
+}
+
+// markdownWriter renders the HTML nodes into Markdown.
+type markdownWriter struct {
+	b     strings.Builder
+	space bool
+}
+
+func (w *markdownWriter) String() string {
+	return strings.TrimSpace(w.b.String())
+}
+
+// breakLines ends the current line, and ensures n line breaks at the end.
+func (w *markdownWriter) breakLines(n int) {
+	w.space = false
+	text := w.b.String()
+	if text == "" {
+		return
+	}
+	for trailing := len(text) - len(strings.TrimRight(text, "\n")); trailing < n; trailing++ {
+		w.b.WriteByte('\n')
+	}
+}
+
+func (w *markdownWriter) writeText(text string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		if text != "" {
+			w.space = true
+		}
+		return
+	}
+	if strings.IndexAny(text[:1], " \t\r\n") == 0 {
+		w.space = true
+	}
+	for i, field := range fields {
+		if (i > 0 || w.space) && w.b.Len() != 0 && !strings.HasSuffix(w.b.String(), "\n") {
+			w.b.WriteByte(' ')
+		}
+		w.b.WriteString(field)
+	}
+	w.space = strings.IndexAny(text[len(text)-1:], " \t\r\n") == 0
+}
+
+func (w *markdownWriter) render(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.writeText(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		w.renderChildren(n)
+		return
+	}
+	switch tag := n.Data; {
+	case htmlSkippedTags[tag]:
+	case len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6':
+		if title := strings.Join(strings.Fields(nodeText(n)), " "); title != "" {
+			w.breakLines(2)
+			w.b.WriteString(strings.Repeat("#", int(tag[1]-'0')) + " " + title)
+			w.breakLines(2)
+		}
+	case tag == "br":
+		w.breakLines(1)
+	case tag == "hr":
+		w.breakLines(2)
+	case tag == "li":
+		w.breakLines(1)
+		w.b.WriteString("- ")
+		w.renderChildren(n)
+		w.breakLines(1)
+	case tag == "pre":
+		w.breakLines(2)
+		w.b.WriteString("```\n" + strings.Trim(nodeText(n), "\n") + "\n```")
+		w.breakLines(2)
+	case tag == "table":
+		w.breakLines(2)
+		w.renderTable(n)
+		w.breakLines(2)
+	case htmlBlockTags[tag]:
+		w.breakLines(2)
+		w.renderChildren(n)
+		w.breakLines(2)
+	default:
+		w.renderChildren(n)
+	}
+}
+
+func (w *markdownWriter) renderChildren(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.render(c)
+	}
+}
+
+// renderTable renders the rows of the table as a Markdown table, with the first row as the header.
+func (w *markdownWriter) renderTable(table *html.Node) {
+	var rows [][]string
+	walkHTML(table, func(n *html.Node) bool {
+		if n.Data == "table" && n != table {
+			return false
+		}
+		if n.Data == "tr" {
+			var row []string
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && (c.Data == "td" || c.Data == "th") {
+					cell := strings.Join(strings.Fields(nodeText(c)), " ")
+					row = append(row, strings.ReplaceAll(cell, "|", `\|`))
+				}
+			}
+			if len(row) != 0 {
+				rows = append(rows, row)
+			}
+			return false
+		}
+		return true
+	})
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		w.b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			w.b.WriteString(strings.Repeat("| --- ", columns) + "|\n")
+		}
+	}
+}
+
+// walkHTML visits the element nodes in depth-first order, and skips the children of a node if visit returns false.
+func walkHTML(n *html.Node, visit func(n *html.Node) bool) {
+	if n.Type == html.ElementNode && !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkHTML(c, visit)
+	}
+}
+
+// nodeText returns the text in the node, without the scripts and styles.
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.Type == html.ElementNode && n.Data != "title" && htmlSkippedTags[n.Data] {
+		return ""
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(nodeText(c))
+	}
+	return b.String()
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/splitter"
+)
+
+const (
+	defaultIngestChunkSize    = 500
+	defaultIngestChunkOverlap = 50
+	defaultIngestBatchSize    = 100
+)
+
+// [IngestParams] holds the parameters for splitting the documents and upserting the chunks.
+//
+// Fields:
+//   - Splitter: (Optional) The splitter of all the documents (defaults to a [splitter.MarkdownHeaderSplitter]
+//     splitting the sections further by a [splitter.RecursiveCharacterSplitter] for Markdown, and a
+//     [splitter.RecursiveCharacterSplitter] for the other formats).
+//   - ChunkSize: (Optional) The chunk size of the default splitters in characters (defaults to 500).
+//   - ChunkOverlap: (Optional) The chunk overlap of the default splitters in characters (defaults to 50).
+//     Set it to 0 for no overlap.
+//   - BatchSize: (Optional) The number of the chunks in an upsert or the sources in a delete (defaults to 100).
+//   - DeleteStale: (Optional) Whether to delete the existing chunks of the documents by their doc_id before
+//     upserting, so that no chunk is left over when a source gets shorter. It needs a filter index of doc_id.
+//   - UpsertParams: (Optional) A pointer to an [tcvectordb.UpsertDocumentParams] object for the upserts.
+type IngestParams struct {
+	Splitter     splitter.Splitter
+	ChunkSize    int
+	ChunkOverlap *int
+	BatchSize    int
+	DeleteStale  bool
+	UpsertParams *tcvectordb.UpsertDocumentParams
+}
+
+// [IngestResult] holds the results for ingesting the documents.
+//
+// Fields:
+//   - Chunks: The number of the chunks upserted.
+//   - AffectedCount: The number of the documents affected in the collection.
+//   - TokenUsed: The number of the tokens used to embed the chunks, if they are embedded.
+type IngestResult struct {
+	Chunks        int
+	AffectedCount int
+	TokenUsed     uint64
+}
+
+// [Split] splits the documents into chunks, and converts the chunks into the documents to upsert, with
+// the fields of [splitter.Documents] and the metadata of the documents.
+//
+// Notes: The ids are "<hash>-<n>-<index>", where the hash is of the source, n is the position of the
+// document among the documents of the same source, and index is the index of the chunk, and the doc_id
+// is "<hash>-<n>". Loading the same files again overwrites their chunks, but the chunks beyond the new
+// last index are left over when a file gets shorter, unless they are deleted by the doc_id first, as
+// [Ingest] does with DeleteStale. The documents without a source are hashed by their texts.
+func Split(docs []Document, params *IngestParams) []tcvectordb.Document {
+	chunks, _ := split(docs, params)
+	return chunks
+}
+
+// split splits the documents into chunks, and returns the chunks with the doc_id of each document.
+func split(docs []Document, params *IngestParams) ([]tcvectordb.Document, []string) {
+	if params == nil {
+		params = &IngestParams{}
+	}
+	text, markdown := params.Splitter, params.Splitter
+	if params.Splitter == nil {
+		size, overlap := params.ChunkSize, defaultIngestChunkOverlap
+		if size <= 0 {
+			size = defaultIngestChunkSize
+		}
+		if params.ChunkOverlap != nil && *params.ChunkOverlap >= 0 {
+			overlap = *params.ChunkOverlap
+		}
+		recursive := &splitter.RecursiveCharacterSplitter{ChunkSize: size, ChunkOverlap: overlap}
+		text, markdown = recursive, &splitter.MarkdownHeaderSplitter{Splitter: recursive}
+	}
+
+	var result []tcvectordb.Document
+	var docIds []string
+	seen := make(map[string]int)
+	for _, doc := range docs {
+		s := text
+		if doc.Format == FormatMarkdown {
+			s = markdown
+		}
+		key, _ := doc.Metadata[MetadataSource].(string)
+		if key == "" {
+			key = doc.Text
+		}
+		sum := sha256.Sum256([]byte(key))
+		n := seen[key]
+		seen[key]++
+		prefix := fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:8]), n)
+		chunks := splitter.WithMetadata(s.Split(doc.Text), doc.Metadata)
+		result = append(result, splitter.Documents(chunks, prefix)...)
+		docIds = append(docIds, prefix)
+	}
+	return result, docIds
+}
+
+// [Ingest] splits the documents by [Split], and upserts the chunks into a collection in batches.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to upsert with, such as [tcvectordb.Client], [tcvectordb.RpcClient] or [tcvectordb.VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - docs: The documents loaded.
+//   - params: (Optional) A pointer to an [IngestParams] object. See [IngestParams] for more information.
+//
+// Notes: The chunks have no vectors, so the collection should embed the text field on the server by
+// [tcvectordb.Embedding], or on the client by [tcvectordb.ClientEmbeddingConfig]. With DeleteStale, all
+// the existing chunks of the documents are deleted before the first upsert, so they are missing until the
+// ingest finishes, and stay missing if it fails.
+//
+// Returns a pointer to an [IngestResult] object, which holds the progress if an upsert fails, or an error.
+func Ingest(ctx context.Context, cli tcvectordb.FlatDocumentInterface, databaseName, collectionName string,
+	docs []Document, params *IngestParams) (*IngestResult, error) {
+	if params == nil {
+		params = &IngestParams{}
+	}
+	batchSize := params.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultIngestBatchSize
+	}
+	var upsertParams []*tcvectordb.UpsertDocumentParams
+	if params.UpsertParams != nil {
+		upsertParams = append(upsertParams, params.UpsertParams)
+	}
+	chunks, docIds := split(docs, params)
+	result := new(IngestResult)
+	if params.DeleteStale {
+		for start := 0; start < len(docIds); start += batchSize {
+			end := start + batchSize
+			if end > len(docIds) {
+				end = len(docIds)
+			}
+			_, err := cli.Delete(ctx, databaseName, collectionName, tcvectordb.DeleteDocumentParams{
+				Filter: tcvectordb.NewFilter(tcvectordb.In(splitter.FieldDocId, docIds[start:end])),
+			})
+			if err != nil {
+				return result, fmt.Errorf("delete stale chunks failed. err: %v", err)
+			}
+		}
+	}
+	for start := 0; start < len(chunks); start += batchSize {
+		end := start + batchSize
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+		res, err := cli.Upsert(ctx, databaseName, collectionName, chunks[start:end], upsertParams...)
+		if err != nil {
+			return result, fmt.Errorf("upsert chunks [%v, %v) failed. err: %v", start, end, err)
+		}
+		result.Chunks = end
+		result.AffectedCount += res.AffectedCount
+		result.TokenUsed += tokenUsed(res.EmbeddingExtraInfo)
+	}
+	return result, nil
+}
+
+func tokenUsed(info *document.EmbeddingExtraInfo) uint64 {
+	if info == nil {
+		return 0
+	}
+	return info.TokenUsed
+}
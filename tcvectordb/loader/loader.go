@@ -0,0 +1,218 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package loader loads the files of the common text formats into texts with metadata, to be split by
+// the splitter package and upserted into the base collections, where the AI collections parse the files
+// on the server. It works offline, and supports plain text, Markdown, HTML, CSV and JSON Lines:
+//
+//	docs, err := loader.LoadDir("./manuals")
+//	...
+//	res, err := loader.Ingest(ctx, cli, "db", "manuals", docs, nil)
+//
+// HTML is converted into Markdown with the headings, lists and tables kept, so both are split by the
+// headings by default. The front matter of Markdown, the title and meta tags of HTML, and the columns
+// of CSV and the fields of JSON Lines chosen as metadata, become the metadata of the documents.
+package loader
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The metadata set by the loaders.
+const (
+	MetadataSource = "source"
+	MetadataFormat = "format"
+	MetadataTitle  = "title"
+	MetadataRow    = "row"
+	MetadataLine   = "line"
+)
+
+// [Format] is the format of a file.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatCSV      Format = "csv"
+	FormatJSONL    Format = "jsonl"
+)
+
+var formatExtensions = map[string]Format{
+	".txt":      FormatText,
+	".text":     FormatText,
+	".log":      FormatText,
+	".md":       FormatMarkdown,
+	".markdown": FormatMarkdown,
+	".html":     FormatHTML,
+	".htm":      FormatHTML,
+	".csv":      FormatCSV,
+	".tsv":      FormatCSV,
+	".jsonl":    FormatJSONL,
+	".ndjson":   FormatJSONL,
+}
+
+// [FormatOf] returns the format of a file by its extension, or false if it is not supported.
+func FormatOf(path string) (Format, bool) {
+	format, ok := formatExtensions[strings.ToLower(filepath.Ext(path))]
+	return format, ok
+}
+
+// [Document] holds a text loaded from a file.
+//
+// Fields:
+//   - Text: The text. It is Markdown for the Markdown and HTML files.
+//   - Format: The format of the text, which is [FormatMarkdown] for the HTML files.
+//   - Metadata: The metadata of the text, such as the source, the title and the front matter.
+type Document struct {
+	Text     string
+	Format   Format
+	Metadata map[string]interface{}
+}
+
+// [Loader] loads the documents from a reader.
+type Loader interface {
+	Load(r io.Reader) ([]Document, error)
+}
+
+// [LoaderOf] returns the [Loader] of the format with the default options.
+func LoaderOf(format Format) (Loader, error) {
+	switch format {
+	case FormatText:
+		return &TextLoader{}, nil
+	case FormatMarkdown:
+		return &MarkdownLoader{}, nil
+	case FormatHTML:
+		return &HTMLLoader{}, nil
+	case FormatCSV:
+		return &CSVLoader{}, nil
+	case FormatJSONL:
+		return &JSONLLoader{}, nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// [Load] loads the documents of the format from a reader with the default options.
+func Load(r io.Reader, format Format) ([]Document, error) {
+	l, err := LoaderOf(format)
+	if err != nil {
+		return nil, err
+	}
+	return l.Load(r)
+}
+
+// [LoadFile] loads the documents from a file by the format of its extension, and sets the source
+// of the documents to the path. A tab-separated ".tsv" file is loaded as CSV with the tab as the comma.
+//
+// Parameters:
+//   - path: The path of the file.
+//   - loader: (Optional) The loader to use instead of the default one of the format.
+//
+// Returns the documents or an error.
+func LoadFile(path string, loader ...Loader) ([]Document, error) {
+	var l Loader
+	if len(loader) != 0 && loader[0] != nil {
+		l = loader[0]
+	} else {
+		format, ok := FormatOf(path)
+		if !ok {
+			return nil, fmt.Errorf("unsupported file %v", path)
+		}
+		l, _ = LoaderOf(format)
+		if strings.EqualFold(filepath.Ext(path), ".tsv") {
+			l = &CSVLoader{Comma: '\t'}
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	docs, err := l.Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("load %v failed. err: %v", path, err)
+	}
+	for i := range docs {
+		if docs[i].Metadata == nil {
+			docs[i].Metadata = make(map[string]interface{})
+		}
+		docs[i].Metadata[MetadataSource] = path
+	}
+	return docs, nil
+}
+
+// [LoadDir] loads the documents from the files of the supported formats in a directory and its
+// subdirectories, in the order of the paths. The other files and the hidden files are skipped.
+func LoadDir(dir string) ([]Document, error) {
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, ok := FormatOf(path); ok && !info.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	var docs []Document
+	for _, path := range paths {
+		loaded, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, loaded...)
+	}
+	return docs, nil
+}
+
+// [TextLoader] loads a plain text as a document.
+type TextLoader struct{}
+
+// [Load] loads the text from the reader.
+func (l *TextLoader) Load(r io.Reader) ([]Document, error) {
+	text, err := readText(r)
+	if err != nil {
+		return nil, err
+	}
+	return []Document{{Text: text, Format: FormatText, Metadata: map[string]interface{}{MetadataFormat: string(FormatText)}}}, nil
+}
+
+// readText reads the text, removing the byte order mark and the carriage returns of the line breaks.
+func readText(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	return strings.ReplaceAll(text, "\r\n", "\n"), nil
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var markdownTitleRegexp = regexp.MustCompile(`(?m)^ {0,3}#[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+
+// [MarkdownLoader] loads a Markdown text as a document. The YAML front matter between the "---" lines
+// at the start is parsed into the metadata and removed from the text, and the title is taken from the
+// front matter or the first level 1 heading.
+type MarkdownLoader struct{}
+
+// [Load] loads the Markdown text from the reader.
+func (l *MarkdownLoader) Load(r io.Reader) ([]Document, error) {
+	text, err := readText(r)
+	if err != nil {
+		return nil, err
+	}
+	metadata, body, err := parseFrontMatter(text)
+	if err != nil {
+		return nil, err
+	}
+	metadata[MetadataFormat] = string(FormatMarkdown)
+	if _, ok := metadata[MetadataTitle]; !ok {
+		if m := markdownTitleRegexp.FindStringSubmatch(body); m != nil {
+			metadata[MetadataTitle] = m[1]
+		}
+	}
+	return []Document{{Text: body, Format: FormatMarkdown, Metadata: metadata}}, nil
+}
+
+// parseFrontMatter parses the YAML front matter of the text, and returns it with the rest of the text.
+func parseFrontMatter(text string) (map[string]interface{}, string, error) {
+	metadata := make(map[string]interface{})
+	if !strings.HasPrefix(text, "---\n") {
+		return metadata, text, nil
+	}
+	rest := text[len("---\n"):]
+	end := strings.Index("\n"+rest, "\n---")
+	if end < 0 {
+		return metadata, text, nil
+	}
+	matter := rest[:end]
+	body := rest[end:]
+	if i := strings.IndexByte(body[1:], '\n'); i >= 0 {
+		body = body[i+2:]
+	} else {
+		body = ""
+	}
+	if err := yaml.Unmarshal([]byte(matter), &metadata); err != nil {
+		return nil, "", fmt.Errorf("parse front matter failed. err: %v", err)
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	return metadata, body, nil
+}
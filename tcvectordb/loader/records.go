@@ -0,0 +1,174 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// [CSVLoader] loads the rows of a CSV file with a header as the documents. The text of a document is
+// the value of the only text column, or the "column: value" lines of the text columns.
+//
+// Fields:
+//   - TextColumns: (Optional) The columns of the text (defaults to the columns not in MetadataColumns).
+//   - MetadataColumns: (Optional) The columns of the metadata.
+//   - Comma: (Optional) The field delimiter (defaults to ',').
+type CSVLoader struct {
+	TextColumns     []string
+	MetadataColumns []string
+	Comma           rune
+}
+
+// [Load] loads the rows from the reader.
+func (l *CSVLoader) Load(r io.Reader) ([]Document, error) {
+	reader := csv.NewReader(r)
+	if l.Comma != 0 {
+		reader.Comma = l.Comma
+	}
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) != 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+	isMetadata := make(map[string]bool)
+	for _, name := range l.MetadataColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("metadata column %q not found", name)
+		}
+		isMetadata[name] = true
+	}
+	textColumns := l.TextColumns
+	if len(textColumns) == 0 {
+		for _, name := range header {
+			if !isMetadata[name] {
+				textColumns = append(textColumns, name)
+			}
+		}
+	}
+	for _, name := range textColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("text column %q not found", name)
+		}
+	}
+
+	var docs []Document
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		var text string
+		if len(textColumns) == 1 {
+			text = value(textColumns[0])
+		} else {
+			lines := make([]string, 0, len(textColumns))
+			for _, name := range textColumns {
+				lines = append(lines, name+": "+value(name))
+			}
+			text = strings.Join(lines, "\n")
+		}
+		metadata := map[string]interface{}{MetadataFormat: string(FormatCSV), MetadataRow: row}
+		for _, name := range l.MetadataColumns {
+			metadata[name] = value(name)
+		}
+		docs = append(docs, Document{Text: text, Format: FormatText, Metadata: metadata})
+	}
+	return docs, nil
+}
+
+// [JSONLLoader] loads the JSON objects of a JSON Lines file as the documents.
+//
+// Fields:
+//   - TextField: (Optional) The field of the text (defaults to "text").
+//   - MetadataFields: (Optional) The fields of the metadata (defaults to all the other fields).
+type JSONLLoader struct {
+	TextField      string
+	MetadataFields []string
+}
+
+// [Load] loads the objects from the reader. The blank lines are skipped, and the numbers are kept
+// as json.Number in the metadata.
+func (l *JSONLLoader) Load(r io.Reader) ([]Document, error) {
+	textField := l.TextField
+	if textField == "" {
+		textField = "text"
+	}
+	reader := bufio.NewReader(r)
+	var docs []Document
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if strings.TrimSpace(string(data)) != "" {
+			var object map[string]interface{}
+			decoder := json.NewDecoder(strings.NewReader(string(data)))
+			decoder.UseNumber()
+			if err := decoder.Decode(&object); err != nil {
+				return nil, fmt.Errorf("line %v: %v", line, err)
+			}
+			text, ok := object[textField].(string)
+			if !ok {
+				return nil, fmt.Errorf("line %v: text field %q is not a string", line, textField)
+			}
+			metadata := map[string]interface{}{MetadataFormat: string(FormatJSONL), MetadataLine: line}
+			if len(l.MetadataFields) != 0 {
+				for _, name := range l.MetadataFields {
+					if v, ok := object[name]; ok {
+						metadata[name] = v
+					}
+				}
+			} else {
+				for name, v := range object {
+					if name != textField {
+						metadata[name] = v
+					}
+				}
+			}
+			docs = append(docs, Document{Text: text, Format: FormatText, Metadata: metadata})
+		}
+		if err == io.EOF {
+			return docs, nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+import (
+	"context"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api"
+)
+
+// localURL is the address of the engine for the HTTP client, which never leaves the process.
+const localURL = "http://local"
+
+// [Client] is a [tcvectordb.VdbClient] on an [Engine] in the process. It is the [tcvectordb.Client]
+// of the SDK with the engine as the transport, so that the same code runs on the server and on
+// the local files.
+type Client struct {
+	*tcvectordb.Client
+	engine *Engine
+}
+
+var _ tcvectordb.VdbClient = &Client{}
+
+// [NewClient] opens the engine on a directory, and creates a [Client] on it.
+//
+// Parameters:
+//   - dir: The directory to save the data, which is created if it does not exist. An empty dir
+//     keeps the data in memory.
+//   - option: A pointer to an [Option] object. See [Option] for more information.
+//
+// Notes: The users, AI databases and embedding apis of the server are not supported by the local
+// engine, and return errors.
+//
+// Returns a pointer to a [Client] object or an error.
+func NewClient(dir string, option *Option) (*Client, error) {
+	engine, err := Open(dir, option)
+	if err != nil {
+		return nil, err
+	}
+	timeout := engine.option.Timeout
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+	cli, err := tcvectordb.NewClient(localURL, "root", "local", &tcvectordb.ClientOption{
+		Transport:       engine,
+		Timeout:         timeout,
+		ReadConsistency: api.StrongConsistency,
+	})
+	if err != nil {
+		engine.Close()
+		return nil, err
+	}
+	return &Client{Client: cli, engine: engine}, nil
+}
+
+// [Engine] returns the engine of the client.
+func (c *Client) Engine() *Engine {
+	return c.engine
+}
+
+// [Close] closes the engine of the client.
+func (c *Client) Close() {
+	c.Client.Close()
+	c.engine.Close()
+}
+
+// [ExistsCollection] checks if the collection exists in the database.
+func (c *Client) ExistsCollection(ctx context.Context, databaseName, collectionName string) (bool, error) {
+	return c.Database(databaseName).ExistsCollection(ctx, collectionName)
+}
+
+// [CreateCollectionIfNotExists] creates a collection in the database if it doesn't exist.
+func (c *Client) CreateCollectionIfNotExists(ctx context.Context, databaseName, collectionName string, shardNum,
+	replicasNum uint32, description string, indexes tcvectordb.Indexes,
+	params ...*tcvectordb.CreateCollectionParams) (*tcvectordb.Collection, error) {
+	return c.Database(databaseName).CreateCollectionIfNotExists(ctx, collectionName, shardNum, replicasNum, description, indexes, params...)
+}
+
+// [CreateCollection] creates a collection in the database.
+func (c *Client) CreateCollection(ctx context.Context, databaseName, collectionName string, shardNum, replicasNum uint32,
+	description string, indexes tcvectordb.Indexes, params ...*tcvectordb.CreateCollectionParams) (*tcvectordb.Collection, error) {
+	return c.Database(databaseName).CreateCollection(ctx, collectionName, shardNum, replicasNum, description, indexes, params...)
+}
+
+// [ListCollection] retrieves the list of the collections in the database.
+func (c *Client) ListCollection(ctx context.Context, databaseName string) (*tcvectordb.ListCollectionResult, error) {
+	return c.Database(databaseName).ListCollection(ctx)
+}
+
+// [DescribeCollection] retrieves the information of the collection in the database.
+func (c *Client) DescribeCollection(ctx context.Context, databaseName, collectionName string) (*tcvectordb.DescribeCollectionResult, error) {
+	return c.Database(databaseName).DescribeCollection(ctx, collectionName)
+}
+
+// [DropCollection] drops the collection in the database.
+func (c *Client) DropCollection(ctx context.Context, databaseName, collectionName string) (*tcvectordb.DropCollectionResult, error) {
+	return c.Database(databaseName).DropCollection(ctx, collectionName)
+}
+
+// [TruncateCollection] clears all the documents of the collection in the database.
+func (c *Client) TruncateCollection(ctx context.Context, databaseName, collectionName string) (*tcvectordb.TruncateCollectionResult, error) {
+	return c.Database(databaseName).TruncateCollection(ctx, collectionName)
+}
@@ -0,0 +1,904 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/collection"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+)
+
+// compactThreshold is the minimum number of log entries before the log is compacted.
+const compactThreshold = 1000
+
+// collectionMeta is the schema of a collection, which is saved as collection.json.
+type collectionMeta struct {
+	Description       string                        `json:"description,omitempty"`
+	ShardNum          uint32                        `json:"shardNum,omitempty"`
+	ReplicaNum        uint32                        `json:"replicaNum,omitempty"`
+	CreateTime        string                        `json:"createTime"`
+	Indexes           []*api.IndexColumn            `json:"indexes"`
+	FilterIndexConfig *collection.FilterIndexConfig `json:"filterIndexConfig,omitempty"`
+	TtlConfig         *collection.TtlConfig         `json:"ttlConfig,omitempty"`
+}
+
+// localCollection holds the documents of a collection and their indexes.
+type localCollection struct {
+	mu      sync.RWMutex
+	name    string
+	dir     string
+	meta    collectionMeta
+	docs    map[string]*storedDoc
+	seq     uint64
+	primary *api.IndexColumn
+	vectors map[string]*vectorField
+	sparse  map[string]*sparseField
+	filters map[string]*api.IndexColumn
+	log     *documentLog
+	dropped bool
+}
+
+// storedDoc is a document with the vectors parsed from it. The seq orders the documents by the time
+// they were last written.
+type storedDoc struct {
+	seq     uint64
+	doc     *document.Document
+	vectors map[string][]float32
+	sparse  map[string]map[int64]float32
+	nodes   map[string]int
+}
+
+// vectorField is a vector index. The graph is nil for the index types searched by brute force.
+type vectorField struct {
+	column *api.IndexColumn
+	metric metric
+	binary bool
+	graph  *hnsw
+}
+
+func isVectorType(fieldType string) bool {
+	switch tcvectordb.FieldType(fieldType) {
+	case tcvectordb.Vector, tcvectordb.BinaryVector, tcvectordb.Float16Vector, tcvectordb.BFloat16Vector:
+		return true
+	}
+	return false
+}
+
+// newCollection validates the create request and creates the collection, which is saved in dir
+// unless dir is empty.
+func newCollection(dir string, req *collection.CreateReq, sync bool) (*localCollection, error) {
+	if req.Embedding.Field != "" || req.Embedding.Model != "" {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "embedding is not supported by the local engine, "+
+			"upsert the vectors instead")
+	}
+	c := &localCollection{
+		name: req.Collection,
+		dir:  dir,
+		meta: collectionMeta{
+			Description:       req.Description,
+			ShardNum:          req.ShardNum,
+			ReplicaNum:        req.ReplicaNum,
+			CreateTime:        time.Now().Format("2006-01-02 15:04:05"),
+			Indexes:           req.Indexes,
+			FilterIndexConfig: req.FilterIndexConfig,
+			TtlConfig:         req.TtlConfig,
+		},
+	}
+	if err := c.buildIndexes(); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		if err := writeJSON(filepath.Join(dir, collectionFile), &c.meta); err != nil {
+			return nil, err
+		}
+		var err error
+		if c.log, err = openDocumentLog(dir, sync); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// openCollection loads the collection saved in dir, and replays its documents.
+func openCollection(dir, name string, sync bool) (*localCollection, error) {
+	c := &localCollection{name: name, dir: dir}
+	if err := readJSON(filepath.Join(dir, collectionFile), &c.meta); err != nil {
+		return nil, err
+	}
+	if err := c.buildIndexes(); err != nil {
+		return nil, err
+	}
+	var err error
+	if c.log, err = openDocumentLog(dir, sync); err != nil {
+		return nil, err
+	}
+	err = c.log.replay(func(entry *logEntry) {
+		if entry.Upsert != nil {
+			if d, err := c.parse(entry.Upsert); err == nil {
+				c.put(d)
+			}
+		}
+		for _, id := range entry.Delete {
+			c.remove(id)
+		}
+	})
+	if err != nil {
+		c.log.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// buildIndexes validates the index columns, and indexes the documents again.
+func (c *localCollection) buildIndexes() error {
+	c.primary = nil
+	c.vectors = make(map[string]*vectorField)
+	c.sparse = make(map[string]*sparseField)
+	c.filters = make(map[string]*api.IndexColumn)
+	for _, column := range c.meta.Indexes {
+		if column == nil || column.FieldName == "" {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "the field name of indexes is required")
+		}
+		if c.vectors[column.FieldName] != nil || c.sparse[column.FieldName] != nil || c.filters[column.FieldName] != nil ||
+			(c.primary != nil && c.primary.FieldName == column.FieldName) {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "duplicated index field %v", column.FieldName)
+		}
+		switch {
+		case column.IndexType == string(tcvectordb.PRIMARY):
+			if column.FieldName != "id" || column.FieldType != string(tcvectordb.String) {
+				return newError(tcvectordb.ERR_SYNTAX_ERROR, "the primary key must be the string field id")
+			}
+			c.primary = column
+		case isVectorType(column.FieldType):
+			field, err := newVectorField(column)
+			if err != nil {
+				return err
+			}
+			c.vectors[column.FieldName] = field
+		case column.FieldType == string(tcvectordb.SparseVector):
+			c.sparse[column.FieldName] = newSparseField()
+		default:
+			switch tcvectordb.FieldType(column.FieldType) {
+			case tcvectordb.Uint64, tcvectordb.Int64, tcvectordb.Double, tcvectordb.String, tcvectordb.Array, tcvectordb.Json:
+			default:
+				return newError(tcvectordb.ERR_SYNTAX_ERROR, "unsupported field type %v of index %v", column.FieldType, column.FieldName)
+			}
+			c.filters[column.FieldName] = column
+		}
+	}
+	if c.primary == nil {
+		return newError(tcvectordb.ERR_SYNTAX_ERROR, "the primary key id is required")
+	}
+	if len(c.vectors) == 0 && len(c.sparse) == 0 {
+		return newError(tcvectordb.ERR_SYNTAX_ERROR, "a vector index is required")
+	}
+	for _, d := range c.sortedDocs() {
+		d.nodes = nil
+		c.index(d)
+	}
+	return nil
+}
+
+func newVectorField(column *api.IndexColumn) (*vectorField, error) {
+	if column.Dimension == 0 {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "the dimension of vector index %v is required", column.FieldName)
+	}
+	m, ok := newMetric(column.MetricType)
+	if !ok {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "unsupported metric type %v of index %v", column.MetricType, column.FieldName)
+	}
+	field := &vectorField{column: column, metric: m, binary: column.FieldType == string(tcvectordb.BinaryVector)}
+	if field.binary && (m.name != tcvectordb.HAMMING || column.Dimension%8 != 0) {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "binary vector index %v requires the Hamming metric "+
+			"and a dimension of a multiple of 8", column.FieldName)
+	}
+	if !field.binary && m.name == tcvectordb.HAMMING {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "the Hamming metric requires a binary vector index")
+	}
+	switch tcvectordb.IndexType(column.IndexType) {
+	case tcvectordb.HNSW, tcvectordb.BIN_HNSW:
+		M, efConstruction := 0, 0
+		if column.Params != nil {
+			M, efConstruction = int(column.Params.M), int(column.Params.EfConstruction)
+		}
+		field.graph = newHNSW(m, M, efConstruction)
+	case tcvectordb.FLAT, tcvectordb.BIN_FLAT, tcvectordb.DISK_FLAT, tcvectordb.IVF_FLAT, tcvectordb.IVF_PQ,
+		tcvectordb.IVF_SQ4, tcvectordb.IVF_SQ8, tcvectordb.IVF_SQ16, tcvectordb.IVF_RABITQ:
+	default:
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "unsupported index type %v of index %v", column.IndexType, column.FieldName)
+	}
+	return field, nil
+}
+
+// dimension returns the number of float32 values of a vector, where a value of a binary vector holds 8 bits.
+func (f *vectorField) dimension() int {
+	if f.binary {
+		return int(f.column.Dimension / 8)
+	}
+	return int(f.column.Dimension)
+}
+
+// isVectorField reports whether the field is a dense or sparse vector, which is only returned with retrieveVector.
+func (c *localCollection) isVectorField(name string) bool {
+	return c.vectors[name] != nil || c.sparse[name] != nil
+}
+
+// parse extracts the vectors of the document.
+func (c *localCollection) parse(doc *document.Document) (*storedDoc, error) {
+	d := &storedDoc{doc: doc, vectors: make(map[string][]float32), sparse: make(map[string]map[int64]float32)}
+	for name, field := range c.vectors {
+		vector := doc.Vector
+		if name != "vector" {
+			var err error
+			if vector, err = toVector(doc.Fields[name]); err != nil {
+				return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "document %v: invalid vector %v: %v", doc.Id, name, err)
+			}
+		}
+		if len(vector) == 0 {
+			return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "document %v: vector %v is required", doc.Id, name)
+		}
+		if len(vector) != field.dimension() {
+			return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "document %v: the dimension of vector %v is %v, but %v is required",
+				doc.Id, name, len(vector), field.dimension())
+		}
+		d.vectors[name] = vector
+	}
+	for name := range c.sparse {
+		items := doc.SparseVector
+		if name != "sparse_vector" {
+			list, _ := doc.Fields[name].([]interface{})
+			items = nil
+			for _, item := range list {
+				pair, _ := item.([]interface{})
+				items = append(items, pair)
+			}
+		}
+		terms, err := toSparseVector(items)
+		if err != nil {
+			return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "document %v: invalid sparse vector %v: %v", doc.Id, name, err)
+		}
+		if len(terms) != 0 {
+			d.sparse[name] = terms
+		}
+	}
+	return d, nil
+}
+
+// validate checks the id and the types of the filter fields of a document to write, and generates
+// the id if the primary key is auto.
+func (c *localCollection) validate(doc *document.Document) error {
+	if doc.Id == "" {
+		if c.primary.AutoId != "uuid" {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "the document id is required")
+		}
+		doc.Id = newUUID()
+	}
+	for name, value := range doc.Fields {
+		column := c.filters[name]
+		if column == nil {
+			continue
+		}
+		if !matchesType(tcvectordb.FieldType(column.FieldType), value) {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "document %v: field %v must be %v", doc.Id, name, column.FieldType)
+		}
+		if column.FieldType == string(tcvectordb.Array) {
+			for _, elem := range value.([]interface{}) {
+				if _, ok := elem.(string); !ok {
+					return newError(tcvectordb.ERR_SYNTAX_ERROR, "document %v: the elements of field %v must be string", doc.Id, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func matchesType(fieldType tcvectordb.FieldType, value interface{}) bool {
+	switch fieldType {
+	case tcvectordb.String:
+		_, ok := value.(string)
+		return ok
+	case tcvectordb.Array:
+		_, ok := value.([]interface{})
+		return ok
+	case tcvectordb.Json:
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	n, ok := value.(json.Number)
+	if !ok {
+		return false
+	}
+	switch fieldType {
+	case tcvectordb.Uint64:
+		return !strings.ContainsAny(string(n), "-.eE")
+	case tcvectordb.Int64:
+		_, err := n.Int64()
+		return err == nil
+	}
+	_, err := n.Float64()
+	return err == nil
+}
+
+func toVector(value interface{}) ([]float32, error) {
+	if value == nil {
+		return nil, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expect a list of numbers")
+	}
+	vector := make([]float32, 0, len(list))
+	for _, v := range list {
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, fmt.Errorf("expect a list of numbers")
+		}
+		vector = append(vector, float32(f))
+	}
+	return vector, nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toSparseVector(items [][]interface{}) (map[int64]float32, error) {
+	terms := make(map[int64]float32, len(items))
+	for _, item := range items {
+		if len(item) != 2 {
+			return nil, fmt.Errorf("expect [termId, score] pairs")
+		}
+		term, ok1 := toFloat(item[0])
+		score, ok2 := toFloat(item[1])
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("expect [termId, score] pairs")
+		}
+		terms[int64(term)] += float32(score)
+	}
+	return terms, nil
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// put adds or replaces the document, and indexes it.
+func (c *localCollection) put(d *storedDoc) {
+	if old := c.docs[d.doc.Id]; old != nil {
+		c.unindex(old)
+	}
+	if c.docs == nil {
+		c.docs = make(map[string]*storedDoc)
+	}
+	c.seq++
+	d.seq = c.seq
+	c.docs[d.doc.Id] = d
+	c.index(d)
+}
+
+// remove deletes the document by id, and reports whether it existed.
+func (c *localCollection) remove(id string) bool {
+	d := c.docs[id]
+	if d == nil {
+		return false
+	}
+	c.unindex(d)
+	delete(c.docs, id)
+	return true
+}
+
+func (c *localCollection) index(d *storedDoc) {
+	for name, field := range c.vectors {
+		if vector := d.vectors[name]; field.graph != nil && vector != nil {
+			if d.nodes == nil {
+				d.nodes = make(map[string]int)
+			}
+			d.nodes[name] = field.graph.insert(d.doc.Id, vector)
+		}
+	}
+	for name, field := range c.sparse {
+		field.add(d.doc.Id, d.sparse[name])
+	}
+}
+
+func (c *localCollection) unindex(d *storedDoc) {
+	for name, node := range d.nodes {
+		if field := c.vectors[name]; field != nil && field.graph != nil {
+			field.graph.remove(node)
+		}
+	}
+	for name, field := range c.sparse {
+		field.remove(d.doc.Id, d.sparse[name])
+	}
+}
+
+// compact rebuilds the graphs once the tombstones outnumber the live nodes, and rewrites the log once
+// it holds twice the entries of the live documents.
+func (c *localCollection) compact() error {
+	for _, field := range c.vectors {
+		if field.graph != nil && field.graph.deleted > compactThreshold && field.graph.deleted > field.graph.live() {
+			if err := c.buildIndexes(); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if c.log != nil && c.log.entries > compactThreshold && c.log.entries > 2*len(c.docs) {
+		return c.log.rewrite(c.documents())
+	}
+	return nil
+}
+
+// sortedDocs returns the documents in the order of writing.
+func (c *localCollection) sortedDocs() []*storedDoc {
+	docs := make([]*storedDoc, 0, len(c.docs))
+	for _, d := range c.docs {
+		docs = append(docs, d)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].seq < docs[j].seq })
+	return docs
+}
+
+func (c *localCollection) documents() []*document.Document {
+	docs := c.sortedDocs()
+	result := make([]*document.Document, 0, len(docs))
+	for _, d := range docs {
+		result = append(result, d.doc)
+	}
+	return result
+}
+
+// expired reports whether the time field of the document has passed, if the collection has TTL enabled.
+func (c *localCollection) expired(d *storedDoc, now time.Time) bool {
+	ttl := c.meta.TtlConfig
+	if ttl == nil || !ttl.Enable || ttl.TimeField == "" {
+		return false
+	}
+	at, ok := toFloat(d.doc.Fields[ttl.TimeField])
+	return ok && at <= float64(now.Unix())
+}
+
+// compileFilter parses the filter, which may only refer to the filter indexes unless FilterAll is set.
+func (c *localCollection) compileFilter(filter string) (*condition, error) {
+	cond, err := parseFilter(filter)
+	if err != nil {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "%v", err)
+	}
+	if c.meta.FilterIndexConfig != nil && c.meta.FilterIndexConfig.FilterAll {
+		return cond, nil
+	}
+	for _, field := range cond.fieldNames() {
+		name := strings.SplitN(field, ".", 2)[0]
+		if name != "id" && c.filters[name] == nil {
+			return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "field %v in filter %q has no filter index", name, filter)
+		}
+	}
+	return cond, nil
+}
+
+func fieldLookup(doc *document.Document) func(string) (interface{}, bool) {
+	return func(path string) (interface{}, bool) {
+		if path == "id" {
+			return doc.Id, true
+		}
+		parts := strings.Split(path, ".")
+		value, ok := doc.Fields[parts[0]]
+		for _, part := range parts[1:] {
+			object, isObject := value.(map[string]interface{})
+			if !ok || !isObject {
+				return nil, false
+			}
+			value, ok = object[part]
+		}
+		return value, ok
+	}
+}
+
+// selector returns whether a document matches the filter and has not expired.
+func (c *localCollection) selector(cond *condition, now time.Time) func(d *storedDoc) bool {
+	return func(d *storedDoc) bool {
+		return !c.expired(d, now) && cond.match(fieldLookup(d.doc))
+	}
+}
+
+// find returns the documents selected by ids and the filter, in the order of the ids or the order of writing.
+func (c *localCollection) find(ids []string, filter string, now time.Time) ([]*storedDoc, error) {
+	cond, err := c.compileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	selected := c.selector(cond, now)
+	var found []*storedDoc
+	if len(ids) != 0 {
+		seen := make(map[string]bool)
+		for _, id := range ids {
+			if d := c.docs[id]; d != nil && !seen[id] && selected(d) {
+				seen[id] = true
+				found = append(found, d)
+			}
+		}
+		return found, nil
+	}
+	for _, d := range c.sortedDocs() {
+		if selected(d) {
+			found = append(found, d)
+		}
+	}
+	return found, nil
+}
+
+// output converts a stored document into a response document with the output fields.
+func (c *localCollection) output(d *storedDoc, outputFields []string, retrieveVector bool) *document.Document {
+	wanted := func(name string) bool {
+		if len(outputFields) == 0 {
+			return true
+		}
+		for _, f := range outputFields {
+			if f == name {
+				return true
+			}
+		}
+		return false
+	}
+	out := &document.Document{Id: d.doc.Id, Fields: make(map[string]interface{})}
+	if retrieveVector && wanted("vector") {
+		out.Vector = d.doc.Vector
+	}
+	if retrieveVector && wanted("sparse_vector") {
+		out.SparseVector = d.doc.SparseVector
+	}
+	for name, value := range d.doc.Fields {
+		if (c.isVectorField(name) && !retrieveVector) || !wanted(name) {
+			continue
+		}
+		out.Fields[name] = value
+	}
+	return out
+}
+
+func (c *localCollection) upsert(docs []*document.Document) (int, error) {
+	prepared := make([]*storedDoc, 0, len(docs))
+	entries := make([]*logEntry, 0, len(docs))
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		if doc.Fields == nil {
+			doc.Fields = make(map[string]interface{})
+		}
+		if err := c.validate(doc); err != nil {
+			return 0, err
+		}
+		d, err := c.parse(doc)
+		if err != nil {
+			return 0, err
+		}
+		prepared = append(prepared, d)
+		entries = append(entries, &logEntry{Upsert: doc})
+	}
+	if err := c.log.append(entries...); err != nil {
+		return 0, err
+	}
+	for _, d := range prepared {
+		c.put(d)
+	}
+	return len(prepared), c.compact()
+}
+
+func (c *localCollection) query(q *document.QueryCond, now time.Time) ([]*document.Document, uint64, error) {
+	found, err := c.find(q.DocumentIds, q.Filter, now)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(q.Sort) != 0 {
+		if err := c.sortDocs(found, q.Sort); err != nil {
+			return nil, 0, err
+		}
+	}
+	total := uint64(len(found))
+	offset, limit := int(q.Offset), int(q.Limit)
+	if limit <= 0 {
+		limit = 1
+		if len(q.DocumentIds) != 0 {
+			limit = len(found)
+		}
+	}
+	if offset > len(found) {
+		offset = len(found)
+	}
+	found = found[offset:]
+	if limit < len(found) {
+		found = found[:limit]
+	}
+	docs := make([]*document.Document, 0, len(found))
+	for _, d := range found {
+		docs = append(docs, c.output(d, q.OutputFields, q.RetrieveVector))
+	}
+	return docs, total, nil
+}
+
+// sortDocs sorts the documents by the rules, where the documents without the field come last.
+func (c *localCollection) sortDocs(docs []*storedDoc, rules []document.SortRule) error {
+	for _, rule := range rules {
+		if column := c.filters[rule.FieldName]; column == nil || column.FieldType != string(tcvectordb.Uint64) {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "sort field %v must be a uint64 filter index", rule.FieldName)
+		}
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, rule := range rules {
+			a, okA := docs[i].doc.Fields[rule.FieldName]
+			b, okB := docs[j].doc.Fields[rule.FieldName]
+			if okA != okB {
+				return okA
+			}
+			cmp, _ := compareValues(a, b)
+			if cmp == 0 {
+				continue
+			}
+			if strings.EqualFold(rule.Direction, "desc") {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	return nil
+}
+
+func (c *localCollection) count(filter string, now time.Time) (uint64, error) {
+	found, err := c.find(nil, filter, now)
+	return uint64(len(found)), err
+}
+
+func (c *localCollection) delete(q *document.QueryCond, now time.Time) (int, error) {
+	found, err := c.find(q.DocumentIds, q.Filter, now)
+	if err != nil {
+		return 0, err
+	}
+	if q.Limit > 0 && int(q.Limit) < len(found) {
+		found = found[:q.Limit]
+	}
+	return c.deleteDocs(found)
+}
+
+func (c *localCollection) deleteDocs(docs []*storedDoc) (int, error) {
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	ids := make([]string, 0, len(docs))
+	for _, d := range docs {
+		ids = append(ids, d.doc.Id)
+	}
+	if err := c.log.append(&logEntry{Delete: ids}); err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		c.remove(id)
+	}
+	return len(ids), c.compact()
+}
+
+// update merges the fields and vectors of the update into the selected documents.
+func (c *localCollection) update(q *document.QueryCond, update *document.Document, now time.Time) (int, error) {
+	if len(q.DocumentIds) == 0 && q.Filter == "" {
+		return 0, newError(tcvectordb.ERR_SYNTAX_ERROR, "documentIds or filter is required to update documents")
+	}
+	if _, ok := update.Fields["id"]; ok {
+		return 0, newError(tcvectordb.ERR_SYNTAX_ERROR, "the document id can not be updated")
+	}
+	found, err := c.find(q.DocumentIds, q.Filter, now)
+	if err != nil {
+		return 0, err
+	}
+	docs := make([]*document.Document, 0, len(found))
+	for _, d := range found {
+		doc := &document.Document{
+			Id:           d.doc.Id,
+			Vector:       d.doc.Vector,
+			SparseVector: d.doc.SparseVector,
+			Fields:       make(map[string]interface{}, len(d.doc.Fields)),
+		}
+		for k, v := range d.doc.Fields {
+			doc.Fields[k] = v
+		}
+		for k, v := range update.Fields {
+			doc.Fields[k] = v
+		}
+		if len(update.Vector) != 0 {
+			doc.Vector = update.Vector
+		}
+		if len(update.SparseVector) != 0 {
+			doc.SparseVector = update.SparseVector
+		}
+		docs = append(docs, doc)
+	}
+	return c.upsert(docs)
+}
+
+// purge deletes the expired documents.
+func (c *localCollection) purge(now time.Time) (int, error) {
+	var expired []*storedDoc
+	for _, d := range c.docs {
+		if c.expired(d, now) {
+			expired = append(expired, d)
+		}
+	}
+	return c.deleteDocs(expired)
+}
+
+func (c *localCollection) truncate() error {
+	c.docs = nil
+	c.seq = 0
+	if err := c.buildIndexes(); err != nil {
+		return err
+	}
+	return c.log.rewrite(nil)
+}
+
+func (c *localCollection) saveMeta() error {
+	if c.dir == "" {
+		return nil
+	}
+	return writeJSON(filepath.Join(c.dir, collectionFile), &c.meta)
+}
+
+// addIndexes adds filter indexes.
+func (c *localCollection) addIndexes(columns []*api.IndexColumn) error {
+	for _, column := range columns {
+		if column == nil || isVectorType(column.FieldType) || column.FieldType == string(tcvectordb.SparseVector) ||
+			column.IndexType == string(tcvectordb.PRIMARY) {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "only filter indexes can be added")
+		}
+	}
+	indexes := c.meta.Indexes
+	c.meta.Indexes = append(append([]*api.IndexColumn(nil), indexes...), columns...)
+	if err := c.buildIndexes(); err != nil {
+		c.meta.Indexes = indexes
+		c.buildIndexes()
+		return err
+	}
+	return c.saveMeta()
+}
+
+// dropIndexes drops filter indexes.
+func (c *localCollection) dropIndexes(names []string) error {
+	drop := make(map[string]bool)
+	for _, name := range names {
+		if c.filters[name] == nil {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "field %v is not a filter index", name)
+		}
+		drop[name] = true
+	}
+	var indexes []*api.IndexColumn
+	for _, column := range c.meta.Indexes {
+		if !drop[column.FieldName] {
+			indexes = append(indexes, column)
+		}
+	}
+	c.meta.Indexes = indexes
+	if err := c.buildIndexes(); err != nil {
+		return err
+	}
+	return c.saveMeta()
+}
+
+// modifyVectorIndexes changes the index type, metric and parameters of vector indexes, and rebuilds them.
+func (c *localCollection) modifyVectorIndexes(columns []*api.IndexColumn) error {
+	indexes := make([]*api.IndexColumn, 0, len(c.meta.Indexes))
+	for _, column := range c.meta.Indexes {
+		copied := *column
+		indexes = append(indexes, &copied)
+	}
+	for _, change := range columns {
+		if change == nil {
+			continue
+		}
+		var target *api.IndexColumn
+		for _, column := range indexes {
+			if column.FieldName == change.FieldName && isVectorType(column.FieldType) {
+				target = column
+			}
+		}
+		if target == nil {
+			return newError(tcvectordb.ERR_SYNTAX_ERROR, "vector index %v does not exist", change.FieldName)
+		}
+		if change.IndexType != "" {
+			target.IndexType = change.IndexType
+		}
+		if change.MetricType != "" {
+			target.MetricType = change.MetricType
+		}
+		if change.Params != nil {
+			target.Params = change.Params
+		}
+	}
+	previous := c.meta.Indexes
+	c.meta.Indexes = indexes
+	if err := c.buildIndexes(); err != nil {
+		c.meta.Indexes = previous
+		c.buildIndexes()
+		return err
+	}
+	return c.saveMeta()
+}
+
+// describe returns the collection as the describe response of the server.
+func (c *localCollection) describe(database string, aliases []string, now time.Time) *collection.DescribeCollectionItem {
+	count := 0
+	for _, d := range c.docs {
+		if !c.expired(d, now) {
+			count++
+		}
+	}
+	indexes := make([]*api.IndexColumn, 0, len(c.meta.Indexes))
+	for _, column := range c.meta.Indexes {
+		copied := *column
+		if isVectorType(column.FieldType) {
+			copied.IndexedCount = uint64(count)
+		}
+		indexes = append(indexes, &copied)
+	}
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return &collection.DescribeCollectionItem{
+		Database:          database,
+		Collection:        c.name,
+		ReplicaNum:        c.meta.ReplicaNum,
+		ShardNum:          c.meta.ShardNum,
+		CreateTime:        c.meta.CreateTime,
+		Description:       c.meta.Description,
+		Indexes:           indexes,
+		IndexStatus:       &collection.IndexStatus{Status: tcvectordb.IndexStatusReady, StartTime: c.meta.CreateTime},
+		Alias:             aliases,
+		DocumentCount:     int64(count),
+		TtlConfig:         c.meta.TtlConfig,
+		FilterIndexConfig: c.meta.FilterIndexConfig,
+	}
+}
+
+func (c *localCollection) close() error {
+	c.dropped = true
+	return c.log.close()
+}
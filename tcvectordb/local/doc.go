@@ -0,0 +1,41 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package local is an embedded vector database, which runs in the process on the files of a
+// directory. Its [Client] implements [tcvectordb.VdbClient], so code written against the interface
+// switches between the server and a local directory by changing the constructor:
+//
+//	cli, err := local.NewClient("./vdb-data", nil)
+//
+// The engine serves the HTTP API of the server, and follows its semantics as a reference:
+//   - FLAT and BIN_FLAT indexes are searched exactly. HNSW and BIN_HNSW indexes are searched with
+//     a graph built with the M and efConstruction of the index. The IVF index types are searched
+//     exactly too, with the same results as FLAT.
+//   - Scores are the squared distance for L2, the number of different bits for Hamming, and the
+//     similarity for IP and COSINE.
+//   - Sparse vectors are searched with an inverted index by the inner product, which is the BM25
+//     score for the sparse vectors of the BM25 encoder of tcvdbtext.
+//   - Filters use the syntax of [tcvectordb.Filter], and may only refer to the id and the filter
+//     indexes unless FilterAll of the collection is set.
+//   - Hybrid search reranks the ann and match results by the weighted or rrf method.
+//   - The documents whose TTL time field has passed are hidden at once, and deleted periodically.
+//
+// A collection is saved as its schema and a log of the documents, which is replayed on opening and
+// compacted as it grows. The graphs are rebuilt from the documents on opening. The users, the AI
+// databases and embedding are not supported.
+package local
@@ -0,0 +1,858 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/alias"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/collection"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/database"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/index"
+)
+
+// [Option] holds the options of the local engine.
+//
+// Fields:
+//   - Timeout: (Optional) The timeout of a request of [Client] (defaults to 1 minute).
+//   - TtlInterval: (Optional) The interval of purging the expired documents of the collections
+//     with TTL enabled (defaults to 1 minute). The expired documents are hidden from reads at once.
+//   - SyncWrites: (Optional) Whether to fsync the documents log after every write (defaults to false).
+//     Without it, the writes survive a crash of the process, but not of the machine.
+type Option struct {
+	Timeout     time.Duration
+	TtlInterval time.Duration
+	SyncWrites  bool
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$`)
+
+// engineError is returned to the client as the code and message of the response.
+type engineError struct {
+	code int
+	msg  string
+}
+
+func (e *engineError) Error() string {
+	return e.msg
+}
+
+func newError(code int, format string, args ...interface{}) error {
+	return &engineError{code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+// [Engine] is an in-process vector database, which serves the HTTP API of the server on the files
+// of a directory. It is used as the transport of the [Client] of this package, and can also be
+// served as a [http.Handler] to any HTTP client of the SDK.
+type Engine struct {
+	dir       string
+	option    Option
+	mu        sync.RWMutex
+	databases map[string]*localDatabase
+	closed    bool
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// localDatabase is a database with its collections. The aliases map an alias to a collection.
+type localDatabase struct {
+	dir         string
+	meta        databaseMeta
+	collections map[string]*localCollection
+}
+
+type databaseMeta struct {
+	CreateTime string            `json:"createTime"`
+	Aliases    map[string]string `json:"aliases,omitempty"`
+}
+
+// [Open] opens the engine on a directory, and loads the databases saved in it.
+//
+// Parameters:
+//   - dir: The directory to save the data, which is created if it does not exist. An empty dir
+//     keeps the data in memory.
+//   - option: A pointer to an [Option] object. See [Option] for more information.
+//
+// Returns a pointer to an [Engine] object or an error.
+func Open(dir string, option *Option) (*Engine, error) {
+	e := &Engine{dir: dir, databases: make(map[string]*localDatabase), stop: make(chan struct{}), done: make(chan struct{})}
+	if option != nil {
+		e.option = *option
+	}
+	if e.option.TtlInterval <= 0 {
+		e.option.TtlInterval = time.Minute
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("open local engine failed. err: %v", err)
+		}
+		if err := e.load(); err != nil {
+			e.closeCollections()
+			return nil, fmt.Errorf("open local engine failed. err: %v", err)
+		}
+	}
+	go e.purgeLoop()
+	return e, nil
+}
+
+func (e *Engine) load() error {
+	entries, err := os.ReadDir(e.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		dbDir := filepath.Join(e.dir, entry.Name())
+		if !entry.IsDir() {
+			continue
+		}
+		db := &localDatabase{dir: dbDir, collections: make(map[string]*localCollection)}
+		if err := readJSON(filepath.Join(dbDir, databaseFile), &db.meta); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		e.databases[entry.Name()] = db
+		collEntries, err := os.ReadDir(dbDir)
+		if err != nil {
+			return err
+		}
+		for _, collEntry := range collEntries {
+			collDir := filepath.Join(dbDir, collEntry.Name())
+			if _, err := os.Stat(filepath.Join(collDir, collectionFile)); !collEntry.IsDir() || err != nil {
+				continue
+			}
+			coll, err := openCollection(collDir, collEntry.Name(), e.option.SyncWrites)
+			if err != nil {
+				return err
+			}
+			db.collections[collEntry.Name()] = coll
+		}
+	}
+	return nil
+}
+
+// [Close] stops purging the expired documents, and closes the files of the engine.
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	e.mu.Unlock()
+	close(e.stop)
+	<-e.done
+	return e.closeCollections()
+}
+
+func (e *Engine) closeCollections() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var firstErr error
+	for _, db := range e.databases {
+		for _, coll := range db.collections {
+			coll.mu.Lock()
+			if err := coll.close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			coll.mu.Unlock()
+		}
+	}
+	return firstErr
+}
+
+func (e *Engine) purgeLoop() {
+	defer close(e.done)
+	ticker := time.NewTicker(e.option.TtlInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			e.purge()
+		}
+	}
+}
+
+// purge deletes the expired documents of all collections.
+func (e *Engine) purge() {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	now := time.Now()
+	for _, db := range e.databases {
+		for _, coll := range db.collections {
+			coll.mu.Lock()
+			if !coll.dropped {
+				coll.purge(now)
+			}
+			coll.mu.Unlock()
+		}
+	}
+}
+
+// [ServeHTTP] serves a request of the HTTP API of the server.
+func (e *Engine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(e.handle(r.URL.Path, body))
+}
+
+// [RoundTrip] handles the request in the process, which makes the engine the transport of an HTTP client.
+func (e *Engine) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := r.Context().Err(); err != nil {
+		return nil, err
+	}
+	data := e.handle(r.URL.Path, body)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       r,
+	}, nil
+}
+
+// handle dispatches the request by the path, and returns the response body.
+func (e *Engine) handle(path string, body []byte) []byte {
+	res, err := e.dispatch(path, body)
+	if err != nil {
+		code := tcvectordb.ERR_SYNTAX_ERROR
+		if engineErr, ok := err.(*engineError); ok {
+			code = engineErr.code
+		}
+		res = &api.CommonRes{Code: int32(code), Msg: err.Error()}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		data, _ = json.Marshal(&api.CommonRes{Code: tcvectordb.ERR_SYNTAX_ERROR, Msg: err.Error()})
+	}
+	return data
+}
+
+func decode(body []byte, req interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, req); err != nil {
+		return newError(tcvectordb.ERR_SYNTAX_ERROR, "invalid request: %v", err)
+	}
+	return nil
+}
+
+func (e *Engine) dispatch(path string, body []byte) (interface{}, error) {
+	switch path {
+	case "/database/create":
+		req := new(database.CreateReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.createDatabase(req)
+	case "/database/drop":
+		req := new(database.DropReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.dropDatabase(req)
+	case "/database/list":
+		return e.listDatabases()
+	case "/collection/create":
+		req := new(collection.CreateReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.createCollection(req)
+	case "/collection/describe":
+		req := new(collection.DescribeReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.describeCollection(req)
+	case "/collection/list":
+		req := new(collection.ListReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.listCollections(req)
+	case "/collection/drop":
+		req := new(collection.DropReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.dropCollection(req)
+	case "/collection/truncate":
+		req := new(collection.TruncateReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.truncateCollection(req)
+	case "/alias/set":
+		req := new(alias.SetReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.setAlias(req)
+	case "/alias/delete":
+		req := new(alias.DeleteReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.deleteAlias(req)
+	case "/alias/describe":
+		req := new(alias.DescribeReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.describeAlias(req)
+	case "/alias/list":
+		req := new(alias.ListReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.listAliases(req)
+	case "/document/upsert", "/document/query", "/document/search", "/document/hybridSearch",
+		"/document/fullTextSearch", "/document/delete", "/document/update", "/document/count":
+		return e.dispatchDocument(path, body)
+	case "/index/add", "/index/drop", "/index/rebuild", "/index/modifyVectorIndex":
+		return e.dispatchIndex(path, body)
+	}
+	return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "%v is not supported by the local engine", path)
+}
+
+func (e *Engine) createDatabase(req *database.CreateReq) (interface{}, error) {
+	if !namePattern.MatchString(req.Database) {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "invalid database name %q", req.Database)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.checkOpen(); err != nil {
+		return nil, err
+	}
+	if e.databases[req.Database] != nil {
+		return &database.CreateRes{}, nil
+	}
+	db := &localDatabase{
+		meta:        databaseMeta{CreateTime: time.Now().Format("2006-01-02 15:04:05")},
+		collections: make(map[string]*localCollection),
+	}
+	if e.dir != "" {
+		db.dir = filepath.Join(e.dir, req.Database)
+		if err := os.MkdirAll(db.dir, 0755); err != nil {
+			return nil, err
+		}
+		if err := writeJSON(filepath.Join(db.dir, databaseFile), &db.meta); err != nil {
+			return nil, err
+		}
+	}
+	e.databases[req.Database] = db
+	return &database.CreateRes{AffectedCount: 1}, nil
+}
+
+func (e *Engine) dropDatabase(req *database.DropReq) (interface{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.checkOpen(); err != nil {
+		return nil, err
+	}
+	db := e.databases[req.Database]
+	if db == nil {
+		return &database.DropRes{}, nil
+	}
+	for _, coll := range db.collections {
+		coll.mu.Lock()
+		coll.close()
+		coll.mu.Unlock()
+	}
+	delete(e.databases, req.Database)
+	if db.dir != "" {
+		if err := os.RemoveAll(db.dir); err != nil {
+			return nil, err
+		}
+	}
+	return &database.DropRes{AffectedCount: 1}, nil
+}
+
+func (e *Engine) listDatabases() (interface{}, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if err := e.checkOpen(); err != nil {
+		return nil, err
+	}
+	res := &database.ListRes{Info: make(map[string]database.DatabaseInfo)}
+	for name, db := range e.databases {
+		res.Databases = append(res.Databases, name)
+		res.Info[name] = database.DatabaseInfo{
+			CreateTime: db.meta.CreateTime,
+			DbType:     tcvectordb.BASEDbType,
+			Count:      int64(len(db.collections)),
+		}
+	}
+	sort.Strings(res.Databases)
+	return res, nil
+}
+
+func (e *Engine) checkOpen() error {
+	if e.closed {
+		return newError(tcvectordb.ERR_SYNTAX_ERROR, "the local engine is closed")
+	}
+	return nil
+}
+
+// database returns the database, which must hold e.mu.
+func (e *Engine) database(name string) (*localDatabase, error) {
+	if err := e.checkOpen(); err != nil {
+		return nil, err
+	}
+	db := e.databases[name]
+	if db == nil {
+		return nil, newError(tcvectordb.ERR_UNDEFINED_DATABASE, "database %v does not exist", name)
+	}
+	return db, nil
+}
+
+// collection returns the collection by its name or alias, which must hold e.mu.
+func (e *Engine) collection(databaseName, name string) (*localCollection, error) {
+	db, err := e.database(databaseName)
+	if err != nil {
+		return nil, err
+	}
+	coll := db.collections[name]
+	if coll == nil {
+		if target, ok := db.meta.Aliases[name]; ok {
+			coll = db.collections[target]
+		}
+	}
+	if coll == nil {
+		return nil, newError(tcvectordb.ERR_UNDEFINED_COLLECTION, "collection %v does not exist in database %v", name, databaseName)
+	}
+	return coll, nil
+}
+
+func (db *localDatabase) aliasesOf(collection string) []string {
+	var aliases []string
+	for a, target := range db.meta.Aliases {
+		if target == collection {
+			aliases = append(aliases, a)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+func (db *localDatabase) saveMeta() error {
+	if db.dir == "" {
+		return nil
+	}
+	return writeJSON(filepath.Join(db.dir, databaseFile), &db.meta)
+}
+
+func (e *Engine) createCollection(req *collection.CreateReq) (interface{}, error) {
+	if !namePattern.MatchString(req.Collection) {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "invalid collection name %q", req.Collection)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	db, err := e.database(req.Database)
+	if err != nil {
+		return nil, err
+	}
+	if db.collections[req.Collection] != nil {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "collection %v already exists", req.Collection)
+	}
+	if _, ok := db.meta.Aliases[req.Collection]; ok {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "%v is an alias", req.Collection)
+	}
+	dir := ""
+	if db.dir != "" {
+		dir = filepath.Join(db.dir, req.Collection)
+	}
+	coll, err := newCollection(dir, req, e.option.SyncWrites)
+	if err != nil {
+		return nil, err
+	}
+	db.collections[req.Collection] = coll
+	return &collection.CreateRes{AffectedCount: 1}, nil
+}
+
+func (e *Engine) describeCollection(req *collection.DescribeReq) (interface{}, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	coll, err := e.collection(req.Database, req.Collection)
+	if err != nil {
+		return nil, err
+	}
+	coll.mu.RLock()
+	defer coll.mu.RUnlock()
+	return &collection.DescribeRes{
+		Collection: coll.describe(req.Database, e.databases[req.Database].aliasesOf(coll.name), time.Now()),
+	}, nil
+}
+
+func (e *Engine) listCollections(req *collection.ListReq) (interface{}, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	db, err := e.database(req.Database)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(db.collections))
+	for name := range db.collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := new(collection.ListRes)
+	now := time.Now()
+	for _, name := range names {
+		coll := db.collections[name]
+		coll.mu.RLock()
+		res.Collections = append(res.Collections, coll.describe(req.Database, db.aliasesOf(name), now))
+		coll.mu.RUnlock()
+	}
+	return res, nil
+}
+
+func (e *Engine) dropCollection(req *collection.DropReq) (interface{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	db, err := e.database(req.Database)
+	if err != nil {
+		return nil, err
+	}
+	coll := db.collections[req.Collection]
+	if coll == nil {
+		return nil, newError(tcvectordb.ERR_UNDEFINED_COLLECTION, "collection %v does not exist in database %v", req.Collection, req.Database)
+	}
+	coll.mu.Lock()
+	coll.close()
+	coll.mu.Unlock()
+	delete(db.collections, req.Collection)
+	for _, a := range db.aliasesOf(req.Collection) {
+		delete(db.meta.Aliases, a)
+	}
+	if err := db.saveMeta(); err != nil {
+		return nil, err
+	}
+	if coll.dir != "" {
+		if err := os.RemoveAll(coll.dir); err != nil {
+			return nil, err
+		}
+	}
+	return &collection.DropRes{AffectedCount: 1}, nil
+}
+
+func (e *Engine) truncateCollection(req *collection.TruncateReq) (interface{}, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	coll, err := e.collection(req.Database, req.Collection)
+	if err != nil {
+		return nil, err
+	}
+	coll.mu.Lock()
+	defer coll.mu.Unlock()
+	count := len(coll.docs)
+	if err := coll.truncate(); err != nil {
+		return nil, err
+	}
+	return &collection.TruncateRes{AffectedCount: count}, nil
+}
+
+func (e *Engine) setAlias(req *alias.SetReq) (interface{}, error) {
+	if !namePattern.MatchString(req.Alias) {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "invalid alias %q", req.Alias)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	db, err := e.database(req.Database)
+	if err != nil {
+		return nil, err
+	}
+	if db.collections[req.Collection] == nil {
+		return nil, newError(tcvectordb.ERR_UNDEFINED_COLLECTION, "collection %v does not exist in database %v", req.Collection, req.Database)
+	}
+	if db.collections[req.Alias] != nil {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "alias %v is the name of a collection", req.Alias)
+	}
+	if db.meta.Aliases == nil {
+		db.meta.Aliases = make(map[string]string)
+	}
+	db.meta.Aliases[req.Alias] = req.Collection
+	if err := db.saveMeta(); err != nil {
+		return nil, err
+	}
+	return &alias.SetRes{AffectedCount: 1}, nil
+}
+
+func (e *Engine) deleteAlias(req *alias.DeleteReq) (interface{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	db, err := e.database(req.Database)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := db.meta.Aliases[req.Alias]; !ok {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "alias %v does not exist", req.Alias)
+	}
+	delete(db.meta.Aliases, req.Alias)
+	if err := db.saveMeta(); err != nil {
+		return nil, err
+	}
+	return &alias.DeleteRes{AffectedCount: 1}, nil
+}
+
+func (e *Engine) describeAlias(req *alias.DescribeReq) (interface{}, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	db, err := e.database(req.Database)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := db.meta.Aliases[req.Alias]
+	if !ok {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "alias %v does not exist", req.Alias)
+	}
+	return &alias.DescribeRes{Aliases: []*alias.AliasItem{{Alias: req.Alias, Collection: target}}}, nil
+}
+
+func (e *Engine) listAliases(req *alias.ListReq) (interface{}, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	db, err := e.database(req.Database)
+	if err != nil {
+		return nil, err
+	}
+	res := new(alias.ListRes)
+	for a, target := range db.meta.Aliases {
+		res.Aliases = append(res.Aliases, &alias.AliasItem{Alias: a, Collection: target})
+	}
+	sort.Slice(res.Aliases, func(i, j int) bool { return res.Aliases[i].Alias < res.Aliases[j].Alias })
+	return res, nil
+}
+
+// withCollection runs fn with the collection of the request locked, exclusively if write is set.
+func (e *Engine) withCollection(databaseName, collectionName string, write bool, fn func(c *localCollection) (interface{}, error)) (interface{}, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	coll, err := e.collection(databaseName, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if write {
+		coll.mu.Lock()
+		defer coll.mu.Unlock()
+	} else {
+		coll.mu.RLock()
+		defer coll.mu.RUnlock()
+	}
+	if coll.dropped {
+		return nil, newError(tcvectordb.ERR_UNDEFINED_COLLECTION, "collection %v does not exist in database %v", collectionName, databaseName)
+	}
+	return fn(coll)
+}
+
+func (e *Engine) dispatchDocument(path string, body []byte) (interface{}, error) {
+	now := time.Now()
+	switch strings.TrimPrefix(path, "/document/") {
+	case "upsert":
+		req := new(document.UpsertReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.withCollection(req.Database, req.Collection, true, func(c *localCollection) (interface{}, error) {
+			count, err := c.upsert(req.Documents)
+			if err != nil {
+				return nil, err
+			}
+			return &document.UpsertRes{AffectedCount: count}, nil
+		})
+	case "query":
+		req := new(document.QueryReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		if req.Query == nil {
+			req.Query = new(document.QueryCond)
+		}
+		return e.withCollection(req.Database, req.Collection, false, func(c *localCollection) (interface{}, error) {
+			docs, total, err := c.query(req.Query, now)
+			if err != nil {
+				return nil, err
+			}
+			return &document.QueryRes{Count: total, Documents: docs}, nil
+		})
+	case "search":
+		req := new(document.SearchReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		if req.Search == nil {
+			req.Search = new(document.SearchCond)
+		}
+		return e.withCollection(req.Database, req.Collection, false, func(c *localCollection) (interface{}, error) {
+			docs, err := c.search(req.Search, now)
+			if err != nil {
+				return nil, err
+			}
+			return &document.SearchRes{Documents: docs}, nil
+		})
+	case "hybridSearch":
+		req := new(document.HybridSearchReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		if req.Search == nil {
+			req.Search = new(document.HybridSearchCond)
+		}
+		return e.withCollection(req.Database, req.Collection, false, func(c *localCollection) (interface{}, error) {
+			docs, err := c.hybridSearch(req.Search, now)
+			if err != nil {
+				return nil, err
+			}
+			return &document.SearchRes{Documents: docs}, nil
+		})
+	case "fullTextSearch":
+		req := new(document.FullTextSearchReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		if req.Search == nil {
+			req.Search = new(document.FullTextSearchCond)
+		}
+		return e.withCollection(req.Database, req.Collection, false, func(c *localCollection) (interface{}, error) {
+			docs, err := c.fullTextSearch(req.Search, now)
+			if err != nil {
+				return nil, err
+			}
+			return &document.SearchRes{Documents: docs}, nil
+		})
+	case "delete":
+		req := new(document.DeleteReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		if req.Query == nil {
+			req.Query = new(document.QueryCond)
+		}
+		return e.withCollection(req.Database, req.Collection, true, func(c *localCollection) (interface{}, error) {
+			count, err := c.delete(req.Query, now)
+			if err != nil {
+				return nil, err
+			}
+			return &document.DeleteRes{AffectedCount: count}, nil
+		})
+	case "update":
+		req := new(document.UpdateReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		if req.Query == nil {
+			req.Query = new(document.QueryCond)
+		}
+		return e.withCollection(req.Database, req.Collection, true, func(c *localCollection) (interface{}, error) {
+			count, err := c.update(req.Query, &req.Update, now)
+			if err != nil {
+				return nil, err
+			}
+			return &document.UpdateRes{AffectedCount: count}, nil
+		})
+	default:
+		req := new(document.CountReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		if req.Query == nil {
+			req.Query = new(document.CountQueryCond)
+		}
+		return e.withCollection(req.Database, req.Collection, false, func(c *localCollection) (interface{}, error) {
+			count, err := c.count(req.Query.Filter, now)
+			if err != nil {
+				return nil, err
+			}
+			return &document.CountRes{Count: count}, nil
+		})
+	}
+}
+
+func (e *Engine) dispatchIndex(path string, body []byte) (interface{}, error) {
+	switch strings.TrimPrefix(path, "/index/") {
+	case "add":
+		req := new(index.AddReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.withCollection(req.Database, req.Collection, true, func(c *localCollection) (interface{}, error) {
+			return &index.AddRes{}, c.addIndexes(req.Indexes)
+		})
+	case "drop":
+		req := new(index.DropReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.withCollection(req.Database, req.Collection, true, func(c *localCollection) (interface{}, error) {
+			return &index.DropRes{}, c.dropIndexes(req.FieldNames)
+		})
+	case "rebuild":
+		req := new(index.RebuildReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.withCollection(req.Database, req.Collection, true, func(c *localCollection) (interface{}, error) {
+			return &index.RebuildRes{}, c.buildIndexes()
+		})
+	default:
+		req := new(index.ModifyVectorIndexReq)
+		if err := decode(body, req); err != nil {
+			return nil, err
+		}
+		return e.withCollection(req.Database, req.Collection, true, func(c *localCollection) (interface{}, error) {
+			return &index.ModifyVectorIndexRes{}, c.modifyVectorIndexes(req.VectorIndexes)
+		})
+	}
+}
@@ -0,0 +1,410 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// condition is a parsed filter expression, such as `author = "Tom" and page > 10`.
+type condition struct {
+	op          string // and, or, not, compare, in, notIn, include, includeAll, exclude
+	left, right *condition
+	field       string
+	compare     string // =, !=, >, >=, <, <=
+	values      []interface{}
+}
+
+// parseFilter parses a filter in the syntax of [tcvectordb.Filter]. It returns nil for an empty filter.
+func parseFilter(s string) (*condition, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenizeFilter(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", s, err)
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("invalid filter %q: unexpected %q", s, p.tokens[p.pos].text)
+	}
+	return cond, nil
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+}
+
+func tokenizeFilter(s string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("invalid filter %q: unterminated string", s)
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: sb.String()})
+			i = j + 1
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || strings.ContainsRune(".eE+-", runes[j])) {
+				if (runes[j] == '+' || runes[j] == '-') && runes[j-1] != 'e' && runes[j-1] != 'E' {
+					break
+				}
+				j++
+			}
+			text := string(runes[i:j])
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				return nil, fmt.Errorf("invalid filter %q: invalid number %v", s, text)
+			}
+			tokens = append(tokens, filterToken{kind: tokenNumber, text: text})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, filterToken{kind: tokenIdent, text: string(runes[i:j])})
+			i = j
+		case strings.ContainsRune("!<>=", r):
+			j := i + 1
+			if j < len(runes) && runes[j] == '=' {
+				j++
+			}
+			text := string(runes[i:j])
+			if text == "!" || text == "==" {
+				return nil, fmt.Errorf("invalid filter %q: unknown operator %v", s, text)
+			}
+			tokens = append(tokens, filterToken{kind: tokenSymbol, text: text})
+			i = j
+		case strings.ContainsRune("(),", r):
+			tokens = append(tokens, filterToken{kind: tokenSymbol, text: string(r)})
+			i++
+		default:
+			return nil, fmt.Errorf("invalid filter %q: unexpected character %q", s, r)
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+// keyword reports whether the next token is the keyword, and consumes it if so.
+func (p *filterParser) keyword(word string) bool {
+	t := p.peek()
+	if t != nil && t.kind == tokenIdent && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) symbol(s string) bool {
+	t := p.peek()
+	if t != nil && t.kind == tokenSymbol && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (*condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &condition{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (*condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &condition{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (*condition, error) {
+	if p.keyword("not") {
+		cond, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &condition{op: "not", left: cond}, nil
+	}
+	if p.symbol("(") {
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.symbol(")") {
+			return nil, fmt.Errorf("missing )")
+		}
+		return cond, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *filterParser) parsePredicate() (*condition, error) {
+	t := p.peek()
+	if t == nil || t.kind != tokenIdent {
+		return nil, fmt.Errorf("expect a field name")
+	}
+	p.pos++
+	cond := &condition{field: t.text}
+	switch {
+	case p.keyword("in"):
+		cond.op = "in"
+	case p.keyword("not"):
+		if !p.keyword("in") {
+			return nil, fmt.Errorf("expect in after not")
+		}
+		cond.op = "notIn"
+	case p.keyword("include"):
+		cond.op = "include"
+		if p.keyword("all") {
+			cond.op = "includeAll"
+		}
+	case p.keyword("exclude"):
+		cond.op = "exclude"
+	default:
+		op := p.peek()
+		if op == nil || op.kind != tokenSymbol || op.text == "(" || op.text == ")" || op.text == "," {
+			return nil, fmt.Errorf("expect an operator after %v", cond.field)
+		}
+		p.pos++
+		cond.op, cond.compare = "compare", op.text
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		cond.values = []interface{}{value}
+		return cond, nil
+	}
+	if !p.symbol("(") {
+		return nil, fmt.Errorf("expect ( after %v", cond.op)
+	}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		cond.values = append(cond.values, value)
+		if p.symbol(")") {
+			return cond, nil
+		}
+		if !p.symbol(",") {
+			return nil, fmt.Errorf("expect , or )")
+		}
+	}
+}
+
+func (p *filterParser) parseValue() (interface{}, error) {
+	t := p.peek()
+	if t == nil || (t.kind != tokenString && t.kind != tokenNumber) {
+		return nil, fmt.Errorf("expect a string or a number")
+	}
+	p.pos++
+	if t.kind == tokenNumber {
+		return json.Number(t.text), nil
+	}
+	return t.text, nil
+}
+
+// fieldNames returns the fields referred to by the condition.
+func (c *condition) fieldNames() []string {
+	if c == nil {
+		return nil
+	}
+	if c.field != "" {
+		return []string{c.field}
+	}
+	return append(c.left.fieldNames(), c.right.fieldNames()...)
+}
+
+// match evaluates the condition with the fields of a document. A missing field, or a value of another
+// type, never matches a comparison.
+func (c *condition) match(lookup func(field string) (interface{}, bool)) bool {
+	if c == nil {
+		return true
+	}
+	switch c.op {
+	case "and":
+		return c.left.match(lookup) && c.right.match(lookup)
+	case "or":
+		return c.left.match(lookup) || c.right.match(lookup)
+	case "not":
+		return !c.left.match(lookup)
+	}
+	value, ok := lookup(c.field)
+	switch c.op {
+	case "compare":
+		if !ok {
+			return false
+		}
+		cmp, ok := compareValues(value, c.values[0])
+		if !ok {
+			return false
+		}
+		switch c.compare {
+		case "=":
+			return cmp == 0
+		case "!=":
+			return cmp != 0
+		case ">":
+			return cmp > 0
+		case ">=":
+			return cmp >= 0
+		case "<":
+			return cmp < 0
+		case "<=":
+			return cmp <= 0
+		}
+		return false
+	case "in", "notIn":
+		found := ok && containsValue(c.values, value)
+		return found == (c.op == "in")
+	}
+	elems, _ := value.([]interface{})
+	count := 0
+	for _, want := range c.values {
+		if containsValue(elems, want) {
+			count++
+		}
+	}
+	switch c.op {
+	case "include":
+		return count > 0
+	case "includeAll":
+		return count == len(c.values)
+	case "exclude":
+		return count == 0
+	}
+	return false
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, v := range list {
+		if cmp, ok := compareValues(v, value); ok && cmp == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues compares two strings, or two numbers. It returns false for values of other types.
+func compareValues(a, b interface{}) (int, bool) {
+	if sa, ok := a.(string); ok {
+		sb, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(sa, sb), true
+	}
+	na, ok := toNumber(a)
+	if !ok {
+		return 0, false
+	}
+	nb, ok := toNumber(b)
+	if !ok {
+		return 0, false
+	}
+	return na.Cmp(nb), true
+}
+
+// toNumber converts a number into a big.Float, which compares the uint64 and int64 values exactly.
+func toNumber(v interface{}) (*big.Float, bool) {
+	var s string
+	switch n := v.(type) {
+	case json.Number:
+		s = string(n)
+	case float64:
+		return new(big.Float).SetFloat64(n), true
+	case float32:
+		return new(big.Float).SetFloat64(float64(n)), true
+	case int:
+		return new(big.Float).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Float).SetInt64(n), true
+	case uint64:
+		return new(big.Float).SetUint64(n), true
+	default:
+		return nil, false
+	}
+	f, _, err := big.ParseFloat(s, 10, 128, big.ToNearestEven)
+	if err != nil {
+		return nil, false
+	}
+	return f, true
+}
@@ -0,0 +1,403 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+)
+
+// defaultEf is the ef of HNSW searching if the request does not set it.
+const defaultEf = 10
+
+// hit is a document found by a search with its score.
+type hit struct {
+	d     *storedDoc
+	score float32
+}
+
+// ranked is the result of a retrieval of a field, which hybrid search reranks.
+type ranked struct {
+	field  string
+	metric metric
+	hits   [][]hit
+}
+
+// sortHits sorts the hits from the most similar one, and keeps the order of writing for the same scores.
+func sortHits(hits []hit, ascending bool) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return (hits[i].score < hits[j].score) == ascending
+		}
+		return hits[i].d.seq < hits[j].d.seq
+	})
+}
+
+func (c *localCollection) defaultVectorField() (string, error) {
+	if c.vectors["vector"] != nil {
+		return "vector", nil
+	}
+	var names []string
+	for name := range c.vectors {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "", newError(tcvectordb.ERR_SYNTAX_ERROR, "the collection has no vector index")
+	}
+	sort.Strings(names)
+	return names[0], nil
+}
+
+// searchVector returns the limit most similar documents selected by the filter. HNSW indexes are searched
+// with the graph, unless the filter selects few documents, which are compared one by one instead.
+func (c *localCollection) searchVector(name string, query []float32, limit int, ef uint32, radius *float32,
+	selected func(d *storedDoc) bool, filtered bool) ([]hit, error) {
+	field := c.vectors[name]
+	if field == nil {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "vector index %v does not exist", name)
+	}
+	if len(query) != field.dimension() {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "the dimension of the query vector is %v, but %v is required",
+			len(query), field.dimension())
+	}
+	var hits []hit
+	useGraph := field.graph != nil
+	allowed := len(c.docs)
+	if useGraph && filtered {
+		allowed = 0
+		for _, d := range c.docs {
+			if selected(d) {
+				allowed++
+			}
+		}
+		useGraph = allowed*10 >= len(c.docs)
+	}
+	if useGraph {
+		width := maxInt(int(ef), defaultEf)
+		if allowed > 0 && allowed < len(c.docs) {
+			width = minInt(width*len(c.docs)/allowed, width*8)
+		}
+		found := field.graph.search(query, limit, width, func(key interface{}) bool {
+			d := c.docs[key.(string)]
+			return d != nil && selected(d)
+		})
+		for _, cand := range found {
+			hits = append(hits, hit{d: c.docs[field.graph.nodes[cand.node].key.(string)], score: field.metric.score(cand.distance)})
+		}
+	} else {
+		docs := c.sortedDocs()
+		best := newNearest(limit)
+		for i, d := range docs {
+			if vector := d.vectors[name]; vector != nil && selected(d) {
+				best.push(candidate{node: i, distance: field.metric.distance(query, vector)})
+			}
+		}
+		for _, cand := range best.sorted() {
+			hits = append(hits, hit{d: docs[cand.node], score: field.metric.score(cand.distance)})
+		}
+	}
+	sortHits(hits, field.metric.ascending())
+	if radius != nil {
+		within := hits[:0]
+		for _, h := range hits {
+			if (field.metric.ascending() && h.score <= *radius) || (!field.metric.ascending() && h.score >= *radius) {
+				within = append(within, h)
+			}
+		}
+		hits = within
+	}
+	return hits, nil
+}
+
+// searchSparse returns the limit documents with the highest inner products with the sparse query.
+func (c *localCollection) searchSparse(name string, query map[int64]float32, limit int, selected func(d *storedDoc) bool) ([]hit, error) {
+	field := c.sparse[name]
+	if field == nil {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "sparse vector index %v does not exist", name)
+	}
+	var hits []hit
+	for id, score := range field.scores(query) {
+		if d := c.docs[id]; d != nil && selected(d) {
+			hits = append(hits, hit{d: d, score: score})
+		}
+	}
+	sortHits(hits, false)
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func limitOrDefault(limit *int) int {
+	if limit == nil || *limit <= 0 {
+		return 1
+	}
+	return *limit
+}
+
+func (c *localCollection) outputHits(hits []hit, outputFields []string, retrieveVector bool) []*document.Document {
+	docs := make([]*document.Document, 0, len(hits))
+	for _, h := range hits {
+		doc := c.output(h.d, outputFields, retrieveVector)
+		doc.Score = h.score
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func (c *localCollection) search(s *document.SearchCond, now time.Time) ([][]*document.Document, error) {
+	if len(s.EmbeddingItems) != 0 {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "embedding is not supported by the local engine, search by vectors instead")
+	}
+	name, err := c.defaultVectorField()
+	if err != nil {
+		return nil, err
+	}
+	cond, err := c.compileFilter(s.Filter)
+	if err != nil {
+		return nil, err
+	}
+	queries := s.Vectors
+	for _, id := range s.DocumentIds {
+		var vector []float32
+		if d := c.docs[id]; d != nil && !c.expired(d, now) {
+			vector = d.vectors[name]
+		}
+		queries = append(queries, vector)
+	}
+	limit := int(s.Limit)
+	if limit <= 0 {
+		limit = 1
+	}
+	var ef uint32
+	if s.Params != nil {
+		ef = s.Params.Ef
+	}
+	selected := c.selector(cond, now)
+	results := make([][]*document.Document, 0, len(queries))
+	for _, query := range queries {
+		if query == nil {
+			results = append(results, []*document.Document{})
+			continue
+		}
+		hits, err := c.searchVector(name, query, limit, ef, s.Radius, selected, cond != nil)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, c.outputHits(hits, s.OutputFields, s.RetrieveVector))
+	}
+	return results, nil
+}
+
+func (c *localCollection) hybridSearch(s *document.HybridSearchCond, now time.Time) ([][]*document.Document, error) {
+	cond, err := c.compileFilter(s.Filter)
+	if err != nil {
+		return nil, err
+	}
+	selected := c.selector(cond, now)
+	limit := limitOrDefault(s.Limit)
+
+	var retrievals []ranked
+	for _, ann := range s.AnnParams {
+		if ann == nil {
+			continue
+		}
+		name := ann.FieldName
+		if name == "" {
+			name = "vector"
+		}
+		field := c.vectors[name]
+		if field == nil {
+			return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "vector index %v does not exist", name)
+		}
+		var ef uint32
+		var radius *float32
+		if ann.Params != nil {
+			ef = ann.Params.Ef
+			if ann.Params.Radius != 0 {
+				radius = &ann.Params.Radius
+			}
+		}
+		r := ranked{field: name, metric: field.metric}
+		for _, data := range ann.Data {
+			if _, ok := data.(string); ok {
+				return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "embedding is not supported by the local engine, search by vectors instead")
+			}
+			query, err := toVector(data)
+			if err != nil {
+				return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "invalid ann data: %v", err)
+			}
+			hits, err := c.searchVector(name, query, limitOrDefault(ann.Limit), ef, radius, selected, cond != nil)
+			if err != nil {
+				return nil, err
+			}
+			r.hits = append(r.hits, hits)
+		}
+		retrievals = append(retrievals, r)
+	}
+	for _, match := range s.Match {
+		if match == nil {
+			continue
+		}
+		r, err := c.match(match, limit, selected)
+		if err != nil {
+			return nil, err
+		}
+		retrievals = append(retrievals, *r)
+	}
+	if len(retrievals) == 0 {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "ann or match is required for hybrid search")
+	}
+	queries := len(retrievals[0].hits)
+	for _, r := range retrievals {
+		if len(r.hits) != queries {
+			return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "the ann and match of hybrid search must have the same number of queries")
+		}
+	}
+
+	results := make([][]*document.Document, 0, queries)
+	for i := 0; i < queries; i++ {
+		var hits []hit
+		if len(retrievals) == 1 {
+			hits = retrievals[0].hits[i]
+			if len(hits) > limit {
+				hits = hits[:limit]
+			}
+		} else {
+			if hits, err = rerank(retrievals, i, s.Rerank, limit); err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, c.outputHits(hits, s.OutputFields, s.RetrieveVector))
+	}
+	return results, nil
+}
+
+// match retrieves the sparse vectors of the match option. The limit of the option defaults to limit.
+func (c *localCollection) match(match *document.MatchOption, limit int, selected func(d *storedDoc) bool) (*ranked, error) {
+	name := match.FieldName
+	if name == "" {
+		name = "sparse_vector"
+	}
+	if match.Limit > 0 {
+		limit = match.Limit
+	}
+	r := &ranked{field: name, metric: metric{name: tcvectordb.IP}}
+	for _, data := range match.Data {
+		query, err := toSparseVector(data)
+		if err != nil {
+			return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "invalid match data: %v", err)
+		}
+		hits, err := c.searchSparse(name, query, limit, selected)
+		if err != nil {
+			return nil, err
+		}
+		r.hits = append(r.hits, hits)
+	}
+	return r, nil
+}
+
+// rerank fuses the hits of the retrievals for the i-th query. The weighted method sums the weighted
+// similarities, where the L2 and Hamming distances d are mapped to 1/(1+d). The rrf method sums
+// 1/(k+rank) with k defaulting to 60. Without the option, rrf is used.
+func rerank(retrievals []ranked, i int, option *document.RerankOption, limit int) ([]hit, error) {
+	method := tcvectordb.RerankRrf
+	if option != nil && option.Method != "" {
+		method = tcvectordb.RerankMethod(option.Method)
+	}
+	weights := make([]float32, len(retrievals))
+	for j := range weights {
+		weights[j] = 1
+	}
+	if method == tcvectordb.RerankWeighted && option != nil && len(option.Weight) != 0 {
+		if len(option.FieldList) == 0 {
+			if len(option.Weight) != len(retrievals) {
+				return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "rerank needs %v weights", len(retrievals))
+			}
+			copy(weights, option.Weight)
+		} else {
+			if len(option.FieldList) != len(option.Weight) {
+				return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "the fieldList and weight of rerank must have the same length")
+			}
+			for j, r := range retrievals {
+				weights[j] = 0
+				for k, field := range option.FieldList {
+					if field == r.field {
+						weights[j] = option.Weight[k]
+					}
+				}
+			}
+		}
+	}
+	k := float32(60)
+	if option != nil && option.RrfK > 0 {
+		k = float32(option.RrfK)
+	}
+
+	fused := make(map[string]*hit)
+	for j, r := range retrievals {
+		for rank, h := range r.hits[i] {
+			var score float32
+			switch method {
+			case tcvectordb.RerankWeighted:
+				score = weights[j] * r.metric.similarity(h.score)
+			case tcvectordb.RerankRrf:
+				score = 1 / (k + float32(rank+1))
+			default:
+				return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "unsupported rerank method %v", method)
+			}
+			if f := fused[h.d.doc.Id]; f != nil {
+				f.score += score
+			} else {
+				fused[h.d.doc.Id] = &hit{d: h.d, score: score}
+			}
+		}
+	}
+	hits := make([]hit, 0, len(fused))
+	for _, h := range fused {
+		hits = append(hits, *h)
+	}
+	sortHits(hits, false)
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func (c *localCollection) fullTextSearch(s *document.FullTextSearchCond, now time.Time) ([][]*document.Document, error) {
+	if s.Match == nil {
+		return nil, newError(tcvectordb.ERR_SYNTAX_ERROR, "match is required for full text search")
+	}
+	cond, err := c.compileFilter(s.Filter)
+	if err != nil {
+		return nil, err
+	}
+	r, err := c.match(s.Match, limitOrDefault(s.Limit), c.selector(cond, now))
+	if err != nil {
+		return nil, err
+	}
+	results := make([][]*document.Document, 0, len(r.hits))
+	for _, hits := range r.hits {
+		results = append(results, c.outputHits(hits, s.OutputFields, s.RetrieveVector))
+	}
+	return results, nil
+}
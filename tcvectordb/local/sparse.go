@@ -0,0 +1,62 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+// sparseField is an inverted index of sparse vectors, which maps a term to the weights of the documents.
+// With the sparse vectors encoded by BM25, the inner product of a query and a document is their BM25 score.
+type sparseField struct {
+	postings map[int64]map[string]float32
+}
+
+func newSparseField() *sparseField {
+	return &sparseField{postings: make(map[int64]map[string]float32)}
+}
+
+func (f *sparseField) add(id string, terms map[int64]float32) {
+	for term, weight := range terms {
+		posting := f.postings[term]
+		if posting == nil {
+			posting = make(map[string]float32)
+			f.postings[term] = posting
+		}
+		posting[id] = weight
+	}
+}
+
+func (f *sparseField) remove(id string, terms map[int64]float32) {
+	for term := range terms {
+		if posting := f.postings[term]; posting != nil {
+			delete(posting, id)
+			if len(posting) == 0 {
+				delete(f.postings, term)
+			}
+		}
+	}
+}
+
+// scores returns the inner products of the query with the documents that share a term with it.
+func (f *sparseField) scores(query map[int64]float32) map[string]float32 {
+	scores := make(map[string]float32)
+	for term, weight := range query {
+		for id, w := range f.postings[term] {
+			scores[id] += weight * w
+		}
+	}
+	return scores
+}
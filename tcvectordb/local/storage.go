@@ -0,0 +1,194 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+)
+
+const (
+	databaseFile   = "database.json"
+	collectionFile = "collection.json"
+	documentsFile  = "documents.log"
+)
+
+// writeJSON writes the value into the file atomically, by writing a temporary file and renaming it.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("read %v failed. err: %v", path, err)
+	}
+	return nil
+}
+
+// logEntry is a line of documents.log, which either upserts a document or deletes documents by ids.
+type logEntry struct {
+	Upsert *document.Document `json:"upsert,omitempty"`
+	Delete []string           `json:"delete,omitempty"`
+}
+
+// documentLog is the append-only log of the documents of a collection. A nil documentLog keeps
+// the collection in memory.
+type documentLog struct {
+	path    string
+	file    *os.File
+	writer  *bufio.Writer
+	sync    bool
+	entries int
+}
+
+func openDocumentLog(dir string, sync bool) (*documentLog, error) {
+	path := filepath.Join(dir, documentsFile)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &documentLog{path: path, file: file, writer: bufio.NewWriter(file), sync: sync}, nil
+}
+
+// replay reads the entries of the log in order.
+func (l *documentLog) replay(fn func(entry *logEntry)) error {
+	file, err := os.Open(l.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) != 0 {
+			entry := new(logEntry)
+			if jsonErr := json.Unmarshal(line, entry); jsonErr != nil {
+				// A torn line at the end is left by a crash during writing, which is ignored.
+				if err == io.EOF {
+					return nil
+				}
+				return fmt.Errorf("read %v failed. err: %v", l.path, jsonErr)
+			}
+			fn(entry)
+			l.entries++
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// append writes the entries, and flushes them to the file.
+func (l *documentLog) append(entries ...*logEntry) error {
+	if l == nil {
+		return nil
+	}
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		l.writer.Write(data)
+		l.writer.WriteByte('\n')
+	}
+	l.entries += len(entries)
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+	if l.sync {
+		return l.file.Sync()
+	}
+	return nil
+}
+
+// rewrite replaces the log with the upserts of the documents, which drops the overwritten and deleted ones.
+func (l *documentLog) rewrite(docs []*document.Document) error {
+	if l == nil {
+		return nil
+	}
+	tmp := l.path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for _, doc := range docs {
+		data, err := json.Marshal(&logEntry{Upsert: doc})
+		if err != nil {
+			file.Close()
+			return err
+		}
+		writer.Write(data)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	l.file.Close()
+	if err := os.Rename(tmp, l.path); err != nil {
+		return err
+	}
+	l.file, err = os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	l.writer.Reset(l.file)
+	l.entries = len(docs)
+	return nil
+}
+
+func (l *documentLog) close() error {
+	if l == nil {
+		return nil
+	}
+	l.writer.Flush()
+	return l.file.Close()
+}
@@ -0,0 +1,370 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package local
+
+import (
+	"container/heap"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+// metric measures the distance between two vectors, where a smaller distance means more similar vectors.
+// The score returned to the client is converted from the distance by score.
+type metric struct {
+	name     tcvectordb.MetricType
+	distance func(a, b []float32) float32
+}
+
+func newMetric(name string) (metric, bool) {
+	switch {
+	case strings.EqualFold(name, string(tcvectordb.L2)):
+		return metric{name: tcvectordb.L2, distance: l2Distance}, true
+	case strings.EqualFold(name, string(tcvectordb.IP)):
+		return metric{name: tcvectordb.IP, distance: func(a, b []float32) float32 { return -innerProduct(a, b) }}, true
+	case strings.EqualFold(name, string(tcvectordb.COSINE)):
+		return metric{name: tcvectordb.COSINE, distance: func(a, b []float32) float32 { return -cosine(a, b) }}, true
+	case strings.EqualFold(name, string(tcvectordb.HAMMING)):
+		return metric{name: tcvectordb.HAMMING, distance: hammingDistance}, true
+	}
+	return metric{}, false
+}
+
+// score converts a distance into the score of the server: the squared distance for L2, the number
+// of different bits for Hamming, and the similarity for IP and COSINE.
+func (m metric) score(distance float32) float32 {
+	if m.ascending() {
+		return distance
+	}
+	return -distance
+}
+
+// ascending reports whether a smaller score means a more similar document.
+func (m metric) ascending() bool {
+	return m.name == tcvectordb.L2 || m.name == tcvectordb.HAMMING
+}
+
+// similarity maps the score into a value where a bigger value means more similar, which is used by
+// the weighted rerank of hybrid search.
+func (m metric) similarity(score float32) float32 {
+	if m.ascending() {
+		return 1 / (1 + score)
+	}
+	return score
+}
+
+func l2Distance(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+func innerProduct(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func cosine(a, b []float32) float32 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return float32(dot / math.Sqrt(na*nb))
+}
+
+// hammingDistance counts the different bits of two binary vectors, whose elements hold 8 bits each.
+// See [utils.BinaryToUint8] for the layout.
+func hammingDistance(a, b []float32) float32 {
+	count := 0
+	for i := range a {
+		count += bits.OnesCount8(uint8(a[i]) ^ uint8(b[i]))
+	}
+	return float32(count)
+}
+
+// candidate is a node of the graph, or a document, with its distance to the query.
+type candidate struct {
+	node     int
+	distance float32
+}
+
+// candidateHeap is a heap of candidates, with the farthest one on the top if farthest is set.
+type candidateHeap struct {
+	items    []candidate
+	farthest bool
+}
+
+func (h *candidateHeap) Len() int { return len(h.items) }
+func (h *candidateHeap) Less(i, j int) bool {
+	if h.farthest {
+		return h.items[i].distance > h.items[j].distance
+	}
+	return h.items[i].distance < h.items[j].distance
+}
+func (h *candidateHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *candidateHeap) Push(x interface{}) { h.items = append(h.items, x.(candidate)) }
+func (h *candidateHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+func (h *candidateHeap) top() candidate { return h.items[0] }
+
+// nearest keeps the k candidates with the smallest distances.
+type nearest struct {
+	k    int
+	heap candidateHeap
+}
+
+func newNearest(k int) *nearest {
+	return &nearest{k: k, heap: candidateHeap{farthest: true}}
+}
+
+func (n *nearest) push(c candidate) {
+	if n.heap.Len() < n.k {
+		heap.Push(&n.heap, c)
+	} else if n.k > 0 && c.distance < n.heap.top().distance {
+		n.heap.items[0] = c
+		heap.Fix(&n.heap, 0)
+	}
+}
+
+// sorted returns the candidates from the nearest to the farthest.
+func (n *nearest) sorted() []candidate {
+	items := append([]candidate(nil), n.heap.items...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].distance < items[j].distance })
+	return items
+}
+
+// hnsw is a hierarchical navigable small world graph. Deleted nodes stay in the graph as tombstones
+// to keep it connected, until the graph is rebuilt.
+type hnsw struct {
+	metric         metric
+	m              int
+	efConstruction int
+	levelFactor    float64
+	nodes          []*hnswNode
+	entry          int
+	maxLevel       int
+	deleted        int
+	rand           *rand.Rand
+}
+
+type hnswNode struct {
+	key     interface{}
+	vector  []float32
+	friends [][]int
+	deleted bool
+}
+
+func newHNSW(m metric, M, efConstruction int) *hnsw {
+	if M <= 1 {
+		M = 16
+	}
+	if efConstruction <= 0 {
+		efConstruction = 200
+	}
+	return &hnsw{
+		metric:         m,
+		m:              M,
+		efConstruction: efConstruction,
+		levelFactor:    1 / math.Log(float64(M)),
+		entry:          -1,
+		rand:           rand.New(rand.NewSource(1)),
+	}
+}
+
+func (g *hnsw) distance(node int, query []float32) float32 {
+	return g.metric.distance(query, g.nodes[node].vector)
+}
+
+// insert adds a vector to the graph, and returns the node id.
+func (g *hnsw) insert(key interface{}, vector []float32) int {
+	level := int(-math.Log(1-g.rand.Float64()) * g.levelFactor)
+	id := len(g.nodes)
+	node := &hnswNode{key: key, vector: vector, friends: make([][]int, level+1)}
+	g.nodes = append(g.nodes, node)
+	if g.entry < 0 {
+		g.entry, g.maxLevel = id, level
+		return id
+	}
+
+	entry := candidate{node: g.entry, distance: g.distance(g.entry, vector)}
+	for l := g.maxLevel; l > level; l-- {
+		entry = g.greedy(entry, vector, l)
+	}
+	for l := minInt(level, g.maxLevel); l >= 0; l-- {
+		found := g.searchLayer(vector, []candidate{entry}, g.efConstruction, l, nil)
+		neighbors := found
+		if len(neighbors) > g.m {
+			neighbors = neighbors[:g.m]
+		}
+		for _, nb := range neighbors {
+			node.friends[l] = append(node.friends[l], nb.node)
+			g.link(nb.node, id, l)
+		}
+		if len(found) > 0 {
+			entry = found[0]
+		}
+	}
+	if level > g.maxLevel {
+		g.entry, g.maxLevel = id, level
+	}
+	return id
+}
+
+// link adds a edge from the node to the friend, and keeps the closest friends if the node has too many.
+func (g *hnsw) link(node, friend, level int) {
+	n := g.nodes[node]
+	n.friends[level] = append(n.friends[level], friend)
+	limit := g.m
+	if level == 0 {
+		limit = 2 * g.m
+	}
+	if len(n.friends[level]) <= limit {
+		return
+	}
+	friends := make([]candidate, 0, len(n.friends[level]))
+	for _, f := range n.friends[level] {
+		friends = append(friends, candidate{node: f, distance: g.metric.distance(n.vector, g.nodes[f].vector)})
+	}
+	sort.SliceStable(friends, func(i, j int) bool { return friends[i].distance < friends[j].distance })
+	n.friends[level] = n.friends[level][:0]
+	for _, f := range friends[:limit] {
+		n.friends[level] = append(n.friends[level], f.node)
+	}
+}
+
+func (g *hnsw) greedy(entry candidate, query []float32, level int) candidate {
+	for changed := true; changed; {
+		changed = false
+		for _, f := range g.nodes[entry.node].friends[level] {
+			if d := g.distance(f, query); d < entry.distance {
+				entry, changed = candidate{node: f, distance: d}, true
+			}
+		}
+	}
+	return entry
+}
+
+// searchLayer returns the ef nearest nodes of the level accepted by allow, from the nearest to the
+// farthest. Deleted and rejected nodes are traversed, but never returned.
+func (g *hnsw) searchLayer(query []float32, entries []candidate, ef, level int, allow func(key interface{}) bool) []candidate {
+	visited := make(map[int]bool)
+	candidates := &candidateHeap{}
+	results := newNearest(ef)
+	accept := func(c candidate) {
+		node := g.nodes[c.node]
+		if !node.deleted && (allow == nil || allow(node.key)) {
+			results.push(c)
+		}
+	}
+	for _, e := range entries {
+		visited[e.node] = true
+		heap.Push(candidates, e)
+		accept(e)
+	}
+	// bound is the distance to stop at, which is the farthest result once ef results are found.
+	bound := func() float32 {
+		if results.heap.Len() < ef {
+			return float32(math.Inf(1))
+		}
+		return results.heap.top().distance
+	}
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate)
+		if c.distance > bound() && results.heap.Len() >= ef {
+			break
+		}
+		for _, f := range g.nodes[c.node].friends[level] {
+			if visited[f] {
+				continue
+			}
+			visited[f] = true
+			d := g.distance(f, query)
+			if d < bound() {
+				next := candidate{node: f, distance: d}
+				heap.Push(candidates, next)
+				accept(next)
+			}
+		}
+	}
+	return results.sorted()
+}
+
+// search returns the k nearest nodes accepted by allow, searching the bottom level with ef candidates.
+func (g *hnsw) search(query []float32, k, ef int, allow func(key interface{}) bool) []candidate {
+	if g.entry < 0 || k <= 0 {
+		return nil
+	}
+	if ef < k {
+		ef = k
+	}
+	entry := candidate{node: g.entry, distance: g.distance(g.entry, query)}
+	for l := g.maxLevel; l > 0; l-- {
+		entry = g.greedy(entry, query, l)
+	}
+	found := g.searchLayer(query, []candidate{entry}, ef, 0, allow)
+	if len(found) > k {
+		found = found[:k]
+	}
+	return found
+}
+
+// remove marks the node as deleted.
+func (g *hnsw) remove(node int) {
+	if !g.nodes[node].deleted {
+		g.nodes[node].deleted = true
+		g.deleted++
+	}
+}
+
+// live returns the number of nodes that are not deleted.
+func (g *hnsw) live() int {
+	return len(g.nodes) - g.deleted
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,265 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const defaultMultiSearchConcurrency = 8
+
+// [SearchTarget] holds a collection to search in [MultiCollectionSearch].
+//
+// Fields:
+//   - DatabaseName: (Required) The name of the database.
+//   - CollectionName: (Required) The name of the collection.
+//   - MetricType: (Optional) The metric type of the vector index. It is taken from [DescribeCollection]
+//     when it is empty and the scores are normalized by NormalizeMetric.
+type SearchTarget struct {
+	DatabaseName   string
+	CollectionName string
+	MetricType     MetricType
+}
+
+// [MultiCollectionSearchParams] holds the parameters for searching documents across multiple collections.
+// Exactly one of Vectors, HybridSearch and FullTextSearch should be set.
+//
+// Fields:
+//   - Targets: (Required) The collections to search, which may be in different databases.
+//   - Vectors: The vectors to search by [Search]. The maximum number of elements in the array is 20.
+//   - Search: (Optional) A pointer to a [SearchDocumentParams] object used with Vectors.
+//   - HybridSearch: A pointer to a [HybridSearchDocumentParams] object to search by [HybridSearch].
+//   - FullTextSearch: A pointer to a [FullTextSearchParams] object to search by [FullTextSearch].
+//   - Limit: (Optional) The number of documents returned for each query after merging
+//     (defaults to the limit of each search).
+//   - Normalization: (Optional) The method to normalize the scores of each collection before merging
+//     (defaults to NormalizeMetric for Vectors and NormalizeMinMax for the others).
+//     See [ScoreNormalization] for more information.
+//   - Concurrency: (Optional) The maximum number of the concurrent searches (defaults to 8).
+type MultiCollectionSearchParams struct {
+	Targets        []SearchTarget
+	Vectors        [][]float32
+	Search         *SearchDocumentParams
+	HybridSearch   *HybridSearchDocumentParams
+	FullTextSearch *FullTextSearchParams
+	Limit          int
+	Normalization  ScoreNormalization
+	Concurrency    int
+}
+
+// [SearchTargetError] holds the error of searching a target in [MultiCollectionSearch].
+type SearchTargetError struct {
+	DatabaseName   string
+	CollectionName string
+	Err            error
+}
+
+func (e *SearchTargetError) Error() string {
+	return fmt.Sprintf("search %v.%v failed. err: %v", e.DatabaseName, e.CollectionName, e.Err)
+}
+
+func (e *SearchTargetError) Unwrap() error {
+	return e.Err
+}
+
+// [MultiCollectionSearchResult] holds the results for searching documents across multiple collections.
+//
+// Fields:
+//   - Warning: The warning messages returned from the server, prefixed by the collections.
+//   - Documents: The global top documents for each query, tagged with their source collections.
+//     The scores are normalized, and a greater score means more similar.
+//   - Errors: The errors of the targets failed to search. The documents of the other targets are still merged.
+type MultiCollectionSearchResult struct {
+	Warning   string
+	Documents [][]RerankedDocument
+	Errors    []*SearchTargetError
+}
+
+// [MultiCollectionSearch] searches the documents across multiple collections concurrently, and merges the
+// results into a global top list for each query by the normalized scores.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - param: A [MultiCollectionSearchParams] object that includes the parameters for searching documents' operation.
+//     See [MultiCollectionSearchParams] for more information.
+//
+// Notes: The partial failures are reported in the Errors of the result, and an error is returned only
+// when the parameters are invalid or all targets fail.
+//
+// Returns a pointer to a [MultiCollectionSearchResult] object or an error.
+func MultiCollectionSearch(ctx context.Context, cli FlatDocumentInterface,
+	param MultiCollectionSearchParams) (*MultiCollectionSearchResult, error) {
+	modes := 0
+	for _, set := range []bool{len(param.Vectors) != 0, param.HybridSearch != nil, param.FullTextSearch != nil} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return nil, errors.New("exactly one of Vectors, HybridSearch and FullTextSearch should be set")
+	}
+	if len(param.Targets) == 0 {
+		return nil, errors.New("search targets are empty")
+	}
+	normalization := param.Normalization
+	if normalization == "" {
+		normalization = NormalizeMinMax
+		if len(param.Vectors) != 0 {
+			normalization = NormalizeMetric
+		}
+	}
+	concurrency := param.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultMultiSearchConcurrency
+	}
+
+	lists := make([][]RankedList, len(param.Targets))
+	warnings := make([]string, len(param.Targets))
+	errs := make([]error, len(param.Targets))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range param.Targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			lists[i], warnings[i], errs[i] = searchTarget(ctx, cli, param.Targets[i], param, normalization)
+		}(i)
+	}
+	wg.Wait()
+
+	result := new(MultiCollectionSearchResult)
+	var warningList []string
+	queryNum := 0
+	for i, target := range param.Targets {
+		if errs[i] != nil {
+			result.Errors = append(result.Errors, &SearchTargetError{
+				DatabaseName:   target.DatabaseName,
+				CollectionName: target.CollectionName,
+				Err:            errs[i],
+			})
+			continue
+		}
+		if warnings[i] != "" {
+			warningList = append(warningList, target.DatabaseName+"."+target.CollectionName+": "+warnings[i])
+		}
+		if len(lists[i]) > queryNum {
+			queryNum = len(lists[i])
+		}
+	}
+	if len(result.Errors) == len(param.Targets) {
+		return nil, result.Errors[0]
+	}
+	result.Warning = strings.Join(warningList, "; ")
+
+	for q := 0; q < queryNum; q++ {
+		var docs []RerankedDocument
+		for i := range param.Targets {
+			if errs[i] != nil || q >= len(lists[i]) {
+				continue
+			}
+			list := lists[i][q]
+			scores, err := normalizeScores(list, normalization)
+			if err != nil {
+				return nil, err
+			}
+			for j, doc := range list.Documents {
+				doc.Score = float32(scores[j])
+				docs = append(docs, RerankedDocument{
+					Document:       doc,
+					DatabaseName:   list.DatabaseName,
+					CollectionName: list.CollectionName,
+				})
+			}
+		}
+		sortRerankedDocuments(docs)
+		if limit := multiSearchLimit(param); limit > 0 && len(docs) > limit {
+			docs = docs[:limit]
+		}
+		result.Documents = append(result.Documents, docs)
+	}
+	return result, nil
+}
+
+func searchTarget(ctx context.Context, cli FlatDocumentInterface, target SearchTarget, param MultiCollectionSearchParams,
+	normalization ScoreNormalization) ([]RankedList, string, error) {
+	metricType := target.MetricType
+	if metricType == "" && len(param.Vectors) != 0 && normalization != NormalizeMinMax {
+		coll, err := describeCollection(ctx, cli, target.DatabaseName, target.CollectionName)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(coll.Indexes.VectorIndex) != 0 {
+			metricType = coll.Indexes.VectorIndex[0].MetricType
+		}
+	}
+
+	var (
+		res *SearchDocumentResult
+		err error
+	)
+	switch {
+	case len(param.Vectors) != 0:
+		var params []*SearchDocumentParams
+		if param.Search != nil {
+			params = append(params, param.Search)
+		}
+		res, err = cli.Search(ctx, target.DatabaseName, target.CollectionName, param.Vectors, params...)
+	case param.HybridSearch != nil:
+		res, err = cli.HybridSearch(ctx, target.DatabaseName, target.CollectionName, *param.HybridSearch)
+	default:
+		res, err = cli.FullTextSearch(ctx, target.DatabaseName, target.CollectionName, *param.FullTextSearch)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	lists := make([]RankedList, 0, len(res.Documents))
+	for _, docs := range res.Documents {
+		lists = append(lists, RankedList{
+			DatabaseName:   target.DatabaseName,
+			CollectionName: target.CollectionName,
+			MetricType:     metricType,
+			Documents:      docs,
+		})
+	}
+	return lists, res.Warning, nil
+}
+
+func multiSearchLimit(param MultiCollectionSearchParams) int {
+	if param.Limit > 0 {
+		return param.Limit
+	}
+	switch {
+	case param.Search != nil:
+		return int(param.Search.Limit)
+	case param.HybridSearch != nil && param.HybridSearch.Limit != nil:
+		return *param.HybridSearch.Limit
+	case param.FullTextSearch != nil && param.FullTextSearch.Limit != nil:
+		return *param.FullTextSearch.Limit
+	}
+	return 0
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// [RecommendStrategy] defines the methods to build the query vector from the examples.
+type RecommendStrategy string
+
+const (
+	// RecommendAverage builds the query vector as avg(positive) + (avg(positive) - avg(negative)),
+	// and as avg(positive) without negative examples.
+	RecommendAverage RecommendStrategy = "average"
+	// RecommendRocchio builds the query vector as Alpha*query + Beta*avg(positive) - Gamma*avg(negative).
+	RecommendRocchio RecommendStrategy = "rocchio"
+)
+
+const (
+	defaultRocchioAlpha = float32(1)
+	defaultRocchioBeta  = float32(0.75)
+	defaultRocchioGamma = float32(0.15)
+)
+
+// [RecommendParams] holds the parameters for recommending documents by positive and negative examples.
+//
+// Fields:
+//   - SearchDocumentParams: The parameters for searching documents. Limit is the number of documents
+//     returned after excluding the examples. See [SearchDocumentParams] for more information.
+//   - PositiveIds: (Optional) The ids of the documents to be similar to.
+//   - NegativeIds: (Optional) The ids of the documents to be dissimilar to.
+//   - PositiveVectors: (Optional) The vectors to be similar to.
+//   - NegativeVectors: (Optional) The vectors to be dissimilar to.
+//   - LookupDatabase: (Optional) The database of the examples' documents (defaults to the searched database).
+//   - LookupCollection: (Optional) The collection of the examples' documents (defaults to the searched collection).
+//   - Strategy: (Optional) The method to build the query vector (defaults to RecommendAverage).
+//     See [RecommendStrategy] for more information.
+//   - QueryVector: (Optional) The original query vector of RecommendRocchio.
+//   - Alpha: (Optional) The weight of QueryVector of RecommendRocchio (defaults to 1).
+//   - Beta: (Optional) The weight of the positive examples of RecommendRocchio (defaults to 0.75).
+//   - Gamma: (Optional) The weight of the negative examples of RecommendRocchio (defaults to 0.15).
+//   - IncludeExamples: (Optional) Whether to keep the example documents in the results. By default, the
+//     documents of PositiveIds and NegativeIds are excluded when they are looked up in the searched collection.
+type RecommendParams struct {
+	SearchDocumentParams
+	PositiveIds      []string
+	NegativeIds      []string
+	PositiveVectors  [][]float32
+	NegativeVectors  [][]float32
+	LookupDatabase   string
+	LookupCollection string
+	Strategy         RecommendStrategy
+	QueryVector      []float32
+	Alpha            *float32
+	Beta             *float32
+	Gamma            *float32
+	IncludeExamples  bool
+}
+
+// [Recommend] recommends the documents which are similar to the positive examples and dissimilar to
+// the negative examples. The vectors of the example ids are fetched by [Query], and the query vector
+// built from the examples is searched by [Search].
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - param: A [RecommendParams] object that includes the other parameters for recommending documents' operation.
+//     See [RecommendParams] for more information.
+//
+// Returns a pointer to a [SearchDocumentResult] object with the documents of one query or an error.
+func Recommend(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	param RecommendParams) (*SearchDocumentResult, error) {
+	lookupDatabase := param.LookupDatabase
+	if lookupDatabase == "" {
+		lookupDatabase = databaseName
+	}
+	lookupCollection := param.LookupCollection
+	if lookupCollection == "" {
+		lookupCollection = collectionName
+	}
+
+	positive := append([][]float32{}, param.PositiveVectors...)
+	negative := append([][]float32{}, param.NegativeVectors...)
+	ids := append(append([]string{}, param.PositiveIds...), param.NegativeIds...)
+	if len(ids) != 0 {
+		vectors, err := queryVectors(ctx, cli, lookupDatabase, lookupCollection, ids)
+		if err != nil {
+			return nil, err
+		}
+		positive = append(positive, vectors[:len(param.PositiveIds)]...)
+		negative = append(negative, vectors[len(param.PositiveIds):]...)
+	}
+
+	queryVector, err := recommendVector(positive, negative, param)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := make(map[string]bool)
+	if !param.IncludeExamples && lookupDatabase == databaseName && lookupCollection == collectionName {
+		for _, id := range ids {
+			exclude[id] = true
+		}
+	}
+	searchParam := param.SearchDocumentParams
+	limit := searchParam.Limit
+	if limit > 0 {
+		searchParam.Limit += int64(len(exclude))
+	}
+	res, err := cli.Search(ctx, databaseName, collectionName, [][]float32{queryVector}, &searchParam)
+	if err != nil {
+		return nil, err
+	}
+	for i, docs := range res.Documents {
+		kept := make([]Document, 0, len(docs))
+		for _, doc := range docs {
+			if exclude[doc.Id] {
+				continue
+			}
+			kept = append(kept, doc)
+		}
+		if limit > 0 && int64(len(kept)) > limit {
+			kept = kept[:limit]
+		}
+		res.Documents[i] = kept
+	}
+	return res, nil
+}
+
+// queryVectors returns the vectors of the documents in the order of the ids.
+func queryVectors(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	ids []string) ([][]float32, error) {
+	res, err := cli.Query(ctx, databaseName, collectionName, ids, &QueryDocumentParams{
+		RetrieveVector: true,
+		OutputFields:   []string{"id"},
+		Limit:          int64(len(ids)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	vectors := make(map[string][]float32, len(res.Documents))
+	for _, doc := range res.Documents {
+		vectors[doc.Id] = doc.Vector
+	}
+	result := make([][]float32, 0, len(ids))
+	for _, id := range ids {
+		vector, ok := vectors[id]
+		if !ok || len(vector) == 0 {
+			return nil, fmt.Errorf("the vector of document %v is not found in %v.%v", id, databaseName, collectionName)
+		}
+		result = append(result, vector)
+	}
+	return result, nil
+}
+
+func recommendVector(positive, negative [][]float32, param RecommendParams) ([]float32, error) {
+	dimension := len(param.QueryVector)
+	for _, vector := range append(append([][]float32{}, positive...), negative...) {
+		if dimension == 0 {
+			dimension = len(vector)
+		}
+		if len(vector) != dimension {
+			return nil, fmt.Errorf("the dimension of the examples is inconsistent, %v and %v", dimension, len(vector))
+		}
+	}
+	positiveAvg := averageVector(positive, dimension)
+	negativeAvg := averageVector(negative, dimension)
+
+	query := make([]float32, dimension)
+	switch param.Strategy {
+	case "", RecommendAverage:
+		if positiveAvg == nil {
+			return nil, errors.New("positive examples are required by the average strategy")
+		}
+		for i := range query {
+			query[i] = positiveAvg[i]
+			if negativeAvg != nil {
+				query[i] += positiveAvg[i] - negativeAvg[i]
+			}
+		}
+	case RecommendRocchio:
+		if positiveAvg == nil && len(param.QueryVector) == 0 {
+			return nil, errors.New("positive examples or the query vector are required by the rocchio strategy")
+		}
+		alpha, beta, gamma := defaultRocchioAlpha, defaultRocchioBeta, defaultRocchioGamma
+		if param.Alpha != nil {
+			alpha = *param.Alpha
+		}
+		if param.Beta != nil {
+			beta = *param.Beta
+		}
+		if param.Gamma != nil {
+			gamma = *param.Gamma
+		}
+		for i := range query {
+			if len(param.QueryVector) != 0 {
+				query[i] += alpha * param.QueryVector[i]
+			}
+			if positiveAvg != nil {
+				query[i] += beta * positiveAvg[i]
+			}
+			if negativeAvg != nil {
+				query[i] -= gamma * negativeAvg[i]
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported recommend strategy: %v", param.Strategy)
+	}
+	return query, nil
+}
+
+func averageVector(vectors [][]float32, dimension int) []float32 {
+	if len(vectors) == 0 {
+		return nil
+	}
+	avg := make([]float32, dimension)
+	for _, vector := range vectors {
+		for i, v := range vector {
+			avg[i] += v
+		}
+	}
+	for i := range avg {
+		avg[i] /= float32(len(vectors))
+	}
+	return avg
+}
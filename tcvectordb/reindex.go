@@ -0,0 +1,383 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const maxReplayRounds = 3
+
+// capturedWrite replays a captured write into the collection.
+type capturedWrite func(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string) error
+
+// [WriteCapture] wraps a client to capture the writes to a collection, so that they can be replayed
+// into another collection, such as the writes made during the copy of [Reindex]. The application
+// should write through the [WriteCapture] while the capture is running.
+type WriteCapture struct {
+	FlatDocumentInterface
+	mu           sync.Mutex
+	capturing    bool
+	databaseName string
+	names        map[string]bool
+	writes       []capturedWrite
+}
+
+// [NewWriteCapture] creates a [WriteCapture] wrapping the client.
+//
+// Parameters:
+//   - cli: The client to write with, such as [Client], [RpcClient] or [VdbClient].
+//
+// Returns a pointer to a [WriteCapture] object.
+func NewWriteCapture(cli FlatDocumentInterface) *WriteCapture {
+	return &WriteCapture{FlatDocumentInterface: cli}
+}
+
+// [Upsert] upserts documents into a collection, and captures them if the collection is being captured.
+func (w *WriteCapture) Upsert(ctx context.Context, databaseName, collectionName string, documents interface{},
+	params ...*UpsertDocumentParams) (*UpsertDocumentResult, error) {
+	if !w.captured(databaseName, collectionName) {
+		return w.FlatDocumentInterface.Upsert(ctx, databaseName, collectionName, documents, params...)
+	}
+	// Upsert modifies the maps of the documents, so they are copied before upserting.
+	docs := copyUpsertDocuments(documents)
+	res, err := w.FlatDocumentInterface.Upsert(ctx, databaseName, collectionName, documents, params...)
+	if err == nil {
+		w.record(databaseName, collectionName, func(ctx context.Context, cli FlatDocumentInterface, db, coll string) error {
+			_, err := cli.Upsert(ctx, db, coll, copyUpsertDocuments(docs), params...)
+			return err
+		})
+	}
+	return res, err
+}
+
+// [Delete] deletes documents by conditions, and captures the deletion if the collection is being captured.
+func (w *WriteCapture) Delete(ctx context.Context, databaseName, collectionName string,
+	param DeleteDocumentParams) (*DeleteDocumentResult, error) {
+	res, err := w.FlatDocumentInterface.Delete(ctx, databaseName, collectionName, param)
+	if err == nil {
+		w.record(databaseName, collectionName, func(ctx context.Context, cli FlatDocumentInterface, db, coll string) error {
+			_, err := cli.Delete(ctx, db, coll, param)
+			return err
+		})
+	}
+	return res, err
+}
+
+// [Update] updates documents by conditions, and captures the update if the collection is being captured.
+func (w *WriteCapture) Update(ctx context.Context, databaseName, collectionName string,
+	param UpdateDocumentParams) (*UpdateDocumentResult, error) {
+	res, err := w.FlatDocumentInterface.Update(ctx, databaseName, collectionName, param)
+	if err == nil {
+		w.record(databaseName, collectionName, func(ctx context.Context, cli FlatDocumentInterface, db, coll string) error {
+			_, err := cli.Update(ctx, db, coll, param)
+			return err
+		})
+	}
+	return res, err
+}
+
+// start starts capturing the writes to the collection, which may be addressed by its aliases.
+func (w *WriteCapture) start(databaseName string, names ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.capturing = true
+	w.databaseName = databaseName
+	w.names = make(map[string]bool, len(names))
+	for _, name := range names {
+		if name != "" {
+			w.names[name] = true
+		}
+	}
+	w.writes = nil
+}
+
+// drain returns the captured writes and clears them. It stops capturing if stop is true.
+func (w *WriteCapture) drain(stop bool) []capturedWrite {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	writes := w.writes
+	w.writes = nil
+	if stop {
+		w.capturing = false
+	}
+	return writes
+}
+
+func (w *WriteCapture) captured(databaseName, collectionName string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.capturing && w.databaseName == databaseName && w.names[collectionName]
+}
+
+func (w *WriteCapture) record(databaseName, collectionName string, write capturedWrite) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.capturing && w.databaseName == databaseName && w.names[collectionName] {
+		w.writes = append(w.writes, write)
+	}
+}
+
+func copyUpsertDocuments(documents interface{}) interface{} {
+	switch docs := documents.(type) {
+	case []Document:
+		return append([]Document{}, docs...)
+	case []map[string]interface{}:
+		copied := make([]map[string]interface{}, 0, len(docs))
+		for _, doc := range docs {
+			m := make(map[string]interface{}, len(doc))
+			for k, v := range doc {
+				m[k] = v
+			}
+			copied = append(copied, m)
+		}
+		return copied
+	}
+	return documents
+}
+
+// [ReindexParams] holds the parameters for reindexing a collection into a new collection.
+//
+// Fields:
+//   - NewCollectionName: (Optional) The name of the new collection (defaults to the old name with a timestamp suffix).
+//   - Alias: (Optional) The alias to point to the new collection after the documents are copied and validated.
+//   - ShardNum: (Optional) The shard number of the new collection (defaults to the old one).
+//   - ReplicasNum: (Optional) The replicas number of the new collection (defaults to the old one).
+//   - Description: (Optional) The description of the new collection (defaults to the old one).
+//   - Indexes: (Optional) The indexes of the new collection (defaults to the old ones).
+//   - Embedding: (Optional) The embedding of the new collection (defaults to the old one). The vectors
+//     are not copied when the embedding of the new collection is set, so they are embedded again.
+//   - TtlConfig: (Optional) The TTL configuration of the new collection (defaults to the old one).
+//   - FilterIndexConfig: (Optional) The filter index configuration of the new collection (defaults to the old one).
+//   - BatchSize: (Optional) The number of documents queried and upserted in each batch (defaults to 500).
+//   - Transform: (Optional) The function to modify each document before it is upserted, such as
+//     re-embedding the vector for a new dimension.
+//   - Capture: (Optional) The [WriteCapture] that the application writes through. The writes to the old
+//     collection during the copy are replayed into the new collection.
+//   - SkipCountValidation: (Optional) Whether to skip comparing the document counts of the two collections.
+//   - DropOld: (Optional) Whether to drop the old collection after the alias points to the new collection.
+//     It requires Alias, so that the readers of the alias are not left without a collection.
+//   - Wait: (Optional) A pointer to a [WaitIndexOptions] object that includes the options for waiting for
+//     the index of the new collection to be ready.
+//   - Progress: (Optional) The callback called after each batch with the number of copied documents.
+type ReindexParams struct {
+	NewCollectionName   string
+	Alias               string
+	ShardNum            uint32
+	ReplicasNum         uint32
+	Description         *string
+	Indexes             *Indexes
+	Embedding           *Embedding
+	TtlConfig           *TtlConfig
+	FilterIndexConfig   *FilterIndexConfig
+	BatchSize           int64
+	Transform           func(doc *Document) error
+	Capture             *WriteCapture
+	SkipCountValidation bool
+	DropOld             bool
+	Wait                *WaitIndexOptions
+	Progress            func(copied int64)
+}
+
+// [ReindexResult] holds the results for reindexing a collection.
+//
+// Fields:
+//   - NewCollectionName: The name of the new collection.
+//   - CopiedCount: The number of documents copied from the old collection.
+//   - ReplayedCount: The number of captured writes replayed into the new collection.
+//   - SourceCount: The number of documents in the old collection after the copy.
+//   - TargetCount: The number of documents in the new collection after the copy.
+//   - OldDropped: Whether the old collection is dropped.
+type ReindexResult struct {
+	NewCollectionName string
+	CopiedCount       int64
+	ReplayedCount     int
+	SourceCount       uint64
+	TargetCount       uint64
+	OldDropped        bool
+}
+
+// [Reindex] recreates a collection in the blue-green way. It creates a new collection from the old one
+// with the overrides, copies all the documents, replays the captured writes, validates the document counts,
+// and then points the alias to the new collection.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to reindex with, such as [Client], [RpcClient] or the client returned by [NewRpcClientPool].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the old collection.
+//   - param: A [ReindexParams] object that includes the other parameters for reindexing.
+//     See [ReindexParams] for more information.
+//
+// Notes: The new collection is kept when the reindex fails after it is created, so that it can be checked.
+// The writes made between the last replay and the alias switch are not replayed. The documents are copied
+// by [DocumentIterator], which pages by offset without a stable order, so a document deleted from the old
+// collection during the copy can shift the pages and make other documents skipped, which the Capture does
+// not replay. Stop deleting from the old collection while it is copied, or the count validation fails.
+//
+// Returns a pointer to a [ReindexResult] object or an error.
+func Reindex(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	param ReindexParams) (*ReindexResult, error) {
+	if param.DropOld && param.Alias == "" {
+		return nil, errors.New("reindex with DropOld requires Alias to point to the new collection")
+	}
+	db, err := databaseOf(cli, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	old, err := db.DescribeCollection(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReindexResult{NewCollectionName: param.NewCollectionName}
+	if result.NewCollectionName == "" {
+		result.NewCollectionName = collectionName + "_" + strconv.FormatInt(time.Now().Unix(), 10)
+	}
+	shardNum, replicasNum := old.ShardNum, old.ReplicasNum
+	if param.ShardNum != 0 {
+		shardNum = param.ShardNum
+	}
+	if param.ReplicasNum != 0 {
+		replicasNum = param.ReplicasNum
+	}
+	description := old.Description
+	if param.Description != nil {
+		description = *param.Description
+	}
+	indexes := old.Indexes
+	if param.Indexes != nil {
+		indexes = *param.Indexes
+	}
+	createParam := &CreateCollectionParams{
+		Embedding:         param.Embedding,
+		TtlConfig:         old.TtlConfig,
+		FilterIndexConfig: old.FilterIndexConfig,
+	}
+	if createParam.Embedding == nil && old.Embedding.Enabled {
+		createParam.Embedding = &Embedding{
+			Field:       old.Embedding.Field,
+			VectorField: old.Embedding.VectorField,
+			ModelName:   old.Embedding.ModelName,
+		}
+	}
+	if param.TtlConfig != nil {
+		createParam.TtlConfig = param.TtlConfig
+	}
+	if param.FilterIndexConfig != nil {
+		createParam.FilterIndexConfig = param.FilterIndexConfig
+	}
+	_, err = db.CreateCollection(ctx, result.NewCollectionName, shardNum, replicasNum, description, indexes, createParam)
+	if err != nil {
+		return nil, fmt.Errorf("create collection %v failed. err: %v", result.NewCollectionName, err)
+	}
+	fail := func(step string, err error) (*ReindexResult, error) {
+		return nil, fmt.Errorf("reindex %v.%v into %v failed when %v. err: %v",
+			databaseName, collectionName, result.NewCollectionName, step, err)
+	}
+
+	if param.Capture != nil {
+		param.Capture.start(databaseName, append([]string{collectionName}, old.Alias...)...)
+		defer param.Capture.drain(true)
+	}
+
+	it := NewDocumentIterator(cli, databaseName, collectionName, &DocumentIteratorParams{
+		RetrieveVector: createParam.Embedding == nil,
+		BatchSize:      param.BatchSize,
+	})
+	for {
+		docs, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail("querying documents", err)
+		}
+		if param.Transform != nil {
+			for i := range docs {
+				if err = param.Transform(&docs[i]); err != nil {
+					return fail("transforming documents", err)
+				}
+			}
+		}
+		if _, err = cli.Upsert(ctx, databaseName, result.NewCollectionName, docs); err != nil {
+			return fail("upserting documents", err)
+		}
+		result.CopiedCount += int64(len(docs))
+		if param.Progress != nil {
+			param.Progress(result.CopiedCount)
+		}
+	}
+
+	if param.Capture != nil {
+		// The writes are replayed in rounds while the application keeps writing, and the capture
+		// stops at the last round.
+		for round := 0; round <= maxReplayRounds; round++ {
+			writes := param.Capture.drain(round == maxReplayRounds)
+			for _, write := range writes {
+				if err = write(ctx, cli, databaseName, result.NewCollectionName); err != nil {
+					return fail("replaying writes", err)
+				}
+			}
+			result.ReplayedCount += len(writes)
+			if len(writes) == 0 && round < maxReplayRounds {
+				round = maxReplayRounds - 1
+			}
+		}
+	}
+
+	if err = waitForIndexReady(ctx, cli, databaseName, result.NewCollectionName, nil, param.Wait); err != nil {
+		return fail("waiting for index", err)
+	}
+	if !param.SkipCountValidation {
+		sourceCount, err := cli.Count(ctx, databaseName, collectionName)
+		if err != nil {
+			return fail("counting documents", err)
+		}
+		targetCount, err := cli.Count(ctx, databaseName, result.NewCollectionName)
+		if err != nil {
+			return fail("counting documents", err)
+		}
+		result.SourceCount, result.TargetCount = sourceCount.Count, targetCount.Count
+		if result.SourceCount != result.TargetCount {
+			return fail("validating counts", errors.New("the document counts are different, "+
+				strconv.FormatUint(result.SourceCount, 10)+" and "+strconv.FormatUint(result.TargetCount, 10)))
+		}
+	}
+
+	if param.Alias != "" {
+		if _, err = db.SetAlias(ctx, result.NewCollectionName, param.Alias); err != nil {
+			return fail("setting alias", err)
+		}
+	}
+	if param.DropOld {
+		if _, err = db.DropCollection(ctx, collectionName); err != nil {
+			return fail("dropping the old collection", err)
+		}
+		result.OldDropped = true
+	}
+	return result, nil
+}
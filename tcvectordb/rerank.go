@@ -0,0 +1,537 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRrfK       = 60
+	defaultDecayValue = 0.5
+)
+
+// [ScoreNormalization] defines the methods to normalize the scores of different retrievals
+// before they are fused.
+type ScoreNormalization string
+
+const (
+	// NormalizeMinMax scales the scores of each retrieval into [0, 1] by their minimum and maximum.
+	NormalizeMinMax ScoreNormalization = "min_max"
+	// NormalizeMetric converts each score into [0, 1] by the metric type of the retrieval, so the scores
+	// of different retrievals with the same metric type keep comparable.
+	NormalizeMetric ScoreNormalization = "metric"
+	// NormalizeNone uses the raw scores.
+	NormalizeNone ScoreNormalization = "none"
+)
+
+// [DecayFunction] defines the shapes of the time-decay curve.
+type DecayFunction string
+
+const (
+	DecayExp    DecayFunction = "exp"
+	DecayGauss  DecayFunction = "gauss"
+	DecayLinear DecayFunction = "linear"
+)
+
+// [Reranker] reranks the documents of one or more retrievals, such as the results of [Search],
+// [FullTextSearch] and [SearchByText] for the same query, into one list sorted by the new scores.
+type Reranker interface {
+	Rerank(ctx context.Context, lists []RankedList) ([]RerankedDocument, error)
+}
+
+// [RankedList] holds the documents of one retrieval, sorted by the order returned from the server.
+//
+// Fields:
+//   - DatabaseName: The name of the database that the documents come from.
+//   - CollectionName: The name of the collection that the documents come from.
+//   - MetricType: (Optional) The metric type of the retrieval, which tells whether a greater score is
+//     more similar. The scores of L2 and HAMMING are distances, and the others are similarities.
+//     It is empty for [FullTextSearch] and [HybridSearch].
+//   - Documents: The documents of the retrieval.
+type RankedList struct {
+	DatabaseName   string
+	CollectionName string
+	MetricType     MetricType
+	Documents      []Document
+}
+
+// [RerankedDocument] holds a document after reranking. The Score of the Document is the reranked score.
+//
+// Fields:
+//   - DatabaseName: The name of the database that the document comes from.
+//   - CollectionName: The name of the collection that the document comes from.
+type RerankedDocument struct {
+	Document
+	DatabaseName   string
+	CollectionName string
+}
+
+// [RerankSource] holds the result of one search call to be reranked.
+//
+// Fields:
+//   - DatabaseName: The name of the database searched.
+//   - CollectionName: The name of the collection searched.
+//   - MetricType: (Optional) The metric type of the retrieval. See [RankedList] for more information.
+//   - Result: The result returned from [Search], [HybridSearch], [FullTextSearch], [SearchById] or [SearchByText].
+type RerankSource struct {
+	DatabaseName   string
+	CollectionName string
+	MetricType     MetricType
+	Result         *SearchDocumentResult
+}
+
+// [RerankSearchResult] holds the results of reranking.
+//
+// Fields:
+//   - Warning: The warning messages of the search results.
+//   - Documents: The reranked documents for each query.
+type RerankSearchResult struct {
+	Warning   string
+	Documents [][]RerankedDocument
+}
+
+// [RerankSearchResults] fuses and reranks the results of separate search calls, which may be made on
+// different collections.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - reranker: The [Reranker] to rerank the documents, such as [RRFReranker] or [WeightedReranker].
+//   - limit: The number of documents returned for each query. 0 means returning all documents.
+//   - sources: The results to rerank. The results are fused by the index of the query, so they should
+//     be searched with the same queries in the same order.
+//
+// Notes: The documents are identified by the database, the collection and the document id. The fields
+// of the same document from different results are merged.
+//
+// Returns a pointer to a [RerankSearchResult] object or an error.
+func RerankSearchResults(ctx context.Context, reranker Reranker, limit int,
+	sources ...RerankSource) (*RerankSearchResult, error) {
+	if reranker == nil {
+		return nil, errors.New("reranker is nil")
+	}
+	result := new(RerankSearchResult)
+	queryNum := 0
+	for _, source := range sources {
+		if source.Result == nil {
+			continue
+		}
+		if source.Result.Warning != "" {
+			if result.Warning != "" {
+				result.Warning += "; "
+			}
+			result.Warning += source.Result.Warning
+		}
+		if len(source.Result.Documents) > queryNum {
+			queryNum = len(source.Result.Documents)
+		}
+	}
+
+	for i := 0; i < queryNum; i++ {
+		lists := make([]RankedList, 0, len(sources))
+		for _, source := range sources {
+			list := RankedList{
+				DatabaseName:   source.DatabaseName,
+				CollectionName: source.CollectionName,
+				MetricType:     source.MetricType,
+			}
+			if source.Result != nil && i < len(source.Result.Documents) {
+				list.Documents = source.Result.Documents[i]
+			}
+			lists = append(lists, list)
+		}
+		docs, err := reranker.Rerank(ctx, lists)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(docs) > limit {
+			docs = docs[:limit]
+		}
+		result.Documents = append(result.Documents, docs)
+	}
+	return result, nil
+}
+
+// [RRFReranker] fuses the retrievals by Reciprocal Rank Fusion, and the score of a document is
+// the sum of Weight/(K+rank) of each retrieval, where rank starts from 1.
+//
+// Fields:
+//   - K: (Optional) The smoothing constant of RRF (defaults to 60).
+//   - Weights: (Optional) The weight of each retrieval (defaults to 1).
+type RRFReranker struct {
+	K       int
+	Weights []float32
+}
+
+// [Rerank] reranks the documents of the retrievals by Reciprocal Rank Fusion.
+func (r *RRFReranker) Rerank(ctx context.Context, lists []RankedList) ([]RerankedDocument, error) {
+	if len(r.Weights) != 0 && len(r.Weights) != len(lists) {
+		return nil, fmt.Errorf("the number of weights %v is not equal to the number of retrievals %v",
+			len(r.Weights), len(lists))
+	}
+	k := r.K
+	if k <= 0 {
+		k = defaultRrfK
+	}
+	fusion := newRerankFusion()
+	for i, list := range lists {
+		weight := float64(1)
+		if len(r.Weights) != 0 {
+			weight = float64(r.Weights[i])
+		}
+		for rank, doc := range list.Documents {
+			fusion.add(list, doc, weight/float64(k+rank+1))
+		}
+	}
+	return fusion.sorted(), nil
+}
+
+// [WeightedReranker] fuses the retrievals by the weighted sum of the normalized scores.
+// A document missing in a retrieval gets 0 from it.
+//
+// Fields:
+//   - Weights: (Optional) The weight of each retrieval (defaults to 1).
+//   - Normalization: (Optional) The method to normalize the scores of each retrieval (defaults to NormalizeMinMax).
+//     See [ScoreNormalization] for more information.
+type WeightedReranker struct {
+	Weights       []float32
+	Normalization ScoreNormalization
+}
+
+// [Rerank] reranks the documents of the retrievals by the weighted sum of the normalized scores.
+func (r *WeightedReranker) Rerank(ctx context.Context, lists []RankedList) ([]RerankedDocument, error) {
+	if len(r.Weights) != 0 && len(r.Weights) != len(lists) {
+		return nil, fmt.Errorf("the number of weights %v is not equal to the number of retrievals %v",
+			len(r.Weights), len(lists))
+	}
+	fusion := newRerankFusion()
+	for i, list := range lists {
+		weight := float64(1)
+		if len(r.Weights) != 0 {
+			weight = float64(r.Weights[i])
+		}
+		scores, err := normalizeScores(list, r.Normalization)
+		if err != nil {
+			return nil, err
+		}
+		for j, doc := range list.Documents {
+			fusion.add(list, doc, weight*scores[j])
+		}
+	}
+	return fusion.sorted(), nil
+}
+
+// [TimeDecayReranker] boosts the recent documents by decaying the scores with the age of a timestamp field.
+// The score is multiplied by (1 - Weight + Weight*decay), where decay is 1 at Origin and Decay at
+// Offset+Scale from Origin.
+//
+// Fields:
+//   - Reranker: (Optional) The [Reranker] to get the scores before decaying (defaults to [RRFReranker]).
+//   - Field: (Required) The name of the timestamp field, whose value is a unix timestamp or an RFC3339 time string.
+//     It should be included in the OutputFields of the searches.
+//   - TimeUnit: (Optional) The unit of the unix timestamp (defaults to time.Second).
+//   - Origin: (Optional) The time when the decay is 1 (defaults to now).
+//   - Scale: (Required) The distance from Origin+Offset at which the decay equals Decay.
+//   - Offset: (Optional) The distance from Origin within which the documents are not decayed.
+//   - Decay: (Optional) The decay at Scale, ranging in (0, 1) (defaults to 0.5).
+//   - Function: (Optional) The shape of the decay curve (defaults to DecayExp). See [DecayFunction] for more information.
+//   - Weight: (Optional) The influence of the decay, ranging in [0, 1] (defaults to 1).
+//
+// Notes: The documents without a valid timestamp keep their scores.
+type TimeDecayReranker struct {
+	Reranker Reranker
+	Field    string
+	TimeUnit time.Duration
+	Origin   time.Time
+	Scale    time.Duration
+	Offset   time.Duration
+	Decay    float64
+	Function DecayFunction
+	Weight   *float32
+}
+
+// [Rerank] reranks the documents of the retrievals, and then decays their scores by the timestamp field.
+func (r *TimeDecayReranker) Rerank(ctx context.Context, lists []RankedList) ([]RerankedDocument, error) {
+	if r.Field == "" {
+		return nil, errors.New("time decay field is empty")
+	}
+	if r.Scale <= 0 {
+		return nil, errors.New("time decay scale must be greater than 0")
+	}
+	decay := r.Decay
+	if decay == 0 {
+		decay = defaultDecayValue
+	}
+	if decay <= 0 || decay >= 1 {
+		return nil, fmt.Errorf("time decay must be in (0, 1), got %v", decay)
+	}
+	weight := float64(1)
+	if r.Weight != nil {
+		weight = float64(*r.Weight)
+	}
+	origin := r.Origin
+	if origin.IsZero() {
+		origin = time.Now()
+	}
+	unit := r.TimeUnit
+	if unit <= 0 {
+		unit = time.Second
+	}
+
+	docs, err := rerankWithDefault(ctx, r.Reranker, lists)
+	if err != nil {
+		return nil, err
+	}
+	for i := range docs {
+		field, ok := docs[i].Fields[r.Field]
+		if !ok {
+			continue
+		}
+		t, ok := fieldTime(field, unit)
+		if !ok {
+			continue
+		}
+		distance := t.Sub(origin)
+		if distance < 0 {
+			distance = -distance
+		}
+		distance -= r.Offset
+		if distance < 0 {
+			distance = 0
+		}
+		factor := decayFactor(r.Function, float64(distance)/float64(r.Scale), decay)
+		docs[i].Score = float32(float64(docs[i].Score) * (1 - weight + weight*factor))
+	}
+	sortRerankedDocuments(docs)
+	return docs, nil
+}
+
+// [CrossEncoderFunc] scores the documents against the query, such as by calling a cross-encoder model.
+// It returns one score for each document, and a greater score means more relevant.
+type CrossEncoderFunc func(ctx context.Context, documents []RerankedDocument) ([]float32, error)
+
+// [CrossEncoderReranker] rescores the top candidates with a callback, such as a cross-encoder model.
+//
+// Fields:
+//   - Reranker: (Optional) The [Reranker] to select the candidates (defaults to [RRFReranker]).
+//   - Score: (Required) The [CrossEncoderFunc] to score the candidates.
+//   - Candidates: (Optional) The number of top candidates to rescore (defaults to all). The rest documents
+//     are placed after the rescored ones and keep their order.
+type CrossEncoderReranker struct {
+	Reranker   Reranker
+	Score      CrossEncoderFunc
+	Candidates int
+}
+
+// [Rerank] selects the candidates of the retrievals, and then reranks them by the scores of the callback.
+func (r *CrossEncoderReranker) Rerank(ctx context.Context, lists []RankedList) ([]RerankedDocument, error) {
+	if r.Score == nil {
+		return nil, errors.New("cross encoder score function is nil")
+	}
+	docs, err := rerankWithDefault(ctx, r.Reranker, lists)
+	if err != nil {
+		return nil, err
+	}
+	candidates := docs
+	if r.Candidates > 0 && len(docs) > r.Candidates {
+		candidates = docs[:r.Candidates]
+	}
+	if len(candidates) == 0 {
+		return docs, nil
+	}
+	scores, err := r.Score(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("cross encoder failed. err: %v", err)
+	}
+	if len(scores) != len(candidates) {
+		return nil, fmt.Errorf("the number of cross encoder scores %v is not equal to the number of candidates %v",
+			len(scores), len(candidates))
+	}
+	for i := range candidates {
+		candidates[i].Score = scores[i]
+	}
+	sortRerankedDocuments(candidates)
+	return docs, nil
+}
+
+// rerankFusion accumulates the scores of the same documents from different retrievals.
+type rerankFusion struct {
+	index map[string]int
+	docs  []RerankedDocument
+}
+
+func newRerankFusion() *rerankFusion {
+	return &rerankFusion{index: make(map[string]int)}
+}
+
+func (f *rerankFusion) add(list RankedList, doc Document, score float64) {
+	key := list.DatabaseName + "\x00" + list.CollectionName + "\x00" + doc.Id
+	i, ok := f.index[key]
+	if !ok {
+		doc.Score = float32(score)
+		f.index[key] = len(f.docs)
+		f.docs = append(f.docs, RerankedDocument{
+			Document:       copyDocument(doc),
+			DatabaseName:   list.DatabaseName,
+			CollectionName: list.CollectionName,
+		})
+		return
+	}
+	fused := &f.docs[i]
+	fused.Score += float32(score)
+	for name, field := range doc.Fields {
+		if _, exist := fused.Fields[name]; !exist {
+			fused.Fields[name] = field
+		}
+	}
+	if len(fused.Vector) == 0 {
+		fused.Vector = doc.Vector
+	}
+	if len(fused.SparseVector) == 0 {
+		fused.SparseVector = doc.SparseVector
+	}
+}
+
+func (f *rerankFusion) sorted() []RerankedDocument {
+	sortRerankedDocuments(f.docs)
+	return f.docs
+}
+
+// copyDocument copies the document with its own fields map, so that merging fields does not
+// modify the search results.
+func copyDocument(doc Document) Document {
+	fields := make(map[string]Field, len(doc.Fields))
+	for name, field := range doc.Fields {
+		fields[name] = field
+	}
+	doc.Fields = fields
+	return doc
+}
+
+func sortRerankedDocuments(docs []RerankedDocument) {
+	sort.SliceStable(docs, func(i, j int) bool {
+		return docs[i].Score > docs[j].Score
+	})
+}
+
+func rerankWithDefault(ctx context.Context, reranker Reranker, lists []RankedList) ([]RerankedDocument, error) {
+	if reranker == nil {
+		reranker = &RRFReranker{}
+	}
+	return reranker.Rerank(ctx, lists)
+}
+
+// normalizeScores returns the normalized scores of the documents, and a greater score means more similar.
+func normalizeScores(list RankedList, normalization ScoreNormalization) ([]float64, error) {
+	scores := make([]float64, len(list.Documents))
+	if len(scores) == 0 {
+		return scores, nil
+	}
+	distance := isDistanceMetric(list.MetricType)
+	switch normalization {
+	case NormalizeNone:
+		for i, doc := range list.Documents {
+			scores[i] = float64(doc.Score)
+			if distance {
+				scores[i] = -scores[i]
+			}
+		}
+	case NormalizeMetric:
+		for i, doc := range list.Documents {
+			scores[i] = normalizeMetricScore(doc.Score, list.MetricType)
+		}
+	case "", NormalizeMinMax:
+		minScore, maxScore := math.Inf(1), math.Inf(-1)
+		for _, doc := range list.Documents {
+			minScore = math.Min(minScore, float64(doc.Score))
+			maxScore = math.Max(maxScore, float64(doc.Score))
+		}
+		for i, doc := range list.Documents {
+			if maxScore == minScore {
+				scores[i] = 1
+				continue
+			}
+			scores[i] = (float64(doc.Score) - minScore) / (maxScore - minScore)
+			if distance {
+				scores[i] = 1 - scores[i]
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported score normalization: %v", normalization)
+	}
+	return scores, nil
+}
+
+// normalizeMetricScore converts the score into [0, 1] by the metric type. The distance d of L2 is converted
+// to 1/(1+d), the cosine similarity s is converted to (1+s)/2, and the others are converted by the sigmoid
+// function, because their ranges are unbounded.
+func normalizeMetricScore(score float32, metricType MetricType) float64 {
+	s := float64(score)
+	switch metricType {
+	case L2:
+		return 1 / (1 + math.Max(s, 0))
+	case COSINE:
+		return (1 + math.Max(math.Min(s, 1), -1)) / 2
+	case HAMMING:
+		return 1 / (1 + math.Max(s, 0))
+	default:
+		return 1 / (1 + math.Exp(-s))
+	}
+}
+
+func isDistanceMetric(metricType MetricType) bool {
+	return metricType == L2 || metricType == HAMMING
+}
+
+// fieldTime parses the field as a unix timestamp or an RFC3339 time string.
+func fieldTime(field Field, unit time.Duration) (time.Time, bool) {
+	if s, ok := field.Val.(string); ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, true
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return time.Time{}, false
+		}
+	}
+	switch field.Type() {
+	case Int64, Uint64, Double, String:
+		v := field.Float()
+		return time.Unix(0, 0).Add(time.Duration(v * float64(unit))), true
+	}
+	return time.Time{}, false
+}
+
+// decayFactor returns the decay at the distance, which is in units of Scale.
+func decayFactor(function DecayFunction, distance, decay float64) float64 {
+	switch function {
+	case DecayGauss:
+		return math.Exp(math.Log(decay) * distance * distance)
+	case DecayLinear:
+		return math.Max(1-(1-decay)*distance, 0)
+	default:
+		return math.Exp(math.Log(decay) * distance)
+	}
+}
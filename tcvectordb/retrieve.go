@@ -0,0 +1,329 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultRetrieveLimit     = 5
+	defaultRetrieveMaxTokens = 2000
+)
+
+// [RetrieveParams] holds the parameters for retrieving the context of a question from the chunks of texts
+// stored in a base collection, such as the chunks upserted by the splitter and loader packages.
+// Exactly one of Text and Vector should be set.
+//
+// Fields:
+//   - Text: The text to search by [SearchByText], which needs the collection to embed the text field.
+//   - Vector: The vector to search by [Search].
+//   - Filter: (Optional) Filter the chunks by [Filter] conditions when searching.
+//   - Params: (Optional) A pointer to a [SearchDocParams] object for searching.
+//   - Limit: (Optional) The number of the chunks to search (defaults to 5).
+//   - ExpandChunk: (Optional) The number of the preceding and the following chunks of each chunk found
+//     to add, like the ExpandChunk of [SearchAIDocumentSetsParams], such as []int{1, 2}.
+//   - MaxTokens: (Optional) The maximum tokens of the context (defaults to 2000). The passages beyond it are skipped.
+//   - TokenLength: (Optional) The function counting the tokens of a text (defaults to an estimate counting
+//     a token for each non-ASCII character and for each 4 ASCII characters).
+//   - TextField: (Optional) The field of the text of the chunks (defaults to "text").
+//   - DocIdField: (Optional) The field of the id of the text a chunk is from (defaults to "doc_id").
+//   - ChunkIndexField: (Optional) The field of the index of a chunk in its text (defaults to "chunk_index").
+//   - SourceField: (Optional) The field of the source cited (defaults to "source"), or the doc id if it is missing.
+type RetrieveParams struct {
+	Text            string
+	Vector          []float32
+	Filter          *Filter
+	Params          *SearchDocParams
+	Limit           int64
+	ExpandChunk     []int
+	MaxTokens       int
+	TokenLength     func(text string) int
+	TextField       string
+	DocIdField      string
+	ChunkIndexField string
+	SourceField     string
+}
+
+// [RetrievedPassage] holds a passage of consecutive chunks of a text in [RetrieveResult].
+//
+// Fields:
+//   - Citation: The number of the passage in the context, such as 1 for "[1]".
+//   - DocId: The id of the text of the chunks.
+//   - Source: The source cited.
+//   - StartChunk: The index of the first chunk.
+//   - EndChunk: The index of the last chunk.
+//   - Text: The text of the chunks, with their overlaps removed.
+//   - Score: The score of the best chunk found in the passage.
+//   - Documents: The chunks, ordered by their indexes.
+type RetrievedPassage struct {
+	Citation   int
+	DocId      string
+	Source     string
+	StartChunk uint64
+	EndChunk   uint64
+	Text       string
+	Score      float32
+	Documents  []Document
+}
+
+// [RetrieveResult] holds the results for retrieving the context.
+//
+// Fields:
+//   - Context: The passages joined as "[<citation>] <source>\n<text>", ready to be put into a prompt.
+//   - Passages: The passages in the context, ordered by their best chunks found.
+//   - Warning: The warning messages returned from the server.
+type RetrieveResult struct {
+	Context  string
+	Passages []RetrievedPassage
+	Warning  string
+}
+
+// retrieveWindow is the range [start, end] of the chunks of a text to fetch.
+type retrieveWindow struct {
+	docId      string
+	start, end uint64
+	rank       int
+	score      float32
+	hit        *Document
+}
+
+// [Retrieve] searches the chunks of a base collection, adds the neighboring chunks of the chunks found,
+// merges the overlapping chunks of the same texts into passages, and assembles the passages into a context
+// with citations within the token budget.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - param: A [RetrieveParams] object that includes the parameters for retrieving. See [RetrieveParams]
+//     for more information.
+//
+// Notes: The neighboring chunks are queried by the doc id and the chunk index, which should be the filter
+// indexes of the collection. The chunks without them are passages by themselves. The overlaps of the
+// consecutive chunks are removed by their start_pos and end_pos fields if they are present.
+//
+// Returns a pointer to a [RetrieveResult] object or an error.
+func Retrieve(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	param RetrieveParams) (*RetrieveResult, error) {
+	if (param.Text == "") == (len(param.Vector) == 0) {
+		return nil, errors.New("exactly one of Text and Vector should be set")
+	}
+	if param.Limit <= 0 {
+		param.Limit = defaultRetrieveLimit
+	}
+	if param.MaxTokens <= 0 {
+		param.MaxTokens = defaultRetrieveMaxTokens
+	}
+	if param.TokenLength == nil {
+		param.TokenLength = estimateTokens
+	}
+	if param.TextField == "" {
+		param.TextField = "text"
+	}
+	if param.DocIdField == "" {
+		param.DocIdField = "doc_id"
+	}
+	if param.ChunkIndexField == "" {
+		param.ChunkIndexField = "chunk_index"
+	}
+	if param.SourceField == "" {
+		param.SourceField = "source"
+	}
+	var before, after uint64
+	if len(param.ExpandChunk) > 0 && param.ExpandChunk[0] > 0 {
+		before = uint64(param.ExpandChunk[0])
+	}
+	if len(param.ExpandChunk) > 1 && param.ExpandChunk[1] > 0 {
+		after = uint64(param.ExpandChunk[1])
+	}
+
+	searchParams := &SearchDocumentParams{Filter: param.Filter, Params: param.Params, Limit: param.Limit}
+	var (
+		res *SearchDocumentResult
+		err error
+	)
+	if param.Text != "" {
+		res, err = cli.SearchByText(ctx, databaseName, collectionName, map[string][]string{param.TextField: {param.Text}}, searchParams)
+	} else {
+		res, err = cli.Search(ctx, databaseName, collectionName, [][]float32{param.Vector}, searchParams)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &RetrieveResult{Warning: res.Warning}
+	if len(res.Documents) == 0 {
+		return result, nil
+	}
+
+	// Merge the windows of the chunks found by the texts, keeping the rank of the best chunk of each.
+	var windows []*retrieveWindow
+	byDoc := make(map[string][]*retrieveWindow)
+	for rank := range res.Documents[0] {
+		hit := &res.Documents[0][rank]
+		docId, hasDoc := hit.Fields[param.DocIdField]
+		index, hasIndex := hit.Fields[param.ChunkIndexField]
+		if !hasDoc || !hasIndex || docId.String() == "" {
+			windows = append(windows, &retrieveWindow{rank: rank, score: hit.Score, hit: hit})
+			continue
+		}
+		w := &retrieveWindow{docId: docId.String(), end: index.Uint64() + after, rank: rank, score: hit.Score}
+		if index.Uint64() > before {
+			w.start = index.Uint64() - before
+		}
+		byDoc[w.docId] = append(byDoc[w.docId], w)
+	}
+	docIds := make([]string, 0, len(byDoc))
+	for docId := range byDoc {
+		docIds = append(docIds, docId)
+	}
+	sort.Strings(docIds)
+	var conds []string
+	var total int64
+	for _, docId := range docIds {
+		list := byDoc[docId]
+		sort.Slice(list, func(i, j int) bool { return list[i].start < list[j].start })
+		merged := []*retrieveWindow{list[0]}
+		for _, w := range list[1:] {
+			last := merged[len(merged)-1]
+			if w.start > last.end+1 {
+				merged = append(merged, w)
+				continue
+			}
+			if w.end > last.end {
+				last.end = w.end
+			}
+			if w.rank < last.rank {
+				last.rank, last.score = w.rank, w.score
+			}
+		}
+		for _, w := range merged {
+			conds = append(conds, fmt.Sprintf(`(%s = "%s" and %s >= %d and %s <= %d)`, param.DocIdField,
+				strings.ReplaceAll(w.docId, `"`, `\"`), param.ChunkIndexField, w.start, param.ChunkIndexField, w.end))
+			total += int64(w.end - w.start + 1)
+			windows = append(windows, w)
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].rank < windows[j].rank })
+
+	// Fetch the chunks of the windows.
+	chunks := make(map[string][]Document)
+	if len(conds) != 0 {
+		queried, err := cli.Query(ctx, databaseName, collectionName, nil, &QueryDocumentParams{
+			Filter: NewFilter(strings.Join(conds, " or ")),
+			Limit:  total,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query neighboring chunks failed. err: %v", err)
+		}
+		if queried.Warning != "" {
+			result.Warning = joinWarnings([]string{result.Warning, queried.Warning})
+		}
+		for _, doc := range queried.Documents {
+			docId := doc.Fields[param.DocIdField].String()
+			chunks[docId] = append(chunks[docId], doc)
+		}
+	}
+
+	// Assemble the passages within the token budget.
+	var b strings.Builder
+	tokens := 0
+	for _, w := range windows {
+		passage := RetrievedPassage{DocId: w.docId, StartChunk: w.start, EndChunk: w.end, Score: w.score}
+		if w.hit != nil {
+			passage.Documents = []Document{*w.hit}
+		} else {
+			for _, doc := range chunks[w.docId] {
+				index := doc.Fields[param.ChunkIndexField].Uint64()
+				if index >= w.start && index <= w.end {
+					passage.Documents = append(passage.Documents, doc)
+				}
+			}
+			sort.Slice(passage.Documents, func(i, j int) bool {
+				return passage.Documents[i].Fields[param.ChunkIndexField].Uint64() <
+					passage.Documents[j].Fields[param.ChunkIndexField].Uint64()
+			})
+			if len(passage.Documents) != 0 {
+				passage.StartChunk = passage.Documents[0].Fields[param.ChunkIndexField].Uint64()
+				passage.EndChunk = passage.Documents[len(passage.Documents)-1].Fields[param.ChunkIndexField].Uint64()
+			}
+		}
+		if len(passage.Documents) == 0 {
+			continue
+		}
+		passage.Text = joinChunks(passage.Documents, param.TextField)
+		passage.Source = passage.Documents[0].Fields[param.SourceField].String()
+		if _, ok := passage.Documents[0].Fields[param.SourceField]; !ok {
+			passage.Source = w.docId
+			if passage.Source == "" {
+				passage.Source = passage.Documents[0].Id
+			}
+		}
+		citation := fmt.Sprintf("[%d] %s\n%s", len(result.Passages)+1, passage.Source, passage.Text)
+		length := param.TokenLength(citation)
+		if tokens+length > param.MaxTokens {
+			continue
+		}
+		tokens += length
+		passage.Citation = len(result.Passages) + 1
+		if b.Len() != 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(citation)
+		result.Passages = append(result.Passages, passage)
+	}
+	result.Context = b.String()
+	return result, nil
+}
+
+// joinChunks joins the texts of the consecutive chunks, removing the overlap of each chunk with the
+// previous one by their offsets.
+func joinChunks(docs []Document, textField string) string {
+	var b strings.Builder
+	var lastEnd uint64
+	for i, doc := range docs {
+		text, _ := doc.Fields[textField].Val.(string)
+		start, hasStart := doc.Fields["start_pos"]
+		_, hasEnd := doc.Fields["end_pos"]
+		if i > 0 {
+			if hasStart && start.Uint64() < lastEnd {
+				if overlap := lastEnd - start.Uint64(); overlap < uint64(len(text)) {
+					text = text[overlap:]
+				} else {
+					text = ""
+				}
+			} else {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(text)
+		if hasEnd {
+			lastEnd = doc.Fields["end_pos"].Uint64()
+		} else {
+			lastEnd = 0
+		}
+	}
+	return b.String()
+}
@@ -2,6 +2,7 @@ package tcvectordb
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/olama"
 )
@@ -61,3 +62,75 @@ func (r *rpcImplementerAlias) DeleteAlias(ctx context.Context, aliasName string)
 	}
 	return &DeleteAliasResult{AffectedCount: int(res.AffectedCount)}, nil
 }
+
+// [ListAliases] retrieves all the aliases in the database and the collections they point to.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//
+// Returns a pointer to a [ListAliasesResult] object or an error.
+func (r *rpcImplementerAlias) ListAliases(ctx context.Context) (*ListAliasesResult, error) {
+	if r.database.IsAIDatabase() {
+		return nil, AIDbTypeError
+	}
+	req := &olama.GetAliasRequest{
+		Database: r.database.DatabaseName,
+	}
+	res, err := r.rpcClient.GetAlias(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := new(ListAliasesResult)
+	for _, item := range res.Aliases {
+		if item == nil {
+			continue
+		}
+		result.Aliases = append(result.Aliases, AliasItem{Alias: item.Alias, Collection: item.Collection})
+	}
+	return result, nil
+}
+
+// [GetAlias] retrieves the collection that the alias points to.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - aliasName: The alias name to retrieve.
+//
+// Returns a pointer to a [GetAliasResult] object or an error if the alias doesn't exist.
+func (r *rpcImplementerAlias) GetAlias(ctx context.Context, aliasName string) (*GetAliasResult, error) {
+	if r.database.IsAIDatabase() {
+		return nil, AIDbTypeError
+	}
+	req := &olama.GetAliasRequest{
+		Database: r.database.DatabaseName,
+		Alias:    aliasName,
+	}
+	res, err := r.rpcClient.GetAlias(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range res.Aliases {
+		if item != nil && item.Alias == aliasName {
+			return &GetAliasResult{AliasItem{Alias: item.Alias, Collection: item.Collection}}, nil
+		}
+	}
+	return nil, fmt.Errorf("alias %v does not exist in database %v", aliasName, r.database.DatabaseName)
+}
+
+// [SwapAlias] points the alias to the collection, and returns the collection it pointed to before.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - collectionName: The name of the collection that the alias will point to.
+//   - aliasName: The alias name to swap. It is created if it doesn't exist.
+//
+// Notes: It is not atomic. The previous collection is read by [ListAliases] before [SetAlias], so it may be
+// wrong if the alias is changed by others in between.
+//
+// Returns a pointer to a [SwapAliasResult] object or an error.
+func (r *rpcImplementerAlias) SwapAlias(ctx context.Context, collectionName, aliasName string) (*SwapAliasResult, error) {
+	return swapAlias(ctx, r, collectionName, aliasName)
+}
@@ -97,6 +97,9 @@ func (r *rpcImplementerCollection) CreateCollection(ctx context.Context, name st
 	if r.database.IsAIDatabase() {
 		return nil, AIDbTypeError
 	}
+	if err := indexes.Validate(); err != nil {
+		return nil, err
+	}
 	req := &olama.CreateCollectionRequest{
 		Database:    r.database.DatabaseName,
 		Collection:  name,
@@ -400,12 +403,14 @@ func (r *rpcImplementerCollection) toCollection(collectionItem *olama.CreateColl
 				switch vector.IndexType {
 				case HNSW:
 					vector.Params = &HNSWParam{M: index.Params.M, EfConstruction: index.Params.EfConstruction}
+				case BIN_HNSW:
+					vector.Params = &BINHNSWParams{M: index.Params.M, EfConstruction: index.Params.EfConstruction}
 				case IVF_FLAT:
 					vector.Params = &IVFFLATParams{NList: index.Params.Nlist}
 				case IVF_PQ:
 					vector.Params = &IVFPQParams{M: index.Params.M, NList: index.Params.Nlist}
 				case IVF_SQ4, IVF_SQ8, IVF_SQ16:
-					vector.Params = &IVFSQParams{NList: index.Params.Nlist}
+					vector.Params = &IVFSQParams{NList: index.Params.Nlist, IndexType: vector.IndexType}
 				case IVF_RABITQ:
 					vector.Params = &IVFRabitQParams{NList: index.Params.Nlist, Bits: index.Params.Bits}
 				}
@@ -477,6 +482,11 @@ func optionRpcParamsFromIndexParams(column *olama.IndexColumn, v IndexParams) {
 			column.Params.M = param.M
 			column.Params.EfConstruction = param.EfConstruction
 		}
+	case *BINHNSWParams:
+		if param != nil {
+			column.Params.M = param.M
+			column.Params.EfConstruction = param.EfConstruction
+		}
 	case *IVFFLATParams:
 		if param != nil {
 			column.Params.Nlist = param.NList
@@ -495,6 +505,7 @@ func optionRpcParamsFromIndexParams(column *olama.IndexColumn, v IndexParams) {
 			column.Params.Nlist = param.NList
 			column.Params.Bits = param.Bits
 		}
+	case *FLATParams, *BINFLATParams, *DiskFLATParams:
 	default:
 		log.Printf("[Warning] unknown type: %v", reflect.TypeOf(v))
 	}
@@ -508,6 +519,11 @@ func optionRpcParams(column *olama.IndexColumn, v VectorIndex) {
 			column.Params.M = param.M
 			column.Params.EfConstruction = param.EfConstruction
 		}
+	case BIN_HNSW:
+		if param, ok := v.Params.(*BINHNSWParams); ok && param != nil {
+			column.Params.M = param.M
+			column.Params.EfConstruction = param.EfConstruction
+		}
 	case IVF_FLAT:
 		if param, ok := v.Params.(*IVFFLATParams); ok && param != nil {
 			column.Params.Nlist = param.NList
@@ -61,6 +61,11 @@ func (r *rpcImplementerFlatIndex) RebuildIndex(ctx context.Context, databaseName
 //
 // Returns an error if the addition fails.
 func (r *rpcImplementerFlatIndex) AddIndex(ctx context.Context, databaseName, collectionName string, params ...*AddIndexParams) error {
+	if len(params) != 0 {
+		if err := params[0].Validate(); err != nil {
+			return err
+		}
+	}
 	req := &olama.AddIndexRequest{
 		Database:   databaseName,
 		Collection: collectionName,
@@ -107,6 +112,11 @@ func (r *rpcImplementerFlatIndex) DropIndex(ctx context.Context, databaseName, c
 // [ModifyVectorIndex] modifies vector indexes to an existing collection.
 func (r *rpcImplementerFlatIndex) ModifyVectorIndex(ctx context.Context, databaseName, collectionName string,
 	param ModifyVectorIndexParam) error {
+	for idx := range param.VectorIndexes {
+		if err := param.VectorIndexes[idx].Validate(); err != nil {
+			return err
+		}
+	}
 	req := &olama.ModifyVectorIndexRequest{
 		Database:      databaseName,
 		Collection:    collectionName,
@@ -114,8 +114,9 @@ func NewRpcClient(url, username, key string, option *ClientOption) (*RpcClient,
 		SdkClient: cli,
 		rpcClient: cli.rpcClient,
 	}
-	cli.DatabaseInterface = databaseImpl
-	cli.FlatInterface = flatImpl
+	cli.FlatInterface = withSearchCache(withClientEmbedding(withAutoSplit(flatImpl, cli.option.AutoSplit),
+		cli.option.ClientEmbedding), cli.option.SearchCache)
+	cli.DatabaseInterface = withSearchCacheDatabase(databaseImpl, cli.FlatInterface)
 	cli.FlatIndexInterface = flatIndexImpl
 
 	return cli, nil
@@ -129,6 +130,12 @@ func (r *RpcClient) Options() ClientOption {
 	return r.option
 }
 
+// DescribeCollection retrieves information about a specific collection of the database.
+// See [Collection] for more information.
+func (r *RpcClient) DescribeCollection(ctx context.Context, databaseName, collectionName string) (*DescribeCollectionResult, error) {
+	return r.Database(databaseName).DescribeCollection(ctx, collectionName)
+}
+
 func (r *RpcClient) WithTimeout(d time.Duration) {
 	r.httpImplementer.WithTimeout(d)
 	r.option.Timeout = d
@@ -0,0 +1,476 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/index"
+)
+
+// [SchemaChangeAction] defines the actions of a schema change step.
+type SchemaChangeAction string
+
+const (
+	SchemaAddFilterIndex     SchemaChangeAction = "add_filter_index"
+	SchemaDropFilterIndex    SchemaChangeAction = "drop_filter_index"
+	SchemaModifyVectorIndex  SchemaChangeAction = "modify_vector_index"
+	SchemaRecreateCollection SchemaChangeAction = "recreate_collection"
+)
+
+// [SchemaChangeStep] holds a step of a [SchemaChangePlan].
+//
+// Fields:
+//   - Action: The action of the step. See [SchemaChangeAction] for more information.
+//   - FieldName: The name of the field changed by the step.
+//   - Description: The readable description of the change, such as "IVF_FLAT{nlist:1024} -> HNSW{M:16,efConstruction:200}".
+//   - NeedRebuild: Whether the step rebuilds the index over all the documents of the collection.
+//   - NeedRecreate: Whether the change can not be applied in place, and the collection has to be recreated.
+//   - FilterIndex: The filter index to add, which is set for SchemaAddFilterIndex.
+//   - VectorIndex: The vector index to modify, which is set for SchemaModifyVectorIndex.
+type SchemaChangeStep struct {
+	Action       SchemaChangeAction
+	FieldName    string
+	Description  string
+	NeedRebuild  bool
+	NeedRecreate bool
+	FilterIndex  *FilterIndex       `json:",omitempty"`
+	VectorIndex  *ModifyVectorIndex `json:",omitempty"`
+}
+
+// [SchemaChangeClient] is the client to apply a [SchemaChangePlan] with, implemented by [Client]
+// and [RpcClient].
+type SchemaChangeClient interface {
+	FlatIndexInterface
+	CollectionDescriber
+}
+
+var (
+	_ SchemaChangeClient = &Client{}
+	_ SchemaChangeClient = &RpcClient{}
+)
+
+// [SchemaChangePlan] holds the ordered steps to change the indexes of a collection into the desired ones.
+//
+// Fields:
+//   - DatabaseName: The name of the database.
+//   - CollectionName: The name of the collection.
+//   - Steps: The ordered steps of the plan. The filter indexes are dropped before they are added, and
+//     the vector indexes are modified at last.
+//   - NeedRebuild: Whether any step rebuilds the index over all the documents.
+//   - NeedRecreate: Whether any change can not be applied in place. Such a plan can not be applied, and the
+//     collection has to be recreated, such as by [Reindex].
+type SchemaChangePlan struct {
+	DatabaseName   string
+	CollectionName string
+	Steps          []SchemaChangeStep
+	NeedRebuild    bool
+	NeedRecreate   bool
+}
+
+// [PlanSchemaChangeParams] holds the parameters for planning a schema change.
+//
+// Fields:
+//   - KeepUnlistedFilterIndexes: (Optional) Whether to keep the filter indexes which are not in the desired
+//     indexes (defaults to false, which means dropping them). The primary key is never dropped.
+type PlanSchemaChangeParams struct {
+	KeepUnlistedFilterIndexes bool
+}
+
+// [ApplySchemaChangeParams] holds the parameters for applying a schema change plan.
+//
+// Fields:
+//   - BuildExistedData: (Optional) Whether to build the added filter indexes over the existing documents (defaults to true).
+//   - RebuildRules: (Optional) A pointer to [RebuildRules] object that specifies the rules for rebuilding
+//     the modified vector indexes.
+//   - Wait: (Optional) A pointer to a [WaitIndexOptions] object that includes the options for waiting for
+//     the index to be ready between steps. Its PreviousStartTime is set to the start time described before
+//     each step is submitted.
+//     See [WaitIndexOptions] for more information.
+type ApplySchemaChangeParams struct {
+	BuildExistedData *bool
+	RebuildRules     *index.RebuildRules
+	Wait             *WaitIndexOptions
+}
+
+// [PlanSchemaChange] compares the desired indexes with the indexes of the collection returned from
+// [DescribeCollection], and returns the ordered plan to change the indexes.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to describe the collection with. See [CollectionDescriber] for more information.
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - desired: The desired [Indexes] of the collection.
+//   - params: A pointer to a [PlanSchemaChangeParams] object that includes the other parameters for planning.
+//     See [PlanSchemaChangeParams] for more information.
+//
+// Notes: The empty MetricType and the nil Params of the desired vector indexes mean keeping the current ones.
+//
+// Returns a pointer to a [SchemaChangePlan] object or an error.
+func PlanSchemaChange(ctx context.Context, cli CollectionDescriber, databaseName, collectionName string,
+	desired Indexes, params ...*PlanSchemaChangeParams) (*SchemaChangePlan, error) {
+	param := new(PlanSchemaChangeParams)
+	if len(params) != 0 && params[0] != nil {
+		param = params[0]
+	}
+	coll, err := cli.DescribeCollection(ctx, databaseName, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	plan := DiffIndexes(coll.Indexes, desired, param)
+	plan.DatabaseName = databaseName
+	plan.CollectionName = collectionName
+	return plan, nil
+}
+
+// [DiffIndexes] compares the desired indexes with the current indexes, and returns the ordered plan
+// to change the indexes. See [PlanSchemaChange] for more information.
+func DiffIndexes(current, desired Indexes, param *PlanSchemaChangeParams) *SchemaChangePlan {
+	if param == nil {
+		param = new(PlanSchemaChangeParams)
+	}
+	plan := new(SchemaChangePlan)
+	var drops, adds, modifies, recreates []SchemaChangeStep
+
+	currentFilters := make(map[string]FilterIndex, len(current.FilterIndex))
+	for _, filter := range current.FilterIndex {
+		currentFilters[filter.FieldName] = filter
+	}
+	desiredFilters := make(map[string]bool, len(desired.FilterIndex))
+	for _, filter := range desired.FilterIndex {
+		filter := filter
+		desiredFilters[filter.FieldName] = true
+		old, ok := currentFilters[filter.FieldName]
+		if ok && filterIndexEqual(old, filter) {
+			continue
+		}
+		if filter.IsPrimaryKey() || (ok && old.IsPrimaryKey()) {
+			recreates = append(recreates, SchemaChangeStep{
+				Action:       SchemaRecreateCollection,
+				FieldName:    filter.FieldName,
+				Description:  fmt.Sprintf("primary key %v -> %v", describeFilterIndex(old, ok), describeFilterIndex(filter, true)),
+				NeedRecreate: true,
+			})
+			continue
+		}
+		if ok {
+			drops = append(drops, SchemaChangeStep{
+				Action:      SchemaDropFilterIndex,
+				FieldName:   filter.FieldName,
+				Description: fmt.Sprintf("%v -> %v", describeFilterIndex(old, true), describeFilterIndex(filter, true)),
+			})
+		}
+		adds = append(adds, SchemaChangeStep{
+			Action:      SchemaAddFilterIndex,
+			FieldName:   filter.FieldName,
+			Description: describeFilterIndex(filter, true),
+			FilterIndex: &filter,
+		})
+	}
+	if !param.KeepUnlistedFilterIndexes {
+		for _, filter := range current.FilterIndex {
+			if desiredFilters[filter.FieldName] || filter.IsPrimaryKey() {
+				continue
+			}
+			drops = append(drops, SchemaChangeStep{
+				Action:      SchemaDropFilterIndex,
+				FieldName:   filter.FieldName,
+				Description: describeFilterIndex(filter, true),
+			})
+		}
+	}
+
+	currentVectors := make(map[string]VectorIndex, len(current.VectorIndex))
+	for _, vector := range current.VectorIndex {
+		currentVectors[vector.FieldName] = vector
+	}
+	desiredVectors := make(map[string]bool, len(desired.VectorIndex))
+	for _, vector := range desired.VectorIndex {
+		desiredVectors[vector.FieldName] = true
+		old, ok := currentVectors[vector.FieldName]
+		if ok && vector.FieldType == "" {
+			vector.FieldType = old.FieldType
+		}
+		switch {
+		case !ok:
+			recreates = append(recreates, SchemaChangeStep{
+				Action:       SchemaRecreateCollection,
+				FieldName:    vector.FieldName,
+				Description:  "add vector index " + describeVectorIndex(vector),
+				NeedRecreate: true,
+			})
+		case old.FieldType != vector.FieldType || old.Dimension != vector.Dimension:
+			recreates = append(recreates, SchemaChangeStep{
+				Action:       SchemaRecreateCollection,
+				FieldName:    vector.FieldName,
+				Description:  describeVectorIndex(old) + " -> " + describeVectorIndex(vector),
+				NeedRecreate: true,
+			})
+		default:
+			modified := vector
+			if modified.MetricType == "" {
+				modified.MetricType = old.MetricType
+			}
+			if modified.Params == nil && modified.IndexType == old.IndexType {
+				modified.Params = old.Params
+			}
+			if modified.IndexType == old.IndexType && modified.MetricType == old.MetricType &&
+				indexParamsEqual(old.Params, modified.Params) {
+				continue
+			}
+			modifies = append(modifies, SchemaChangeStep{
+				Action:      SchemaModifyVectorIndex,
+				FieldName:   vector.FieldName,
+				Description: describeVectorIndex(old) + " -> " + describeVectorIndex(modified),
+				NeedRebuild: true,
+				VectorIndex: &ModifyVectorIndex{
+					FieldName:  modified.FieldName,
+					FieldType:  string(modified.FieldType),
+					IndexType:  string(modified.IndexType),
+					MetricType: modified.MetricType,
+					Params:     modified.Params,
+				},
+			})
+		}
+	}
+	for _, vector := range current.VectorIndex {
+		if !desiredVectors[vector.FieldName] {
+			recreates = append(recreates, SchemaChangeStep{
+				Action:       SchemaRecreateCollection,
+				FieldName:    vector.FieldName,
+				Description:  "drop vector index " + describeVectorIndex(vector),
+				NeedRecreate: true,
+			})
+		}
+	}
+
+	currentSparse := make(map[string]SparseVectorIndex, len(current.SparseVectorIndex))
+	for _, sparse := range current.SparseVectorIndex {
+		currentSparse[sparse.FieldName] = sparse
+	}
+	desiredSparse := make(map[string]bool, len(desired.SparseVectorIndex))
+	for _, sparse := range desired.SparseVectorIndex {
+		desiredSparse[sparse.FieldName] = true
+		old, ok := currentSparse[sparse.FieldName]
+		if !ok {
+			recreates = append(recreates, SchemaChangeStep{
+				Action:       SchemaRecreateCollection,
+				FieldName:    sparse.FieldName,
+				Description:  "add sparse vector index " + describeSparseVectorIndex(sparse),
+				NeedRecreate: true,
+			})
+			continue
+		}
+		modified := sparse
+		if modified.MetricType == "" {
+			modified.MetricType = old.MetricType
+		}
+		if modified.IndexType == "" {
+			modified.IndexType = old.IndexType
+		}
+		if modified.DiskSwapEnabled == nil {
+			modified.DiskSwapEnabled = old.DiskSwapEnabled
+		}
+		if modified.IndexType == old.IndexType && modified.MetricType == old.MetricType &&
+			boolPtrValue(modified.DiskSwapEnabled) == boolPtrValue(old.DiskSwapEnabled) {
+			continue
+		}
+		modifies = append(modifies, SchemaChangeStep{
+			Action:      SchemaModifyVectorIndex,
+			FieldName:   sparse.FieldName,
+			Description: describeSparseVectorIndex(old) + " -> " + describeSparseVectorIndex(modified),
+			NeedRebuild: true,
+			VectorIndex: &ModifyVectorIndex{
+				FieldName:       modified.FieldName,
+				FieldType:       string(SparseVector),
+				IndexType:       string(modified.IndexType),
+				MetricType:      modified.MetricType,
+				DiskSwapEnabled: modified.DiskSwapEnabled,
+			},
+		})
+	}
+	for _, sparse := range current.SparseVectorIndex {
+		if !desiredSparse[sparse.FieldName] {
+			recreates = append(recreates, SchemaChangeStep{
+				Action:       SchemaRecreateCollection,
+				FieldName:    sparse.FieldName,
+				Description:  "drop sparse vector index " + describeSparseVectorIndex(sparse),
+				NeedRecreate: true,
+			})
+		}
+	}
+
+	plan.Steps = append(plan.Steps, recreates...)
+	plan.Steps = append(plan.Steps, drops...)
+	plan.Steps = append(plan.Steps, adds...)
+	plan.Steps = append(plan.Steps, modifies...)
+	for _, step := range plan.Steps {
+		plan.NeedRebuild = plan.NeedRebuild || step.NeedRebuild
+		plan.NeedRecreate = plan.NeedRecreate || step.NeedRecreate
+	}
+	return plan
+}
+
+// [Empty] returns true if the indexes of the collection are already the desired ones.
+func (p *SchemaChangePlan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+// [String] returns the reviewable diff of the plan. The lines are prefixed by "+" for adding,
+// "-" for dropping, "~" for modifying and "!" for the changes which need recreating the collection.
+func (p *SchemaChangePlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "collection %v.%v:", p.DatabaseName, p.CollectionName)
+	if p.Empty() {
+		b.WriteString(" no changes")
+		return b.String()
+	}
+	for _, step := range p.Steps {
+		prefix := "~"
+		switch step.Action {
+		case SchemaAddFilterIndex:
+			prefix = "+"
+		case SchemaDropFilterIndex:
+			prefix = "-"
+		case SchemaRecreateCollection:
+			prefix = "!"
+		}
+		fmt.Fprintf(&b, "\n  %v %v %v: %v", prefix, step.Action, step.FieldName, step.Description)
+		if step.NeedRebuild {
+			b.WriteString(" (rebuild)")
+		}
+	}
+	return b.String()
+}
+
+// [Apply] executes the steps of the plan in order, and waits for the index to be ready after the steps
+// that build indexes.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to change the indexes with, such as [Client] or [RpcClient]. See [SchemaChangeClient] for more information.
+//   - params: A pointer to a [ApplySchemaChangeParams] object that includes the other parameters for applying.
+//     See [ApplySchemaChangeParams] for more information.
+//
+// Notes: It returns an error without changing anything if the plan needs recreating the collection.
+//
+// Returns an error if any step fails.
+func (p *SchemaChangePlan) Apply(ctx context.Context, cli SchemaChangeClient, params ...*ApplySchemaChangeParams) error {
+	if p.NeedRecreate {
+		return errors.New("the schema change needs recreating the collection, which can not be applied in place")
+	}
+	param := new(ApplySchemaChangeParams)
+	if len(params) != 0 && params[0] != nil {
+		param = params[0]
+	}
+	for _, step := range p.Steps {
+		build := step.Action == SchemaModifyVectorIndex ||
+			step.Action == SchemaAddFilterIndex && (param.BuildExistedData == nil || *param.BuildExistedData)
+		var previous time.Time
+		if build {
+			coll, err := cli.DescribeCollection(ctx, p.DatabaseName, p.CollectionName)
+			if err != nil {
+				return fmt.Errorf("%v %v failed. err: %v", step.Action, step.FieldName, err)
+			}
+			previous = coll.IndexStatus.StartTime
+		}
+		var err error
+		switch step.Action {
+		case SchemaDropFilterIndex:
+			err = cli.DropIndex(ctx, p.DatabaseName, p.CollectionName, DropIndexParams{FieldNames: []string{step.FieldName}})
+		case SchemaAddFilterIndex:
+			err = cli.AddIndex(ctx, p.DatabaseName, p.CollectionName, &AddIndexParams{
+				FilterIndexs:     []FilterIndex{*step.FilterIndex},
+				BuildExistedData: param.BuildExistedData,
+			})
+		case SchemaModifyVectorIndex:
+			err = cli.ModifyVectorIndex(ctx, p.DatabaseName, p.CollectionName, ModifyVectorIndexParam{
+				VectorIndexes: []ModifyVectorIndex{*step.VectorIndex},
+				RebuildRules:  param.RebuildRules,
+			})
+		default:
+			err = fmt.Errorf("unsupported schema change action: %v", step.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("%v %v failed. err: %v", step.Action, step.FieldName, err)
+		}
+		if build {
+			wait := WaitIndexOptions{}
+			if param.Wait != nil {
+				wait = *param.Wait
+			}
+			wait.PreviousStartTime = &previous
+			if err = WaitForIndexReady(ctx, cli, p.DatabaseName, p.CollectionName, &wait); err != nil {
+				return fmt.Errorf("%v %v failed. err: %v", step.Action, step.FieldName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func filterIndexEqual(a, b FilterIndex) bool {
+	return a.FieldType == b.FieldType && a.IndexType == b.IndexType && a.ElemType == b.ElemType
+}
+
+func indexParamsEqual(a, b IndexParams) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	aJson, aErr := a.MarshalJson()
+	bJson, bErr := b.MarshalJson()
+	return aErr == nil && bErr == nil && bytes.Equal(aJson, bJson)
+}
+
+func describeFilterIndex(filter FilterIndex, exist bool) string {
+	if !exist {
+		return "none"
+	}
+	s := fmt.Sprintf("%v %v", filter.FieldType, filter.IndexType)
+	if filter.ElemType != "" {
+		s += fmt.Sprintf(" of %v", filter.ElemType)
+	}
+	return s
+}
+
+func describeVectorIndex(vector VectorIndex) string {
+	s := fmt.Sprintf("%v(%v) %v %v", vector.FieldType, vector.Dimension, vector.IndexType, vector.MetricType)
+	if vector.Params != nil {
+		if params, err := vector.Params.MarshalJson(); err == nil {
+			s += " " + string(params)
+		}
+	}
+	return s
+}
+
+func describeSparseVectorIndex(sparse SparseVectorIndex) string {
+	return fmt.Sprintf("%v %v %v diskSwap=%v", sparse.FieldType, sparse.IndexType, sparse.MetricType,
+		boolPtrValue(sparse.DiskSwapEnabled))
+}
+
+func boolPtrValue(b *bool) bool {
+	return b != nil && *b
+}
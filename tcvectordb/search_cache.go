@@ -0,0 +1,583 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultSearchCacheTTL           = time.Minute
+	defaultSearchCacheMaxBytes      = 64 << 20
+	defaultSearchCacheMaxEntryBytes = 1 << 20
+)
+
+// [SearchCacheBackend] stores the cached results of the flat read methods. The values are the json
+// encoded results with the go types of their field values, so a backend shared by several clients,
+// such as redis, can be plugged in.
+//
+// Notes: Errors of the backend are ignored, and the request goes to the server as a cache miss.
+type SearchCacheBackend interface {
+	// Get returns the value of the key, and whether it is found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value of the key, which expires after ttl. A zero ttl never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// [SearchCacheOption] holds the parameters of the read-through cache in front of the Search, SearchById,
+// SearchByText, HybridSearch, FullTextSearch and Query methods of the client.
+//
+// Fields:
+//   - Backend: (Optional) The backend to store the results (defaults to a [MemorySearchCache] of MaxBytes).
+//   - MaxBytes: (Optional) The maximum size of the default memory backend (defaults to 64MB).
+//   - MaxEntryBytes: (Optional) The maximum size of a result to cache, and the bigger ones are not
+//     cached (defaults to 1MB).
+//   - TTL: (Optional) The time to keep a result (defaults to 1 minute).
+//   - KeyPrefix: (Optional) The prefix of the keys, which separates the instances sharing a backend.
+//
+// Notes: The cached results of a collection are invalidated when the client upserts, updates or deletes
+// documents in the collection with the flat methods. The writes of the other clients sharing the backend
+// invalidate them too. Writes by other means, such as the methods of [Collection], truncating the
+// collection or the expiration of TTL documents, are only seen after the TTL. The aliases set, swapped or
+// deleted with the [Database] of the client are resolved, so the writes through a collection and its
+// aliases invalidate each other's results, and the results read through an alias are invalidated when it
+// is moved. The aliases changed by other means are not known, and the results read through them are only
+// invalidated by the writes through the same name until the TTL.
+// The field values of the cached results have the same go types as the ones returned from the server,
+// such as the uint64 and int64 of the [RpcClient].
+type SearchCacheOption struct {
+	Backend       SearchCacheBackend
+	MaxBytes      int64
+	MaxEntryBytes int
+	TTL           time.Duration
+	KeyPrefix     string
+}
+
+var _ FlatInterface = &searchCacheFlatDocument{}
+
+// searchCacheFlatDocument wraps the flat document methods to cache the results of reads. Each collection
+// has a generation in the backend, which is a part of the keys of its results, and is renewed by writes.
+type searchCacheFlatDocument struct {
+	FlatInterface
+	option SearchCacheOption
+
+	mu sync.RWMutex
+	// aliases holds the collections of the aliases set by the client, keyed by the database and the alias.
+	aliases map[string]string
+}
+
+func withSearchCache(flat FlatInterface, option *SearchCacheOption) FlatInterface {
+	if option == nil {
+		return flat
+	}
+	opt := *option
+	if opt.MaxBytes <= 0 {
+		opt.MaxBytes = defaultSearchCacheMaxBytes
+	}
+	if opt.MaxEntryBytes <= 0 {
+		opt.MaxEntryBytes = defaultSearchCacheMaxEntryBytes
+	}
+	if opt.TTL <= 0 {
+		opt.TTL = defaultSearchCacheTTL
+	}
+	if opt.Backend == nil {
+		opt.Backend = NewMemorySearchCache(opt.MaxBytes)
+	}
+	return &searchCacheFlatDocument{FlatInterface: flat, option: opt, aliases: make(map[string]string)}
+}
+
+// searchCacheDatabase wraps the databases of the client, so that the search cache learns the aliases
+// set, swapped and deleted with them.
+type searchCacheDatabase struct {
+	DatabaseInterface
+	cache *searchCacheFlatDocument
+}
+
+func withSearchCacheDatabase(database DatabaseInterface, flat FlatInterface) DatabaseInterface {
+	cache, ok := flat.(*searchCacheFlatDocument)
+	if !ok {
+		return database
+	}
+	return &searchCacheDatabase{DatabaseInterface: database, cache: cache}
+}
+
+// [Database] returns a pointer to a [Database] object, whose alias changes are seen by the search cache.
+func (s *searchCacheDatabase) Database(name string) *Database {
+	database := s.DatabaseInterface.Database(name)
+	database.AliasInterface = &searchCacheAlias{AliasInterface: database.AliasInterface, cache: s.cache, databaseName: name}
+	return database
+}
+
+// searchCacheAlias records the aliases changed by the client in the search cache.
+type searchCacheAlias struct {
+	AliasInterface
+	cache        *searchCacheFlatDocument
+	databaseName string
+}
+
+// [SetAlias] sets an alias for the collection, and points the cached results of the alias to the collection.
+func (s *searchCacheAlias) SetAlias(ctx context.Context, collectionName, aliasName string) (*SetAliasResult, error) {
+	result, err := s.AliasInterface.SetAlias(ctx, collectionName, aliasName)
+	if err == nil {
+		s.cache.setAlias(ctx, s.databaseName, aliasName, collectionName)
+	}
+	return result, err
+}
+
+// [SwapAlias] points the alias to the collection, and points the cached results of the alias to the collection.
+func (s *searchCacheAlias) SwapAlias(ctx context.Context, collectionName, aliasName string) (*SwapAliasResult, error) {
+	result, err := s.AliasInterface.SwapAlias(ctx, collectionName, aliasName)
+	if err == nil {
+		s.cache.setAlias(ctx, s.databaseName, aliasName, collectionName)
+	}
+	return result, err
+}
+
+// [DeleteAlias] deletes the alias, and invalidates the cached results of the alias.
+func (s *searchCacheAlias) DeleteAlias(ctx context.Context, aliasName string) (*DeleteAliasResult, error) {
+	result, err := s.AliasInterface.DeleteAlias(ctx, aliasName)
+	if err == nil {
+		s.cache.setAlias(ctx, s.databaseName, aliasName, "")
+	}
+	return result, err
+}
+
+var searchCacheGeneration uint64
+
+// generationKey returns the key of the generation of the collection. Only the aliases set by the client are
+// resolved, as resolving the others would cost a request per read, so they have their own generations.
+func (s *searchCacheFlatDocument) generationKey(databaseName, collectionName string) string {
+	s.mu.RLock()
+	if target, ok := s.aliases[databaseName+"/"+collectionName]; ok {
+		collectionName = target
+	}
+	s.mu.RUnlock()
+	return s.option.KeyPrefix + "generation/" + databaseName + "/" + collectionName
+}
+
+// setAlias records the collection of the alias, or deletes the alias if collectionName is empty. The own
+// generation of the alias is renewed too, which invalidates the results cached through the alias by the
+// clients sharing the backend that don't know the alias was moved.
+func (s *searchCacheFlatDocument) setAlias(ctx context.Context, databaseName, aliasName, collectionName string) {
+	s.mu.Lock()
+	delete(s.aliases, databaseName+"/"+aliasName)
+	s.mu.Unlock()
+	s.renew(ctx, databaseName, aliasName)
+	if collectionName != "" {
+		s.mu.Lock()
+		s.aliases[databaseName+"/"+aliasName] = collectionName
+		s.mu.Unlock()
+	}
+}
+
+// renew replaces the generation of the collection, which invalidates its cached results.
+func (s *searchCacheFlatDocument) renew(ctx context.Context, databaseName, collectionName string) string {
+	generation := strconv.FormatInt(time.Now().UnixNano(), 36) + "." +
+		strconv.FormatUint(atomic.AddUint64(&searchCacheGeneration, 1), 36)
+	s.option.Backend.Set(ctx, s.generationKey(databaseName, collectionName), []byte(generation), 0)
+	return generation
+}
+
+// key returns the key of a read, which is the hash of the canonical json encoding of its arguments.
+// A missing generation is renewed, because the results cached before it was evicted may be stale.
+func (s *searchCacheFlatDocument) key(ctx context.Context, databaseName, collectionName string, args ...interface{}) (string, bool) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	generation, ok, err := s.option.Backend.Get(ctx, s.generationKey(databaseName, collectionName))
+	if err != nil {
+		return "", false
+	}
+	if !ok {
+		generation = []byte(s.renew(ctx, databaseName, collectionName))
+	}
+	sum := sha256.Sum256(data)
+	return s.option.KeyPrefix + databaseName + "/" + collectionName + "/" + string(generation) + "/" +
+		hex.EncodeToString(sum[:]), true
+}
+
+// searchCacheEntry is a cached result. Json loses the go types of some field values, such as the uint64
+// of the [RpcClient], so their types are kept by the index of the document in the result.
+type searchCacheEntry struct {
+	Result json.RawMessage           `json:"result"`
+	Types  map[int]map[string]string `json:"types,omitempty"`
+}
+
+// cached returns the result of the key from the backend, or calls fn and caches its result.
+func (s *searchCacheFlatDocument) cached(ctx context.Context, key string, ok bool, result interface{}, fn func() error) error {
+	if ok {
+		if data, found, err := s.option.Backend.Get(ctx, key); err == nil && found {
+			entry := new(searchCacheEntry)
+			if json.Unmarshal(data, entry) == nil && len(entry.Result) != 0 {
+				decoder := json.NewDecoder(bytes.NewReader(entry.Result))
+				decoder.UseNumber()
+				if decoder.Decode(result) == nil {
+					restoreFieldTypes(searchCacheDocuments(result), entry.Types)
+					return nil
+				}
+			}
+		}
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	if ok {
+		entry := searchCacheEntry{Types: fieldTypes(searchCacheDocuments(result))}
+		var err error
+		if entry.Result, err = json.Marshal(result); err != nil {
+			return nil
+		}
+		if data, err := json.Marshal(entry); err == nil && len(data) <= s.option.MaxEntryBytes {
+			s.option.Backend.Set(ctx, key, data, s.option.TTL)
+		}
+	}
+	return nil
+}
+
+// searchCacheDocuments returns the documents of the result in order. They share the fields with the result.
+func searchCacheDocuments(result interface{}) []Document {
+	switch r := result.(type) {
+	case *SearchDocumentResult:
+		var documents []Document
+		for _, docs := range r.Documents {
+			documents = append(documents, docs...)
+		}
+		return documents
+	case *QueryDocumentResult:
+		return r.Documents
+	}
+	return nil
+}
+
+// fieldTypes returns the types of the field values which are not decoded from json with numbers as they
+// are, keyed by the index of the document and the name of the field.
+func fieldTypes(documents []Document) map[int]map[string]string {
+	var types map[int]map[string]string
+	for i, doc := range documents {
+		for name, field := range doc.Fields {
+			typ := fieldType(field.Val)
+			if typ == "" {
+				continue
+			}
+			if types == nil {
+				types = make(map[int]map[string]string)
+			}
+			if types[i] == nil {
+				types[i] = make(map[string]string)
+			}
+			types[i][name] = typ
+		}
+	}
+	return types
+}
+
+// fieldType returns the type of the value, or empty if it is decoded from json with numbers as it is.
+// The arrays and the json objects holding float64 numbers, such as the json fields of the [RpcClient],
+// have the type of their numbers.
+func fieldType(val interface{}) string {
+	switch v := val.(type) {
+	case uint64:
+		return "uint64"
+	case int64:
+		return "int64"
+	case float64:
+		return "float64"
+	case []string:
+		return "[]string"
+	case []interface{}:
+		for _, elem := range v {
+			if fieldType(elem) == "float64" {
+				return "float64"
+			}
+		}
+	case map[string]interface{}:
+		for _, elem := range v {
+			if fieldType(elem) == "float64" {
+				return "float64"
+			}
+		}
+	}
+	return ""
+}
+
+// restoreFieldTypes converts the field values decoded from json with numbers back into their types.
+func restoreFieldTypes(documents []Document, types map[int]map[string]string) {
+	for i, typ := range types {
+		if i >= len(documents) {
+			continue
+		}
+		for name, fieldType := range typ {
+			if field, ok := documents[i].Fields[name]; ok {
+				documents[i].Fields[name] = Field{Val: restoreFieldValue(field.Val, fieldType)}
+			}
+		}
+	}
+}
+
+func restoreFieldValue(val interface{}, typ string) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		switch typ {
+		case "uint64":
+			if n, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+				return n
+			}
+		case "int64":
+			if n, err := v.Int64(); err == nil {
+				return n
+			}
+		case "float64":
+			if n, err := v.Float64(); err == nil {
+				return n
+			}
+		}
+	case []interface{}:
+		if typ == "[]string" {
+			strs := make([]string, 0, len(v))
+			for _, elem := range v {
+				str, _ := elem.(string)
+				strs = append(strs, str)
+			}
+			return strs
+		}
+		for i := range v {
+			v[i] = restoreFieldValue(v[i], typ)
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = restoreFieldValue(v[k], typ)
+		}
+	}
+	return val
+}
+
+func (s *searchCacheFlatDocument) search(ctx context.Context, databaseName, collectionName string, args []interface{},
+	fn func() (*SearchDocumentResult, error)) (*SearchDocumentResult, error) {
+	key, ok := s.key(ctx, databaseName, collectionName, args...)
+	result := new(SearchDocumentResult)
+	err := s.cached(ctx, key, ok, result, func() error {
+		res, err := fn()
+		if err != nil {
+			return err
+		}
+		*result = *res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// searchParamsKey returns the parameters with the condition of the filter, which is not exported.
+func searchParamsKey(params []*SearchDocumentParams) []interface{} {
+	if len(params) == 0 || params[0] == nil {
+		return nil
+	}
+	p := *params[0]
+	p.Filter = nil
+	return []interface{}{params[0].Filter.Cond(), p}
+}
+
+// [Search] returns the most similar topK vectors by the given vectors, from the cache if it has the result.
+func (s *searchCacheFlatDocument) Search(ctx context.Context, databaseName, collectionName string,
+	vectors [][]float32, params ...*SearchDocumentParams) (*SearchDocumentResult, error) {
+	args := append([]interface{}{"search", vectors}, searchParamsKey(params)...)
+	return s.search(ctx, databaseName, collectionName, args, func() (*SearchDocumentResult, error) {
+		return s.FlatInterface.Search(ctx, databaseName, collectionName, vectors, params...)
+	})
+}
+
+// [SearchById] returns the most similar topK vectors by the given documentIds, from the cache if it has the result.
+func (s *searchCacheFlatDocument) SearchById(ctx context.Context, databaseName, collectionName string,
+	documentIds []string, params ...*SearchDocumentParams) (*SearchDocumentResult, error) {
+	args := append([]interface{}{"searchById", documentIds}, searchParamsKey(params)...)
+	return s.search(ctx, databaseName, collectionName, args, func() (*SearchDocumentResult, error) {
+		return s.FlatInterface.SearchById(ctx, databaseName, collectionName, documentIds, params...)
+	})
+}
+
+// [SearchByText] returns the most similar topK vectors by the given text map, from the cache if it has the result.
+// The EmbeddingExtraInfo of a cached result is nil, because no tokens are used.
+func (s *searchCacheFlatDocument) SearchByText(ctx context.Context, databaseName, collectionName string,
+	text map[string][]string, params ...*SearchDocumentParams) (*SearchDocumentResult, error) {
+	args := append([]interface{}{"searchByText", text}, searchParamsKey(params)...)
+	hit := true
+	result, err := s.search(ctx, databaseName, collectionName, args, func() (*SearchDocumentResult, error) {
+		hit = false
+		return s.FlatInterface.SearchByText(ctx, databaseName, collectionName, text, params...)
+	})
+	if err == nil && hit {
+		result.EmbeddingExtraInfo = nil
+	}
+	return result, err
+}
+
+// [HybridSearch] retrieves both dense and sparse vectors to return the most similar topK vectors, from
+// the cache if it has the result.
+func (s *searchCacheFlatDocument) HybridSearch(ctx context.Context, databaseName, collectionName string,
+	params HybridSearchDocumentParams) (*SearchDocumentResult, error) {
+	p := params
+	p.Filter = nil
+	args := []interface{}{"hybridSearch", params.Filter.Cond(), p}
+	return s.search(ctx, databaseName, collectionName, args, func() (*SearchDocumentResult, error) {
+		return s.FlatInterface.HybridSearch(ctx, databaseName, collectionName, params)
+	})
+}
+
+// [FullTextSearch] retrieves the most similar topK sparse vectors by the given text, from the cache if it has the result.
+func (s *searchCacheFlatDocument) FullTextSearch(ctx context.Context, databaseName, collectionName string,
+	params FullTextSearchParams) (*SearchDocumentResult, error) {
+	p := params
+	p.Filter = nil
+	args := []interface{}{"fullTextSearch", params.Filter.Cond(), p}
+	return s.search(ctx, databaseName, collectionName, args, func() (*SearchDocumentResult, error) {
+		return s.FlatInterface.FullTextSearch(ctx, databaseName, collectionName, params)
+	})
+}
+
+// [Query] queries documents that satisfies the condition from the collection, from the cache if it has the result.
+func (s *searchCacheFlatDocument) Query(ctx context.Context, databaseName, collectionName string,
+	documentIds []string, params ...*QueryDocumentParams) (*QueryDocumentResult, error) {
+	args := []interface{}{"query", documentIds}
+	if len(params) != 0 && params[0] != nil {
+		p := *params[0]
+		p.Filter = nil
+		args = append(args, params[0].Filter.Cond(), p)
+	}
+	key, ok := s.key(ctx, databaseName, collectionName, args...)
+	result := new(QueryDocumentResult)
+	err := s.cached(ctx, key, ok, result, func() error {
+		res, err := s.FlatInterface.Query(ctx, databaseName, collectionName, documentIds, params...)
+		if err != nil {
+			return err
+		}
+		*result = *res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// [Upsert] upserts documents into a collection, and invalidates the cached results of the collection.
+func (s *searchCacheFlatDocument) Upsert(ctx context.Context, databaseName, collectionName string,
+	documents interface{}, params ...*UpsertDocumentParams) (*UpsertDocumentResult, error) {
+	defer s.renew(ctx, databaseName, collectionName)
+	return s.FlatInterface.Upsert(ctx, databaseName, collectionName, documents, params...)
+}
+
+// [Update] updates documents by conditions, and invalidates the cached results of the collection.
+func (s *searchCacheFlatDocument) Update(ctx context.Context, databaseName, collectionName string,
+	param UpdateDocumentParams) (*UpdateDocumentResult, error) {
+	defer s.renew(ctx, databaseName, collectionName)
+	return s.FlatInterface.Update(ctx, databaseName, collectionName, param)
+}
+
+// [Delete] deletes documents by conditions, and invalidates the cached results of the collection.
+func (s *searchCacheFlatDocument) Delete(ctx context.Context, databaseName, collectionName string,
+	param DeleteDocumentParams) (*DeleteDocumentResult, error) {
+	defer s.renew(ctx, databaseName, collectionName)
+	return s.FlatInterface.Delete(ctx, databaseName, collectionName, param)
+}
+
+// [MemorySearchCache] is an in-memory [SearchCacheBackend], which evicts the least recently used values
+// when their total size exceeds the limit.
+type MemorySearchCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	lru      *list.List
+	items    map[string]*list.Element
+}
+
+type memorySearchCacheItem struct {
+	key      string
+	value    []byte
+	expireAt time.Time
+}
+
+// [NewMemorySearchCache] creates a [MemorySearchCache] holding values up to maxBytes in total.
+func NewMemorySearchCache(maxBytes int64) *MemorySearchCache {
+	return &MemorySearchCache{maxBytes: maxBytes, lru: list.New(), items: make(map[string]*list.Element)}
+}
+
+// [Get] returns the value of the key if it has not expired.
+func (m *MemorySearchCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	item := elem.Value.(*memorySearchCacheItem)
+	if !item.expireAt.IsZero() && time.Now().After(item.expireAt) {
+		m.remove(elem)
+		return nil, false, nil
+	}
+	m.lru.MoveToFront(elem)
+	return item.value, true, nil
+}
+
+// [Set] stores the value of the key, and evicts the least recently used values to keep the size within the limit.
+func (m *MemorySearchCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.items[key]; ok {
+		m.remove(elem)
+	}
+	size := int64(len(key) + len(value))
+	if size > m.maxBytes {
+		return nil
+	}
+	item := &memorySearchCacheItem{key: key, value: value}
+	if ttl > 0 {
+		item.expireAt = time.Now().Add(ttl)
+	}
+	m.items[key] = m.lru.PushFront(item)
+	m.size += size
+	for m.size > m.maxBytes {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+// [Len] returns the number of values in the cache, including the expired ones not evicted yet.
+func (m *MemorySearchCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+func (m *MemorySearchCache) remove(elem *list.Element) {
+	item := m.lru.Remove(elem).(*memorySearchCacheItem)
+	delete(m.items, item.key)
+	m.size -= int64(len(item.key) + len(item.value))
+}
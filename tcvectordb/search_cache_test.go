@@ -0,0 +1,48 @@
+package tcvectordb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// rpcFieldsFlat returns the field values with the go types of the [RpcClient].
+type rpcFieldsFlat struct {
+	FlatInterface
+	reads int
+}
+
+func (r *rpcFieldsFlat) Query(ctx context.Context, databaseName, collectionName string,
+	documentIds []string, params ...*QueryDocumentParams) (*QueryDocumentResult, error) {
+	r.reads++
+	return &QueryDocumentResult{Documents: []Document{
+		{Id: "0001", Fields: map[string]Field{
+			"page":   {Val: uint64(18446744073709551615)},
+			"offset": {Val: int64(-21)},
+			"price":  {Val: float64(9.5)},
+			"author": {Val: "Tom"},
+			"tags":   {Val: []string{"a", "b"}},
+			"extra":  {Val: map[string]interface{}{"score": float64(1), "list": []interface{}{float64(2), "c"}}},
+		}},
+		{Id: "0002"},
+	}}, nil
+}
+
+func TestSearchCacheKeepsFieldTypes(t *testing.T) {
+	stub := &rpcFieldsFlat{}
+	flat := withSearchCache(stub, &SearchCacheOption{})
+	miss, err := flat.Query(context.Background(), "db", "coll", []string{"0001", "0002"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hit, err := flat.Query(context.Background(), "db", "coll", []string{"0001", "0002"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stub.reads != 1 {
+		t.Fatalf("read the server %v times, want 1", stub.reads)
+	}
+	if !reflect.DeepEqual(miss.Documents, hit.Documents) {
+		t.Fatalf("the cached documents %#v differ from %#v", hit.Documents, miss.Documents)
+	}
+}
@@ -0,0 +1,441 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+)
+
+const (
+	defaultMMRLambda          = float32(0.5)
+	defaultDiversifyFetchRate = 4
+)
+
+// [MMRSearchParams] holds the parameters for searching documents with Maximal Marginal Relevance (MMR).
+//
+// Fields:
+//   - SearchDocumentParams: The parameters for searching documents. Limit is the number of documents
+//     returned for each query after diversification. See [SearchDocumentParams] for more information.
+//   - FetchLimit: (Optional) The number of candidates retrieved from the server for each query before
+//     diversification (defaults to 4 times Limit).
+//   - Lambda: (Optional) The trade-off between relevance and diversity, ranging from [0, 1] (defaults to 0.5).
+//     1 means ranking by relevance only, and 0 means ranking by diversity only.
+//   - MetricType: (Optional) The metric used to calculate the similarity between vectors (defaults to COSINE).
+//     It should be the same as the metric type of the vector index.
+type MMRSearchParams struct {
+	SearchDocumentParams
+	FetchLimit int64
+	Lambda     *float32
+	MetricType MetricType
+}
+
+// [GroupBySearchParams] holds the parameters for searching documents grouped by a field.
+//
+// Fields:
+//   - SearchDocumentParams: The parameters for searching documents. Limit is the number of groups
+//     returned for each query. See [SearchDocumentParams] for more information.
+//   - GroupByField: (Required) The field name to group documents by, such as the id of the source file.
+//   - GroupSize: (Optional) The maximum number of documents kept in each group (defaults to 1).
+//   - FetchLimit: (Optional) The number of candidates retrieved from the server for each query before
+//     grouping (defaults to 4 times Limit multiplied by GroupSize).
+type GroupBySearchParams struct {
+	SearchDocumentParams
+	GroupByField string
+	GroupSize    int
+	FetchLimit   int64
+}
+
+// [DocumentGroup] holds the documents which have the same value of the GroupByField.
+//
+// Fields:
+//   - Value: The value of the GroupByField. It is nil for the documents without the field.
+//   - Documents: The documents of the group, sorted by the order returned from the server.
+type DocumentGroup struct {
+	Value     interface{}
+	Documents []Document
+}
+
+// [GroupBySearchResult] holds the results for searching documents grouped by a field.
+//
+// Fields:
+//   - Warning: The warning message returned from the server.
+//   - Groups: The list of [DocumentGroup] for each query, sorted by the best document of each group.
+//   - EmbeddingExtraInfo: The embedding information when searching by text.
+type GroupBySearchResult struct {
+	Warning            string
+	Groups             [][]DocumentGroup
+	EmbeddingExtraInfo *document.EmbeddingExtraInfo
+}
+
+// [SearchWithMMR] searches the most similar vectors by the given vectors, and then re-ranks the
+// candidates by Maximal Marginal Relevance to reduce near-duplicate documents in the results.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - vectors: The list of vectors to search. The maximum number of elements in the array is 20.
+//   - param: A [MMRSearchParams] object that includes the other parameters for searching documents' operation.
+//     See [MMRSearchParams] for more information.
+//
+// Notes: The vectors of the candidates are always retrieved to calculate the diversity, and they are
+// removed from the results unless RetrieveVector is true.
+//
+// Returns a pointer to a [SearchDocumentResult] object or an error.
+func SearchWithMMR(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	vectors [][]float32, param MMRSearchParams) (*SearchDocumentResult, error) {
+	searchParam, err := mmrSearchParams(param)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.Search(ctx, databaseName, collectionName, vectors, searchParam)
+	if err != nil {
+		return nil, err
+	}
+	return diversifyResult(res, vectors, param), nil
+}
+
+// [SearchByTextWithMMR] searches the most similar vectors by the given text map, and then re-ranks the
+// candidates by Maximal Marginal Relevance to reduce near-duplicate documents in the results.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - text: It is a map where the keys represent column names, and the values are lists of column values to be retrieved.
+//   - param: A [MMRSearchParams] object that includes the other parameters for searching documents' operation.
+//     See [MMRSearchParams] for more information.
+//
+// Notes: The query vectors are embedded on the server, so the relevance of the candidates is taken from their scores.
+//
+// Returns a pointer to a [SearchDocumentResult] object or an error.
+func SearchByTextWithMMR(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	text map[string][]string, param MMRSearchParams) (*SearchDocumentResult, error) {
+	searchParam, err := mmrSearchParams(param)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.SearchByText(ctx, databaseName, collectionName, text, searchParam)
+	if err != nil {
+		return nil, err
+	}
+	return diversifyResult(res, nil, param), nil
+}
+
+// [SearchWithGroupBy] searches the most similar vectors by the given vectors, and then groups the
+// candidates by the value of GroupByField, keeping the top GroupSize documents of each group.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - vectors: The list of vectors to search. The maximum number of elements in the array is 20.
+//   - param: A [GroupBySearchParams] object that includes the other parameters for searching documents' operation.
+//     See [GroupBySearchParams] for more information.
+//
+// Returns a pointer to a [GroupBySearchResult] object or an error.
+func SearchWithGroupBy(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	vectors [][]float32, param GroupBySearchParams) (*GroupBySearchResult, error) {
+	searchParam, err := groupBySearchParams(param)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.Search(ctx, databaseName, collectionName, vectors, searchParam)
+	if err != nil {
+		return nil, err
+	}
+	return groupResult(res, param), nil
+}
+
+// [SearchByTextWithGroupBy] searches the most similar vectors by the given text map, and then groups the
+// candidates by the value of GroupByField, keeping the top GroupSize documents of each group.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client to search with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection.
+//   - text: It is a map where the keys represent column names, and the values are lists of column values to be retrieved.
+//   - param: A [GroupBySearchParams] object that includes the other parameters for searching documents' operation.
+//     See [GroupBySearchParams] for more information.
+//
+// Returns a pointer to a [GroupBySearchResult] object or an error.
+func SearchByTextWithGroupBy(ctx context.Context, cli FlatDocumentInterface, databaseName, collectionName string,
+	text map[string][]string, param GroupBySearchParams) (*GroupBySearchResult, error) {
+	searchParam, err := groupBySearchParams(param)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.SearchByText(ctx, databaseName, collectionName, text, searchParam)
+	if err != nil {
+		return nil, err
+	}
+	return groupResult(res, param), nil
+}
+
+// [MaximalMarginalRelevance] selects at most limit documents from the candidates, balancing the relevance
+// to the query and the diversity among the selected documents.
+//
+// Parameters:
+//   - queryVector: (Optional) The query vector. If it is nil, the relevance is taken from the scores of the documents.
+//   - documents: The candidate documents sorted by relevance, whose vectors are used to calculate the diversity.
+//   - limit: The maximum number of documents to select.
+//   - lambda: The trade-off between relevance and diversity, ranging from [0, 1].
+//   - metricType: The metric used to calculate the similarity between vectors.
+//
+// Returns the selected documents in the order of selection.
+func MaximalMarginalRelevance(queryVector []float32, documents []Document, limit int,
+	lambda float32, metricType MetricType) []Document {
+	if limit <= 0 || len(documents) == 0 {
+		return []Document{}
+	}
+	if limit > len(documents) {
+		limit = len(documents)
+	}
+	relevance := documentRelevance(queryVector, documents, metricType)
+
+	selected := make([]Document, 0, limit)
+	used := make([]bool, len(documents))
+	maxSimilarity := make([]float64, len(documents))
+	for len(selected) < limit {
+		best := -1
+		bestScore := math.Inf(-1)
+		for i := range documents {
+			if used[i] {
+				continue
+			}
+			score := float64(lambda) * relevance[i]
+			if len(selected) != 0 {
+				score -= float64(1-lambda) * maxSimilarity[i]
+			}
+			if best == -1 || score > bestScore {
+				best = i
+				bestScore = score
+			}
+		}
+		used[best] = true
+		selected = append(selected, documents[best])
+		for i := range documents {
+			if used[i] {
+				continue
+			}
+			similarity := vectorSimilarity(documents[i].Vector, documents[best].Vector, metricType)
+			if len(selected) == 1 || similarity > maxSimilarity[i] {
+				maxSimilarity[i] = similarity
+			}
+		}
+	}
+	return selected
+}
+
+// [GroupDocuments] groups the documents by the value of the field, keeping at most groupSize documents
+// in each group and at most limit groups. The groups are sorted by the position of their first document.
+func GroupDocuments(documents []Document, field string, groupSize, limit int) []DocumentGroup {
+	if groupSize <= 0 {
+		groupSize = 1
+	}
+	groups := make([]DocumentGroup, 0)
+	positions := make(map[string]int)
+	for _, doc := range documents {
+		var value interface{}
+		key := ""
+		if f, ok := doc.Fields[field]; ok {
+			value = f.Val
+			key = "v:" + f.String()
+		}
+		pos, ok := positions[key]
+		if !ok {
+			if limit > 0 && len(groups) >= limit {
+				continue
+			}
+			pos = len(groups)
+			positions[key] = pos
+			groups = append(groups, DocumentGroup{Value: value})
+		}
+		if len(groups[pos].Documents) < groupSize {
+			groups[pos].Documents = append(groups[pos].Documents, doc)
+		}
+	}
+	return groups
+}
+
+func mmrSearchParams(param MMRSearchParams) (*SearchDocumentParams, error) {
+	if param.Limit <= 0 {
+		return nil, errors.New("searching with mmr failed, because the limit must be greater than 0")
+	}
+	if param.Lambda != nil && (*param.Lambda < 0 || *param.Lambda > 1) {
+		return nil, errors.New("searching with mmr failed, because the lambda must be in the range [0, 1]")
+	}
+	searchParam := param.SearchDocumentParams
+	searchParam.RetrieveVector = true
+	searchParam.Limit = param.FetchLimit
+	if searchParam.Limit < param.Limit {
+		searchParam.Limit = param.Limit * defaultDiversifyFetchRate
+	}
+	return &searchParam, nil
+}
+
+func diversifyResult(res *SearchDocumentResult, vectors [][]float32, param MMRSearchParams) *SearchDocumentResult {
+	lambda := defaultMMRLambda
+	if param.Lambda != nil {
+		lambda = *param.Lambda
+	}
+	metricType := param.MetricType
+	if metricType == "" {
+		metricType = COSINE
+	}
+	for i, docs := range res.Documents {
+		var queryVector []float32
+		if i < len(vectors) {
+			queryVector = vectors[i]
+		}
+		selected := MaximalMarginalRelevance(queryVector, docs, int(param.Limit), lambda, metricType)
+		if !param.RetrieveVector {
+			for j := range selected {
+				selected[j].Vector = nil
+			}
+		}
+		res.Documents[i] = selected
+	}
+	return res
+}
+
+func groupBySearchParams(param GroupBySearchParams) (*SearchDocumentParams, error) {
+	if param.GroupByField == "" {
+		return nil, errors.New("searching with group by failed, because the GroupByField is empty")
+	}
+	if param.Limit <= 0 {
+		return nil, errors.New("searching with group by failed, because the limit must be greater than 0")
+	}
+	groupSize := int64(param.GroupSize)
+	if groupSize <= 0 {
+		groupSize = 1
+	}
+	searchParam := param.SearchDocumentParams
+	searchParam.Limit = param.FetchLimit
+	if searchParam.Limit < param.Limit*groupSize {
+		searchParam.Limit = param.Limit * groupSize * defaultDiversifyFetchRate
+	}
+	if len(searchParam.OutputFields) != 0 {
+		outputFields := make([]string, 0, len(searchParam.OutputFields)+1)
+		outputFields = append(outputFields, searchParam.OutputFields...)
+		searchParam.OutputFields = append(outputFields, param.GroupByField)
+	}
+	return &searchParam, nil
+}
+
+func groupResult(res *SearchDocumentResult, param GroupBySearchParams) *GroupBySearchResult {
+	result := new(GroupBySearchResult)
+	result.Warning = res.Warning
+	result.EmbeddingExtraInfo = res.EmbeddingExtraInfo
+	for _, docs := range res.Documents {
+		result.Groups = append(result.Groups, GroupDocuments(docs, param.GroupByField, param.GroupSize, int(param.Limit)))
+	}
+	return result
+}
+
+// documentRelevance returns the relevance of each document to the query. Without the query vector or the
+// document vectors, the scores are normalized into [0, 1] by the order returned from the server.
+func documentRelevance(queryVector []float32, documents []Document, metricType MetricType) []float64 {
+	relevance := make([]float64, len(documents))
+	if len(queryVector) != 0 {
+		complete := true
+		for i, doc := range documents {
+			if len(doc.Vector) != len(queryVector) {
+				complete = false
+				break
+			}
+			relevance[i] = vectorSimilarity(queryVector, doc.Vector, metricType)
+		}
+		if complete {
+			return relevance
+		}
+	}
+
+	minScore, maxScore := float64(documents[0].Score), float64(documents[0].Score)
+	for _, doc := range documents {
+		minScore = math.Min(minScore, float64(doc.Score))
+		maxScore = math.Max(maxScore, float64(doc.Score))
+	}
+	ascending := documents[0].Score < documents[len(documents)-1].Score
+	for i, doc := range documents {
+		if maxScore == minScore {
+			relevance[i] = 1
+			continue
+		}
+		relevance[i] = (float64(doc.Score) - minScore) / (maxScore - minScore)
+		if ascending {
+			relevance[i] = 1 - relevance[i]
+		}
+	}
+	return relevance
+}
+
+// vectorSimilarity returns the similarity of two vectors, and the greater value means the more similar.
+// The L2 distance d is converted to 1/(1+d), and the hamming distance of binary vectors is converted to
+// the ratio of the same bits.
+func vectorSimilarity(a, b []float32, metricType MetricType) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	switch metricType {
+	case IP:
+		var dot float64
+		for i := range a {
+			dot += float64(a[i]) * float64(b[i])
+		}
+		return dot
+	case L2:
+		var sum float64
+		for i := range a {
+			diff := float64(a[i]) - float64(b[i])
+			sum += diff * diff
+		}
+		return 1 / (1 + math.Sqrt(sum))
+	case HAMMING:
+		distance := 0
+		for i := range a {
+			distance += bits.OnesCount8(uint8(a[i]) ^ uint8(b[i]))
+		}
+		return 1 - float64(distance)/float64(len(a)*8)
+	default:
+		var dot, normA, normB float64
+		for i := range a {
+			dot += float64(a[i]) * float64(b[i])
+			normA += float64(a[i]) * float64(a[i])
+			normB += float64(b[i]) * float64(b[i])
+		}
+		if normA == 0 || normB == 0 {
+			return 0
+		}
+		return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tcvectordb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultSemanticCacheRadius    = 0.95
+	defaultSemanticCacheTTL       = 24 * time.Hour
+	defaultSemanticCacheEmbedSize = 1024
+
+	semanticCachePromptField   = "prompt"
+	semanticCacheResponseField = "response"
+	semanticCacheExpireField   = "expire_at"
+)
+
+// [SemanticCacheOption] holds the parameters of a [SemanticCache].
+//
+// Fields:
+//   - Embedder: (Required) The [Embedder] to embed the prompts, such as a [ServerEmbedder].
+//   - Radius: (Optional) The minimum COSINE similarity of a cached prompt to hit (defaults to 0.95).
+//   - TTL: (Optional) The time to keep a response (defaults to 24 hours).
+//   - ScopeFields: (Optional) The fields scoping the responses, such as "model" and "tenant", which
+//     are the filter indexes of the collection. A prompt only hits the responses of the same scope.
+type SemanticCacheOption struct {
+	Embedder    Embedder
+	Radius      float32
+	TTL         time.Duration
+	ScopeFields []string
+}
+
+// [SemanticCacheHit] holds a cached response.
+//
+// Fields:
+//   - Id: The id of the document of the response.
+//   - Prompt: The cached prompt similar to the prompt looked up.
+//   - Response: The response of the cached prompt.
+//   - Score: The COSINE similarity of the prompts.
+//   - ExpireAt: The time the response expires.
+type SemanticCacheHit struct {
+	Id       string
+	Prompt   string
+	Response string
+	Score    float32
+	ExpireAt time.Time
+}
+
+// [SemanticCacheStats] holds the statistics of a [SemanticCache].
+//
+// Fields:
+//   - Hits: The number of the lookups finding a response.
+//   - Misses: The number of the lookups finding nothing.
+//   - Errors: The number of the lookups and stores failed.
+type SemanticCacheStats struct {
+	Hits   int64
+	Misses int64
+	Errors int64
+}
+
+// [HitRate] returns the ratio of the hits to the lookups, or 0 if there is no lookup.
+func (s SemanticCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// [SemanticCache] caches the responses of an LLM in a collection by the embeddings of the prompts, so
+// a prompt similar to a cached one gets its response without calling the LLM. The responses expire
+// by the TTL of the collection on the expire_at field, and the expired ones are never returned even
+// before the server removes them.
+//
+//	cache, err := tcvectordb.NewSemanticCache(cli, "db", "llm_cache", tcvectordb.SemanticCacheOption{
+//		Embedder:    &tcvectordb.ServerEmbedder{Client: cli, Model: "bge-base-zh"},
+//		ScopeFields: []string{"model"},
+//	})
+//	err = cache.CreateCollection(ctx, cli, 1, 1, 768)
+//	hit, err := cache.Get(ctx, prompt, map[string]string{"model": "hunyuan"})
+//	if hit == nil {
+//		response := callLLM(prompt)
+//		err = cache.Set(ctx, prompt, response, map[string]string{"model": "hunyuan"})
+//	}
+type SemanticCache struct {
+	cli            FlatDocumentInterface
+	databaseName   string
+	collectionName string
+	option         SemanticCacheOption
+	embeddings     *embeddingCache
+
+	hits   int64
+	misses int64
+	errors int64
+}
+
+// [NewSemanticCache] creates a [SemanticCache] on a collection.
+//
+// Parameters:
+//   - cli: The client to search and upsert with, such as [Client], [RpcClient] or [VdbClient].
+//   - databaseName: The name of the database.
+//   - collectionName: The name of the collection, which is created by [SemanticCache.CreateCollection]
+//     or has the same indexes and TTL config.
+//   - option: A [SemanticCacheOption] object. See [SemanticCacheOption] for more information.
+//
+// Returns a pointer to a [SemanticCache] object or an error.
+func NewSemanticCache(cli FlatDocumentInterface, databaseName, collectionName string,
+	option SemanticCacheOption) (*SemanticCache, error) {
+	if option.Embedder == nil {
+		return nil, errors.New("semantic cache embedder is required")
+	}
+	if option.Radius <= 0 {
+		option.Radius = defaultSemanticCacheRadius
+	}
+	if option.TTL <= 0 {
+		option.TTL = defaultSemanticCacheTTL
+	}
+	return &SemanticCache{
+		cli:            cli,
+		databaseName:   databaseName,
+		collectionName: collectionName,
+		option:         option,
+		embeddings:     newEmbeddingCache(defaultSemanticCacheEmbedSize),
+	}, nil
+}
+
+// [CreateCollection] creates the collection of the cache if it doesn't exist, with an HNSW COSINE index
+// of the dimension of the embedder, the filter indexes of expire_at and the scope fields, and the TTL
+// config on expire_at.
+func (c *SemanticCache) CreateCollection(ctx context.Context, cli VdbClient, shardNum, replicasNum, dimension uint32) error {
+	indexes := Indexes{
+		VectorIndex: []VectorIndex{{
+			FilterIndex: FilterIndex{FieldName: "vector", FieldType: Vector, IndexType: HNSW},
+			Dimension:   dimension,
+			MetricType:  COSINE,
+			Params:      &HNSWParam{M: 16, EfConstruction: 200},
+		}},
+		FilterIndex: []FilterIndex{
+			{FieldName: "id", FieldType: String, IndexType: PRIMARY},
+			{FieldName: semanticCacheExpireField, FieldType: Uint64, IndexType: FILTER},
+		},
+	}
+	for _, field := range c.option.ScopeFields {
+		indexes.FilterIndex = append(indexes.FilterIndex, FilterIndex{FieldName: field, FieldType: String, IndexType: FILTER})
+	}
+	if _, err := cli.CreateDatabaseIfNotExists(ctx, c.databaseName); err != nil {
+		return err
+	}
+	_, err := cli.CreateCollectionIfNotExists(ctx, c.databaseName, c.collectionName, shardNum, replicasNum,
+		"semantic cache", indexes, &CreateCollectionParams{
+			TtlConfig: &TtlConfig{Enable: true, TimeField: semanticCacheExpireField},
+		})
+	return err
+}
+
+// [Get] looks up the response of the most similar prompt of the scope.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - prompt: The prompt to look up.
+//   - scope: (Optional) The values of the scope fields, such as the model name or the tenant.
+//
+// Returns a pointer to a [SemanticCacheHit] object, nil on a miss, or an error.
+func (c *SemanticCache) Get(ctx context.Context, prompt string, scope map[string]string) (*SemanticCacheHit, error) {
+	hit, err := c.get(ctx, prompt, scope)
+	switch {
+	case err != nil:
+		atomic.AddInt64(&c.errors, 1)
+	case hit == nil:
+		atomic.AddInt64(&c.misses, 1)
+	default:
+		atomic.AddInt64(&c.hits, 1)
+	}
+	return hit, err
+}
+
+func (c *SemanticCache) get(ctx context.Context, prompt string, scope map[string]string) (*SemanticCacheHit, error) {
+	vectors, _, err := embedTexts(ctx, c.option.Embedder, c.embeddings, 1, []string{prompt})
+	if err != nil {
+		return nil, err
+	}
+	filter, err := c.scopeFilter(scope)
+	if err != nil {
+		return nil, err
+	}
+	filter.And(fmt.Sprintf("%s > %d", semanticCacheExpireField, time.Now().Unix()))
+	radius := c.option.Radius
+	res, err := c.cli.Search(ctx, c.databaseName, c.collectionName, vectors, &SearchDocumentParams{
+		Filter:       filter,
+		Limit:        1,
+		Radius:       &radius,
+		OutputFields: []string{"id", semanticCachePromptField, semanticCacheResponseField, semanticCacheExpireField},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Documents) == 0 || len(res.Documents[0]) == 0 || res.Documents[0][0].Score < radius {
+		return nil, nil
+	}
+	doc := res.Documents[0][0]
+	hit := &SemanticCacheHit{
+		Id:       doc.Id,
+		Score:    doc.Score,
+		ExpireAt: time.Unix(doc.Fields[semanticCacheExpireField].Int64(), 0),
+	}
+	hit.Prompt, _ = doc.Fields[semanticCachePromptField].Val.(string)
+	hit.Response, _ = doc.Fields[semanticCacheResponseField].Val.(string)
+	return hit, nil
+}
+
+// [Set] stores the response of the prompt in the scope, which replaces the response of the same prompt
+// and scope, and expires after the TTL.
+func (c *SemanticCache) Set(ctx context.Context, prompt, response string, scope map[string]string) error {
+	err := c.set(ctx, prompt, response, scope)
+	if err != nil {
+		atomic.AddInt64(&c.errors, 1)
+	}
+	return err
+}
+
+func (c *SemanticCache) set(ctx context.Context, prompt, response string, scope map[string]string) error {
+	vectors, _, err := embedTexts(ctx, c.option.Embedder, c.embeddings, 1, []string{prompt})
+	if err != nil {
+		return err
+	}
+	if _, err := c.scopeFilter(scope); err != nil {
+		return err
+	}
+	h := sha256.New()
+	fields := map[string]Field{
+		semanticCachePromptField:   {Val: prompt},
+		semanticCacheResponseField: {Val: response},
+		semanticCacheExpireField:   {Val: uint64(time.Now().Add(c.option.TTL).Unix())},
+	}
+	for _, name := range c.option.ScopeFields {
+		fields[name] = Field{Val: scope[name]}
+		h.Write([]byte(name + "=" + scope[name] + "\x00"))
+	}
+	h.Write([]byte(prompt))
+	_, err = c.cli.Upsert(ctx, c.databaseName, c.collectionName, []Document{{
+		Id:     hex.EncodeToString(h.Sum(nil)),
+		Vector: vectors[0],
+		Fields: fields,
+	}})
+	return err
+}
+
+// [Stats] returns the statistics of the lookups and stores.
+func (c *SemanticCache) Stats() SemanticCacheStats {
+	return SemanticCacheStats{
+		Hits:   atomic.LoadInt64(&c.hits),
+		Misses: atomic.LoadInt64(&c.misses),
+		Errors: atomic.LoadInt64(&c.errors),
+	}
+}
+
+// scopeFilter returns the filter of the scope, where the missing scope fields are empty.
+func (c *SemanticCache) scopeFilter(scope map[string]string) (*Filter, error) {
+	known := make(map[string]bool, len(c.option.ScopeFields))
+	for _, name := range c.option.ScopeFields {
+		known[name] = true
+	}
+	for name := range scope {
+		if !known[name] {
+			return nil, fmt.Errorf("%v is not a scope field of the semantic cache", name)
+		}
+	}
+	filter := NewFilter("")
+	for _, name := range c.option.ScopeFields {
+		filter.And(fmt.Sprintf(`%s = "%s"`, name, strings.ReplaceAll(scope[name], `"`, `\"`)))
+	}
+	return filter, nil
+}
@@ -0,0 +1,762 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package spec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	api_user "github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/user"
+)
+
+// rootUser is the built-in user, which is never dropped by pruning.
+const rootUser = "root"
+
+// [Client] is the client to apply an [InstanceSpec], implemented by [tcvectordb.Client] and [tcvectordb.RpcClient].
+type Client interface {
+	tcvectordb.DatabaseInterface
+	tcvectordb.FlatInterface
+	tcvectordb.FlatIndexInterface
+}
+
+var (
+	_ Client = &tcvectordb.Client{}
+	_ Client = &tcvectordb.RpcClient{}
+)
+
+// [Action] is the kind of a [Change].
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	// ActionConflict is a difference that can't be applied, such as a different dimension of a vector index,
+	// which needs the collection to be recreated by hand.
+	ActionConflict Action = "conflict"
+)
+
+var actionSigns = map[Action]string{
+	ActionCreate:   "+",
+	ActionUpdate:   "~",
+	ActionDelete:   "-",
+	ActionConflict: "!",
+}
+
+// [Change] is a change of the instance in a [Plan].
+//
+// Fields:
+//   - Action: The action of the change: create, update, delete or conflict.
+//   - Kind: The kind of the resource: database, aiDatabase, collection, collectionView, index, alias, user or privilege.
+//   - Name: The name of the resource, such as db1.collection1.
+//   - Detail: (Optional) What is changed.
+type Change struct {
+	Action Action `json:"action"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+
+	run func(ctx context.Context) error
+}
+
+// [String] returns the change as a line of a diff, such as "+ collection db1.collection1".
+func (c Change) String() string {
+	s := fmt.Sprintf("%v %v %v", actionSigns[c.Action], c.Kind, c.Name)
+	if c.Detail != "" {
+		s += ": " + c.Detail
+	}
+	return s
+}
+
+// [Plan] holds the changes that bring an instance to an [InstanceSpec], in the order they are executed.
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// [Empty] reports whether the instance is already in the desired state.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// [Conflicts] returns the changes that can't be applied.
+func (p *Plan) Conflicts() []Change {
+	var conflicts []Change
+	for _, c := range p.Changes {
+		if c.Action == ActionConflict {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// [WriteDiff] writes the changes to w, one per line.
+func (p *Plan) WriteDiff(w io.Writer) error {
+	if p.Empty() {
+		_, err := fmt.Fprintln(w, "no changes")
+		return err
+	}
+	for _, c := range p.Changes {
+		if _, err := fmt.Fprintln(w, c.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// [Execute] executes the changes in order, and stops at the first failure.
+//
+// Notes: Nothing is executed if the plan has conflicts.
+func (p *Plan) Execute(ctx context.Context) error {
+	if conflicts := p.Conflicts(); len(conflicts) > 0 {
+		return fmt.Errorf("the plan has %v conflicts, the first is %q", len(conflicts), conflicts[0].String())
+	}
+	for _, c := range p.Changes {
+		if err := c.run(ctx); err != nil {
+			return fmt.Errorf("apply %q failed. err: %v", c.String(), err)
+		}
+	}
+	return nil
+}
+
+// [ApplyOptions] holds the options for applying an [InstanceSpec].
+//
+// Fields:
+//   - DryRun: Only compute the plan, without changing the instance.
+//   - Prune: Also delete what the spec doesn't declare: databases, AI databases, collections, collection views,
+//     filter indexes, aliases, users except root, and privileges of the declared users.
+type ApplyOptions struct {
+	DryRun bool
+	Prune  bool
+}
+
+// [Apply] brings the instance to the desired state described by the spec. It is idempotent: resources
+// which already exist are left as they are, and applying the same spec again results in an empty plan.
+//
+// Parameters:
+//   - ctx: A context.Context object controls the request's lifetime, allowing for the request
+//     to be canceled or to timeout according to the context's deadline.
+//   - cli: The client of the instance.
+//   - s: The desired state of the instance.
+//   - options: (Optional) The options, see [ApplyOptions] for more information.
+//
+// Notes: Existing collections are compared with the spec. Missing filter indexes are added, but other
+// differences, such as a vector index with another dimension, are conflicts, and nothing is applied
+// while there is any conflict. Existing collection views are not compared.
+//
+// Returns the executed [Plan], or the computed one with DryRun, or an error.
+func Apply(ctx context.Context, cli Client, s *InstanceSpec, options ...ApplyOptions) (*Plan, error) {
+	var option ApplyOptions
+	if len(options) != 0 {
+		option = options[0]
+	}
+	plan, err := Diff(ctx, cli, s, option.Prune)
+	if err != nil {
+		return nil, err
+	}
+	if option.DryRun {
+		return plan, nil
+	}
+	return plan, plan.Execute(ctx)
+}
+
+// [Diff] compares the instance with the spec, and returns the [Plan] to apply it without changing anything.
+// With prune, the plan also deletes what the spec doesn't declare. See [Apply] for more information.
+func Diff(ctx context.Context, cli Client, s *InstanceSpec, prune bool) (*Plan, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := cli.ListDatabase(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list databases failed. err: %v", err)
+	}
+	databases := make(map[string]bool)
+	for _, db := range res.Databases {
+		databases[db.DatabaseName] = true
+	}
+	aiDatabases := make(map[string]bool)
+	for _, db := range res.AIDatabases {
+		aiDatabases[db.DatabaseName] = true
+	}
+
+	d := &differ{ctx: ctx, cli: cli, prune: prune, plan: new(Plan)}
+	declared := make(map[string]bool)
+	for i := range s.Databases {
+		db := &s.Databases[i]
+		declared[db.Name] = true
+		if aiDatabases[db.Name] {
+			d.add(ActionConflict, "database", db.Name, "exists as an AI database", nil)
+			continue
+		}
+		if err := d.database(db, databases[db.Name]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range s.AIDatabases {
+		db := &s.AIDatabases[i]
+		declared[db.Name] = true
+		if databases[db.Name] {
+			d.add(ActionConflict, "aiDatabase", db.Name, "exists as a database", nil)
+			continue
+		}
+		if err := d.aiDatabase(db, aiDatabases[db.Name]); err != nil {
+			return nil, err
+		}
+	}
+	if prune {
+		for _, name := range sortedKeys(databases) {
+			if !declared[name] {
+				name := name
+				d.add(ActionDelete, "database", name, "", func(ctx context.Context) error {
+					_, err := cli.DropDatabase(ctx, name)
+					return err
+				})
+			}
+		}
+		for _, name := range sortedKeys(aiDatabases) {
+			if !declared[name] {
+				name := name
+				d.add(ActionDelete, "aiDatabase", name, "", func(ctx context.Context) error {
+					_, err := cli.DropAIDatabase(ctx, name)
+					return err
+				})
+			}
+		}
+	}
+	if err := d.users(s.Users); err != nil {
+		return nil, err
+	}
+	return d.plan, nil
+}
+
+// [Export] describes the current state of the instance as an [InstanceSpec], which can be edited and applied
+// to keep the instance, or another one, in that state.
+//
+// Notes: The passwords of the users can't be read, so PasswordEnv of each user is set to VDB_PASSWORD_<USER>.
+// The built-in root user is not exported.
+func Export(ctx context.Context, cli Client) (*InstanceSpec, error) {
+	res, err := cli.ListDatabase(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list databases failed. err: %v", err)
+	}
+	s := new(InstanceSpec)
+	for _, db := range res.Databases {
+		database := cli.Database(db.DatabaseName)
+		dbSpec := DatabaseSpec{Name: db.DatabaseName}
+		collections, err := database.ListCollection(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list collections of database %v failed. err: %v", db.DatabaseName, err)
+		}
+		for _, coll := range collections.Collections {
+			collSpec := FromCollection(coll)
+			collSpec.Database = ""
+			dbSpec.Collections = append(dbSpec.Collections, collSpec)
+		}
+		aliases, err := database.ListAliases(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list aliases of database %v failed. err: %v", db.DatabaseName, err)
+		}
+		for _, alias := range aliases.Aliases {
+			dbSpec.Aliases = append(dbSpec.Aliases, AliasSpec{Alias: alias.Alias, Collection: alias.Collection})
+		}
+		s.Databases = append(s.Databases, dbSpec)
+	}
+	for _, db := range res.AIDatabases {
+		dbSpec := AIDatabaseSpec{Name: db.DatabaseName}
+		views, err := cli.AIDatabase(db.DatabaseName).ListCollectionViews(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list collection views of ai database %v failed. err: %v", db.DatabaseName, err)
+		}
+		for _, view := range views.CollectionViews {
+			viewSpec := CollectionViewSpec{
+				Name:               view.CollectionViewName,
+				Description:        view.Description,
+				Embedding:          view.Embedding,
+				SplitterPreprocess: view.SplitterPreprocess,
+				ParsingProcess:     view.ParsingProcess,
+				ReplicaNum:         view.ReplicaNum,
+				ShardNum:           view.ShardNum,
+			}
+			for _, index := range view.FilterIndexes {
+				viewSpec.Indexes = append(viewSpec.Indexes, IndexSpec{
+					FieldName: index.FieldName,
+					FieldType: string(index.FieldType),
+					IndexType: string(index.IndexType),
+					ElemType:  string(index.ElemType),
+				})
+			}
+			dbSpec.CollectionViews = append(dbSpec.CollectionViews, viewSpec)
+			for _, alias := range view.Alias {
+				dbSpec.Aliases = append(dbSpec.Aliases, AliasSpec{Alias: alias, Collection: view.CollectionViewName})
+			}
+		}
+		s.AIDatabases = append(s.AIDatabases, dbSpec)
+	}
+	users, err := cli.ListUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users failed. err: %v", err)
+	}
+	for _, user := range users.Users {
+		if user.User == rootUser {
+			continue
+		}
+		s.Users = append(s.Users, UserSpec{
+			User:        user.User,
+			PasswordEnv: "VDB_PASSWORD_" + strings.ToUpper(user.User),
+			Privileges:  user.Privileges,
+		})
+	}
+	return s, nil
+}
+
+// differ builds a [Plan] by comparing the instance with the spec.
+type differ struct {
+	ctx   context.Context
+	cli   Client
+	prune bool
+	plan  *Plan
+}
+
+func (d *differ) add(action Action, kind, name, detail string, run func(ctx context.Context) error) {
+	d.plan.Changes = append(d.plan.Changes, Change{Action: action, Kind: kind, Name: name, Detail: detail, run: run})
+}
+
+func (d *differ) database(db *DatabaseSpec, exists bool) error {
+	name := db.Name
+	database := d.cli.Database(name)
+	collections := make(map[string]*tcvectordb.Collection)
+	aliases := make(map[string]string)
+	if !exists {
+		d.add(ActionCreate, "database", name, "", func(ctx context.Context) error {
+			_, err := d.cli.CreateDatabaseIfNotExists(ctx, name)
+			return err
+		})
+	} else {
+		res, err := database.ListCollection(d.ctx)
+		if err != nil {
+			return fmt.Errorf("list collections of database %v failed. err: %v", name, err)
+		}
+		for _, coll := range res.Collections {
+			collections[coll.CollectionName] = coll
+		}
+		aliasRes, err := database.ListAliases(d.ctx)
+		if err != nil {
+			return fmt.Errorf("list aliases of database %v failed. err: %v", name, err)
+		}
+		for _, alias := range aliasRes.Aliases {
+			aliases[alias.Alias] = alias.Collection
+		}
+	}
+
+	declared := make(map[string]bool)
+	for i := range db.Collections {
+		want := db.Collections[i]
+		want.Database = name
+		declared[want.Name] = true
+		if have, ok := collections[want.Name]; ok {
+			d.collection(&want, have)
+			continue
+		}
+		d.createCollection(&want)
+	}
+	d.aliases(name, db.Aliases, aliases, func(ctx context.Context, collection, alias string) error {
+		_, err := database.SetAlias(ctx, collection, alias)
+		return err
+	})
+	if !d.prune {
+		return nil
+	}
+	for _, alias := range sortedKeys(aliases) {
+		if !aliasDeclared(db.Aliases, alias) {
+			alias := alias
+			d.add(ActionDelete, "alias", name+"."+alias, "", func(ctx context.Context) error {
+				_, err := database.DeleteAlias(ctx, alias)
+				return err
+			})
+		}
+	}
+	for _, coll := range sortedKeys(collections) {
+		if !declared[coll] {
+			coll := coll
+			d.add(ActionDelete, "collection", name+"."+coll, "", func(ctx context.Context) error {
+				_, err := database.DropCollection(ctx, coll)
+				return err
+			})
+		}
+	}
+	return nil
+}
+
+func (d *differ) createCollection(s *CollectionSpec) {
+	indexes, _ := s.ToIndexes()
+	shardNum := s.ShardNum
+	if shardNum == 0 {
+		shardNum = 1
+	}
+	d.add(ActionCreate, "collection", s.Database+"."+s.Name, "", func(ctx context.Context) error {
+		_, err := d.cli.Database(s.Database).CreateCollectionIfNotExists(ctx, s.Name, shardNum, s.ReplicaNum,
+			s.Description, indexes, s.CreateParams())
+		return err
+	})
+}
+
+// collection compares an existing collection with its spec. Missing filter indexes are added, extra
+// filter indexes are dropped with prune, and the other differences are conflicts.
+func (d *differ) collection(want *CollectionSpec, coll *tcvectordb.Collection) {
+	name := want.Database + "." + want.Name
+	have := FromCollection(coll)
+	var conflicts []string
+	if want.ShardNum != 0 && want.ShardNum != have.ShardNum {
+		conflicts = append(conflicts, fmt.Sprintf("shardNum is %v, want %v", have.ShardNum, want.ShardNum))
+	}
+	if want.ReplicaNum != 0 && want.ReplicaNum != have.ReplicaNum {
+		conflicts = append(conflicts, fmt.Sprintf("replicaNum is %v, want %v", have.ReplicaNum, want.ReplicaNum))
+	}
+	if want.Description != "" && want.Description != have.Description {
+		conflicts = append(conflicts, fmt.Sprintf("description is %q, want %q", have.Description, want.Description))
+	}
+	if want.TtlConfig != nil && (have.TtlConfig == nil || !reflect.DeepEqual(*want.TtlConfig, *have.TtlConfig)) {
+		conflicts = append(conflicts, "ttlConfig differs")
+	}
+	if want.FilterIndexConfig != nil && (have.FilterIndexConfig == nil ||
+		!filterIndexConfigMatches(want.FilterIndexConfig, have.FilterIndexConfig)) {
+		conflicts = append(conflicts, "filterIndexConfig differs")
+	}
+	if want.Embedding != nil && (have.Embedding == nil || !embeddingMatches(want.Embedding, have.Embedding)) {
+		conflicts = append(conflicts, "embedding differs")
+	}
+
+	existing := make(map[string]IndexSpec)
+	for _, index := range have.Indexes {
+		existing[index.FieldName] = index
+	}
+	declared := make(map[string]bool)
+	var adds []tcvectordb.FilterIndex
+	var addNames []string
+	for i := range want.Indexes {
+		index := &want.Indexes[i]
+		declared[index.FieldName] = true
+		current, ok := existing[index.FieldName]
+		switch {
+		case ok:
+			if diff := indexDiff(index, &current); diff != "" {
+				conflicts = append(conflicts, fmt.Sprintf("field %v: %v", index.FieldName, diff))
+			}
+		case index.isFilter():
+			adds = append(adds, index.filterIndex())
+			addNames = append(addNames, index.FieldName)
+		default:
+			conflicts = append(conflicts, fmt.Sprintf("field %v is missing", index.FieldName))
+		}
+	}
+	var drops []string
+	for i := range have.Indexes {
+		index := &have.Indexes[i]
+		if declared[index.FieldName] {
+			continue
+		}
+		if index.isFilter() && index.IndexType != string(tcvectordb.PRIMARY) {
+			drops = append(drops, index.FieldName)
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("field %v is not declared", index.FieldName))
+	}
+
+	for _, conflict := range conflicts {
+		d.add(ActionConflict, "collection", name, conflict, nil)
+	}
+	if len(adds) > 0 {
+		d.add(ActionUpdate, "index", name, "add filter indexes "+strings.Join(addNames, ", "), func(ctx context.Context) error {
+			return d.cli.AddIndex(ctx, want.Database, want.Name, &tcvectordb.AddIndexParams{FilterIndexs: adds})
+		})
+	}
+	if d.prune && len(drops) > 0 {
+		d.add(ActionDelete, "index", name, "drop filter indexes "+strings.Join(drops, ", "), func(ctx context.Context) error {
+			return d.cli.DropIndex(ctx, want.Database, want.Name, tcvectordb.DropIndexParams{FieldNames: drops})
+		})
+	}
+}
+
+// indexDiff describes the differences between the index spec and the existing index. Unset optional
+// fields of the spec are not compared.
+func indexDiff(want, have *IndexSpec) string {
+	var diffs []string
+	diff := func(field string, want, have interface{}) {
+		diffs = append(diffs, fmt.Sprintf("%v is %v, want %v", field, have, want))
+	}
+	if want.FieldType != have.FieldType {
+		diff("fieldType", want.FieldType, have.FieldType)
+	}
+	if want.IndexType != have.IndexType {
+		diff("indexType", want.IndexType, have.IndexType)
+	}
+	if want.ElemType != "" && want.ElemType != have.ElemType {
+		diff("elemType", want.ElemType, have.ElemType)
+	}
+	if want.Dimension != 0 && want.Dimension != have.Dimension {
+		diff("dimension", want.Dimension, have.Dimension)
+	}
+	if want.MetricType != "" && want.MetricType != have.MetricType {
+		diff("metricType", want.MetricType, have.MetricType)
+	}
+	if want.Params != nil && have.Params != nil {
+		if want.Params.M != 0 && want.Params.M != have.Params.M {
+			diff("M", want.Params.M, have.Params.M)
+		}
+		if want.Params.EfConstruction != 0 && want.Params.EfConstruction != have.Params.EfConstruction {
+			diff("efConstruction", want.Params.EfConstruction, have.Params.EfConstruction)
+		}
+		if want.Params.NList != 0 && want.Params.NList != have.Params.NList {
+			diff("nlist", want.Params.NList, have.Params.NList)
+		}
+	}
+	return strings.Join(diffs, ", ")
+}
+
+func embeddingMatches(want, have *tcvectordb.Embedding) bool {
+	return (want.Field == "" || want.Field == have.Field) &&
+		(want.VectorField == "" || want.VectorField == have.VectorField) &&
+		(want.ModelName == "" || want.ModelName == have.ModelName)
+}
+
+// filterIndexConfigMatches compares the filter index configurations, where the order of the fields without
+// index does not matter and an unset maxStrLen is left to the server.
+func filterIndexConfigMatches(want, have *tcvectordb.FilterIndexConfig) bool {
+	if want.FilterAll != have.FilterAll || len(want.FieldsWithoutIndex) != len(have.FieldsWithoutIndex) {
+		return false
+	}
+	fields := make(map[string]bool, len(have.FieldsWithoutIndex))
+	for _, field := range have.FieldsWithoutIndex {
+		fields[field] = true
+	}
+	for _, field := range want.FieldsWithoutIndex {
+		if !fields[field] {
+			return false
+		}
+	}
+	return want.MaxStrLen == nil || have.MaxStrLen != nil && *want.MaxStrLen == *have.MaxStrLen
+}
+
+// aliases sets the declared aliases which are missing or point to another collection.
+func (d *differ) aliases(database string, aliases []AliasSpec, existing map[string]string,
+	set func(ctx context.Context, collection, alias string) error) {
+	for _, alias := range aliases {
+		alias := alias
+		current, ok := existing[alias.Alias]
+		if ok && current == alias.Collection {
+			continue
+		}
+		action, detail := ActionCreate, "-> "+alias.Collection
+		if ok {
+			action, detail = ActionUpdate, current+" -> "+alias.Collection
+		}
+		d.add(action, "alias", database+"."+alias.Alias, detail, func(ctx context.Context) error {
+			return set(ctx, alias.Collection, alias.Alias)
+		})
+	}
+}
+
+func aliasDeclared(aliases []AliasSpec, alias string) bool {
+	for _, a := range aliases {
+		if a.Alias == alias {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *differ) aiDatabase(db *AIDatabaseSpec, exists bool) error {
+	name := db.Name
+	database := d.cli.AIDatabase(name)
+	views := make(map[string]bool)
+	aliases := make(map[string]string)
+	if !exists {
+		d.add(ActionCreate, "aiDatabase", name, "", func(ctx context.Context) error {
+			_, err := d.cli.CreateAIDatabase(ctx, name)
+			return err
+		})
+	} else {
+		res, err := database.ListCollectionViews(d.ctx)
+		if err != nil {
+			return fmt.Errorf("list collection views of ai database %v failed. err: %v", name, err)
+		}
+		for _, view := range res.CollectionViews {
+			views[view.CollectionViewName] = true
+			for _, alias := range view.Alias {
+				aliases[alias] = view.CollectionViewName
+			}
+		}
+	}
+
+	declared := make(map[string]bool)
+	for i := range db.CollectionViews {
+		view := db.CollectionViews[i]
+		declared[view.Name] = true
+		if views[view.Name] {
+			continue
+		}
+		param, _ := view.CreateParams()
+		d.add(ActionCreate, "collectionView", name+"."+view.Name, "", func(ctx context.Context) error {
+			_, err := database.CreateCollectionView(ctx, view.Name, param)
+			return err
+		})
+	}
+	d.aliases(name, db.Aliases, aliases, func(ctx context.Context, view, alias string) error {
+		_, err := database.SetAlias(ctx, view, alias)
+		return err
+	})
+	if !d.prune {
+		return nil
+	}
+	for _, alias := range sortedKeys(aliases) {
+		if !aliasDeclared(db.Aliases, alias) {
+			alias := alias
+			d.add(ActionDelete, "alias", name+"."+alias, "", func(ctx context.Context) error {
+				_, err := database.DeleteAlias(ctx, alias)
+				return err
+			})
+		}
+	}
+	for _, view := range sortedKeys(views) {
+		if !declared[view] {
+			view := view
+			d.add(ActionDelete, "collectionView", name+"."+view, "", func(ctx context.Context) error {
+				_, err := database.DropCollectionView(ctx, view)
+				return err
+			})
+		}
+	}
+	return nil
+}
+
+// users creates the missing users, grants the missing privileges, and with prune revokes the
+// undeclared privileges and drops the undeclared users.
+func (d *differ) users(users []UserSpec) error {
+	if len(users) == 0 && !d.prune {
+		return nil
+	}
+	res, err := d.cli.ListUser(d.ctx)
+	if err != nil {
+		return fmt.Errorf("list users failed. err: %v", err)
+	}
+	existing := make(map[string][]api_user.Privilege)
+	for _, user := range res.Users {
+		existing[user.User] = user.Privileges
+	}
+
+	declared := make(map[string]bool)
+	for i := range users {
+		user := users[i]
+		declared[user.User] = true
+		current, ok := existing[user.User]
+		if !ok {
+			password, err := user.password()
+			if err != nil {
+				return err
+			}
+			d.add(ActionCreate, "user", user.User, "", func(ctx context.Context) error {
+				return d.cli.CreateUser(ctx, tcvectordb.CreateUserParams{User: user.User, Password: password})
+			})
+		}
+		grants, revokes := privilegeDiff(user.Privileges, current)
+		for _, p := range grants {
+			p := p
+			d.add(ActionCreate, "privilege", user.User, privilegeString(p), func(ctx context.Context) error {
+				return d.cli.GrantToUser(ctx, tcvectordb.GrantToUserParams{User: user.User, Privileges: []*api_user.Privilege{&p}})
+			})
+		}
+		if !d.prune {
+			continue
+		}
+		for _, p := range revokes {
+			p := p
+			d.add(ActionDelete, "privilege", user.User, privilegeString(p), func(ctx context.Context) error {
+				return d.cli.RevokeFromUser(ctx, tcvectordb.RevokeFromUserParams{User: user.User, Privileges: []*api_user.Privilege{&p}})
+			})
+		}
+	}
+	if !d.prune {
+		return nil
+	}
+	for _, user := range sortedKeys(existing) {
+		if declared[user] || user == rootUser {
+			continue
+		}
+		user := user
+		d.add(ActionDelete, "user", user, "", func(ctx context.Context) error {
+			return d.cli.DropUser(ctx, tcvectordb.DropUserParams{User: user})
+		})
+	}
+	return nil
+}
+
+// privilegeDiff returns the actions to grant and to revoke, for each resource, to turn the current
+// privileges into the wanted ones.
+func privilegeDiff(want, have []api_user.Privilege) (grants, revokes []api_user.Privilege) {
+	wantActions := privilegeActions(want)
+	haveActions := privilegeActions(have)
+	diff := func(a, b map[string]map[string]bool) []api_user.Privilege {
+		var privileges []api_user.Privilege
+		for _, resource := range sortedKeys(a) {
+			var actions []string
+			for _, action := range sortedKeys(a[resource]) {
+				if !b[resource][action] {
+					actions = append(actions, action)
+				}
+			}
+			if len(actions) > 0 {
+				privileges = append(privileges, api_user.Privilege{Resource: resource, Actions: actions})
+			}
+		}
+		return privileges
+	}
+	return diff(wantActions, haveActions), diff(haveActions, wantActions)
+}
+
+func privilegeActions(privileges []api_user.Privilege) map[string]map[string]bool {
+	m := make(map[string]map[string]bool)
+	for _, p := range privileges {
+		if m[p.Resource] == nil {
+			m[p.Resource] = make(map[string]bool)
+		}
+		for _, action := range p.Actions {
+			m[p.Resource][action] = true
+		}
+	}
+	return m
+}
+
+func privilegeString(p api_user.Privilege) string {
+	return strings.Join(p.Actions, ",") + " on " + p.Resource
+}
+
+// sortedKeys returns the keys of a map with string keys in order.
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	keys := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
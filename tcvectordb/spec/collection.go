@@ -0,0 +1,260 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package spec describes the collections, and in general the desired state of an instance,
+// in YAML or JSON files.
+package spec
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+// [CollectionSpec] describes a collection.
+//
+// Fields:
+//   - Name: (Required) The name of the collection.
+//   - Database: (Optional) The name of the database, if not given by the command or the parent spec.
+//   - ShardNum: (Optional) The number of the shards (defaults to 1).
+//   - ReplicaNum: (Optional) The number of the replicas (defaults to 0).
+//   - Description: (Optional) The description of the collection.
+//   - Indexes: (Required) The indexes of the collection. See [IndexSpec] for more information.
+//   - Embedding: (Optional) The embedding configuration. See [tcvectordb.Embedding] for more information.
+//   - TtlConfig: (Optional) The TTL configuration. See [tcvectordb.TtlConfig] for more information.
+//   - FilterIndexConfig: (Optional) The filter index configuration. See [tcvectordb.FilterIndexConfig] for more information.
+//
+// An example in YAML:
+//
+//	name: articles
+//	shardNum: 1
+//	replicaNum: 2
+//	indexes:
+//	  - {fieldName: id, fieldType: string, indexType: primaryKey}
+//	  - {fieldName: vector, fieldType: vector, indexType: HNSW, dimension: 768, metricType: COSINE,
+//	     params: {M: 16, efConstruction: 200}}
+//	  - {fieldName: sparse_vector, fieldType: sparseVector, indexType: inverted, metricType: IP}
+//	  - {fieldName: author, fieldType: string, indexType: filter}
+//	ttlConfig: {enable: true, timeField: expire_at}
+type CollectionSpec struct {
+	Name              string                        `json:"name"`
+	Database          string                        `json:"database,omitempty"`
+	ShardNum          uint32                        `json:"shardNum,omitempty"`
+	ReplicaNum        uint32                        `json:"replicaNum,omitempty"`
+	Description       string                        `json:"description,omitempty"`
+	Indexes           []IndexSpec                   `json:"indexes"`
+	Embedding         *tcvectordb.Embedding         `json:"embedding,omitempty"`
+	TtlConfig         *tcvectordb.TtlConfig         `json:"ttlConfig,omitempty"`
+	FilterIndexConfig *tcvectordb.FilterIndexConfig `json:"filterIndexConfig,omitempty"`
+}
+
+// [IndexSpec] describes an index of a collection, in the same form as the indexes of the HTTP API.
+// The kind of the index follows the FieldType: vector types make a [tcvectordb.VectorIndex],
+// sparseVector makes a [tcvectordb.SparseVectorIndex], and the others make a [tcvectordb.FilterIndex].
+//
+// Fields:
+//   - FieldName: (Required) The name of the field.
+//   - FieldType: (Required) The type of the field, such as string, uint64, array, json, vector or sparseVector.
+//   - IndexType: (Required) The type of the index, such as primaryKey, filter, HNSW or inverted.
+//   - ElemType: (Optional) The type of the elements of an array field.
+//   - AutoId: (Optional) The method to generate the primary keys, such as uuid.
+//   - Dimension: (Optional) The dimension of a vector field.
+//   - MetricType: (Optional) The metric type of a vector field.
+//   - DiskSwapEnabled: (Optional) Whether to store a sparse vector field on disk.
+//   - Params: (Optional) The parameters of a vector index. See [IndexParamsSpec] for more information.
+type IndexSpec struct {
+	FieldName       string           `json:"fieldName"`
+	FieldType       string           `json:"fieldType"`
+	IndexType       string           `json:"indexType"`
+	ElemType        string           `json:"elemType,omitempty"`
+	AutoId          string           `json:"autoId,omitempty"`
+	Dimension       uint32           `json:"dimension,omitempty"`
+	MetricType      string           `json:"metricType,omitempty"`
+	DiskSwapEnabled *bool            `json:"diskSwapEnabled,omitempty"`
+	Params          *IndexParamsSpec `json:"params,omitempty"`
+}
+
+// [IndexParamsSpec] holds the parameters of a vector index, which are used according to the index type.
+type IndexParamsSpec struct {
+	M              uint32  `json:"M,omitempty"`
+	EfConstruction uint32  `json:"efConstruction,omitempty"`
+	NList          uint32  `json:"nlist,omitempty"`
+	Bits           *uint32 `json:"bits,omitempty"`
+}
+
+// filterIndex returns the [tcvectordb.FilterIndex] part of the index.
+func (i *IndexSpec) filterIndex() tcvectordb.FilterIndex {
+	return tcvectordb.FilterIndex{
+		FieldName: i.FieldName,
+		FieldType: tcvectordb.FieldType(i.FieldType),
+		ElemType:  tcvectordb.FieldType(i.ElemType),
+		IndexType: tcvectordb.IndexType(i.IndexType),
+		AutoId:    i.AutoId,
+	}
+}
+
+// isFilter reports whether the index is a filter index, which can be added to or dropped from
+// an existing collection.
+func (i *IndexSpec) isFilter() bool {
+	filter := i.filterIndex()
+	return !filter.IsVectorField() && filter.FieldType != tcvectordb.SparseVector
+}
+
+// [ToIndexes] converts the index specs into [tcvectordb.Indexes], and validates them.
+func (s *CollectionSpec) ToIndexes() (tcvectordb.Indexes, error) {
+	var indexes tcvectordb.Indexes
+	for _, index := range s.Indexes {
+		filter := index.filterIndex()
+		switch {
+		case filter.FieldType == tcvectordb.SparseVector:
+			indexes.SparseVectorIndex = append(indexes.SparseVectorIndex, tcvectordb.SparseVectorIndex{
+				FieldName:       index.FieldName,
+				FieldType:       filter.FieldType,
+				IndexType:       filter.IndexType,
+				MetricType:      tcvectordb.MetricType(index.MetricType),
+				DiskSwapEnabled: index.DiskSwapEnabled,
+			})
+		case filter.IsVectorField():
+			indexes.VectorIndex = append(indexes.VectorIndex, tcvectordb.VectorIndex{
+				FilterIndex: filter,
+				Dimension:   index.Dimension,
+				MetricType:  tcvectordb.MetricType(index.MetricType),
+				Params:      index.Params.IndexParams(filter.IndexType),
+			})
+		default:
+			indexes.FilterIndex = append(indexes.FilterIndex, filter)
+		}
+	}
+	if err := indexes.Validate(); err != nil {
+		return indexes, fmt.Errorf("collection %v: %v", s.Name, err)
+	}
+	return indexes, nil
+}
+
+// [CreateParams] returns the [tcvectordb.CreateCollectionParams] of the collection.
+func (s *CollectionSpec) CreateParams() *tcvectordb.CreateCollectionParams {
+	return &tcvectordb.CreateCollectionParams{
+		Embedding:         s.Embedding,
+		TtlConfig:         s.TtlConfig,
+		FilterIndexConfig: s.FilterIndexConfig,
+	}
+}
+
+// [Validate] checks the name and the indexes of the collection.
+func (s *CollectionSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("collection name is empty")
+	}
+	if len(s.Indexes) == 0 {
+		return fmt.Errorf("collection %v has no index", s.Name)
+	}
+	_, err := s.ToIndexes()
+	return err
+}
+
+// [IndexParams] returns the typed parameters of the index type, or nil if the index type has no parameters.
+func (p *IndexParamsSpec) IndexParams(indexType tcvectordb.IndexType) tcvectordb.IndexParams {
+	if p == nil {
+		return nil
+	}
+	switch indexType {
+	case tcvectordb.HNSW:
+		return &tcvectordb.HNSWParam{M: p.M, EfConstruction: p.EfConstruction}
+	case tcvectordb.BIN_HNSW:
+		return &tcvectordb.BINHNSWParams{M: p.M, EfConstruction: p.EfConstruction}
+	case tcvectordb.IVF_FLAT:
+		return &tcvectordb.IVFFLATParams{NList: p.NList}
+	case tcvectordb.IVF_PQ:
+		return &tcvectordb.IVFPQParams{M: p.M, NList: p.NList}
+	case tcvectordb.IVF_SQ4, tcvectordb.IVF_SQ8, tcvectordb.IVF_SQ16:
+		return &tcvectordb.IVFSQParams{NList: p.NList, IndexType: indexType}
+	case tcvectordb.IVF_RABITQ:
+		return &tcvectordb.IVFRabitQParams{NList: p.NList, Bits: p.Bits}
+	}
+	return nil
+}
+
+// [FromCollection] describes an existing collection as a [CollectionSpec], which can be saved and
+// applied to create the same collection elsewhere.
+func FromCollection(coll *tcvectordb.Collection) CollectionSpec {
+	s := CollectionSpec{
+		Name:              coll.CollectionName,
+		Database:          coll.DatabaseName,
+		ShardNum:          coll.ShardNum,
+		ReplicaNum:        coll.ReplicasNum,
+		Description:       coll.Description,
+		TtlConfig:         coll.TtlConfig,
+		FilterIndexConfig: coll.FilterIndexConfig,
+	}
+	if coll.Embedding.Field != "" || coll.Embedding.VectorField != "" {
+		embedding := coll.Embedding
+		s.Embedding = &embedding
+	}
+	for _, index := range coll.Indexes.FilterIndex {
+		s.Indexes = append(s.Indexes, IndexSpec{
+			FieldName: index.FieldName,
+			FieldType: string(index.FieldType),
+			IndexType: string(index.IndexType),
+			ElemType:  string(index.ElemType),
+			AutoId:    index.AutoId,
+		})
+	}
+	for _, index := range coll.Indexes.VectorIndex {
+		s.Indexes = append(s.Indexes, IndexSpec{
+			FieldName:  index.FieldName,
+			FieldType:  string(index.FieldType),
+			IndexType:  string(index.IndexType),
+			Dimension:  index.Dimension,
+			MetricType: string(index.MetricType),
+			Params:     indexParamsSpec(index.Params),
+		})
+	}
+	for _, index := range coll.Indexes.SparseVectorIndex {
+		s.Indexes = append(s.Indexes, IndexSpec{
+			FieldName:       index.FieldName,
+			FieldType:       string(index.FieldType),
+			IndexType:       string(index.IndexType),
+			MetricType:      string(index.MetricType),
+			DiskSwapEnabled: index.DiskSwapEnabled,
+		})
+	}
+	// The primary key goes first, as in the specs written by hand.
+	sort.SliceStable(s.Indexes, func(i, j int) bool {
+		return s.Indexes[i].IndexType == string(tcvectordb.PRIMARY) && s.Indexes[j].IndexType != string(tcvectordb.PRIMARY)
+	})
+	return s
+}
+
+func indexParamsSpec(params tcvectordb.IndexParams) *IndexParamsSpec {
+	switch p := params.(type) {
+	case *tcvectordb.HNSWParam:
+		return &IndexParamsSpec{M: p.M, EfConstruction: p.EfConstruction}
+	case *tcvectordb.BINHNSWParams:
+		return &IndexParamsSpec{M: p.M, EfConstruction: p.EfConstruction}
+	case *tcvectordb.IVFFLATParams:
+		return &IndexParamsSpec{NList: p.NList}
+	case *tcvectordb.IVFPQParams:
+		return &IndexParamsSpec{M: p.M, NList: p.NList}
+	case *tcvectordb.IVFSQParams:
+		return &IndexParamsSpec{NList: p.NList}
+	case *tcvectordb.IVFRabitQParams:
+		return &IndexParamsSpec{NList: p.NList, Bits: p.Bits}
+	}
+	return nil
+}
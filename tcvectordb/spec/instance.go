@@ -0,0 +1,284 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package spec
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/collection_view"
+	api_user "github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/user"
+)
+
+// [InstanceSpec] describes the desired state of an instance, which is applied by [Apply].
+//
+// Fields:
+//   - Databases: (Optional) The databases, with their collections and aliases. See [DatabaseSpec] for more information.
+//   - AIDatabases: (Optional) The AI databases, with their collection views and aliases.
+//     See [AIDatabaseSpec] for more information.
+//   - Users: (Optional) The users and their privileges. See [UserSpec] for more information.
+//
+// An example in YAML:
+//
+//	databases:
+//	  - name: docs
+//	    collections:
+//	      - name: articles_v2
+//	        indexes:
+//	          - {fieldName: id, fieldType: string, indexType: primaryKey}
+//	          - {fieldName: vector, fieldType: vector, indexType: HNSW, dimension: 768, metricType: COSINE,
+//	             params: {M: 16, efConstruction: 200}}
+//	    aliases:
+//	      - {alias: articles, collection: articles_v2}
+//	aiDatabases:
+//	  - name: knowledge
+//	    collectionViews:
+//	      - name: manuals
+//	        embedding: {language: en}
+//	users:
+//	  - user: reader
+//	    passwordEnv: VDB_READER_PASSWORD
+//	    privileges:
+//	      - {resource: "docs.*", actions: [read]}
+type InstanceSpec struct {
+	Databases   []DatabaseSpec   `json:"databases,omitempty"`
+	AIDatabases []AIDatabaseSpec `json:"aiDatabases,omitempty"`
+	Users       []UserSpec       `json:"users,omitempty"`
+}
+
+// [DatabaseSpec] describes a database.
+//
+// Fields:
+//   - Name: (Required) The name of the database.
+//   - Collections: (Optional) The collections of the database. The Database of each collection
+//     must be empty or the same as Name.
+//   - Aliases: (Optional) The aliases of the collections. See [AliasSpec] for more information.
+type DatabaseSpec struct {
+	Name        string           `json:"name"`
+	Collections []CollectionSpec `json:"collections,omitempty"`
+	Aliases     []AliasSpec      `json:"aliases,omitempty"`
+}
+
+// [AIDatabaseSpec] describes an AI database.
+//
+// Fields:
+//   - Name: (Required) The name of the AI database.
+//   - CollectionViews: (Optional) The collection views of the AI database. See [CollectionViewSpec] for more information.
+//   - Aliases: (Optional) The aliases of the collection views. See [AliasSpec] for more information.
+type AIDatabaseSpec struct {
+	Name            string               `json:"name"`
+	CollectionViews []CollectionViewSpec `json:"collectionViews,omitempty"`
+	Aliases         []AliasSpec          `json:"aliases,omitempty"`
+}
+
+// [CollectionViewSpec] describes a collection view of an AI database.
+//
+// Fields:
+//   - Name: (Required) The name of the collection view.
+//   - Description: (Optional) The description of the collection view.
+//   - Indexes: (Optional) The filter indexes of the document sets. Vector indexes are not allowed.
+//   - Embedding: (Optional) The embedding configuration of the document sets.
+//   - SplitterPreprocess: (Optional) The configuration for splitting the documents into chunks.
+//   - ParsingProcess: (Optional) The configuration for parsing the files.
+//   - ExpectedFileNum: (Optional) The expected number of files.
+//   - AverageFileSize: (Optional) The expected average size of the files.
+//   - ReplicaNum: (Optional) The number of the replicas.
+//   - ShardNum: (Optional) The number of the shards.
+type CollectionViewSpec struct {
+	Name               string                              `json:"name"`
+	Description        string                              `json:"description,omitempty"`
+	Indexes            []IndexSpec                         `json:"indexes,omitempty"`
+	Embedding          *collection_view.DocumentEmbedding  `json:"embedding,omitempty"`
+	SplitterPreprocess *collection_view.SplitterPreprocess `json:"splitterPreprocess,omitempty"`
+	ParsingProcess     *api.ParsingProcess                 `json:"parsingProcess,omitempty"`
+	ExpectedFileNum    uint64                              `json:"expectedFileNum,omitempty"`
+	AverageFileSize    uint64                              `json:"averageFileSize,omitempty"`
+	ReplicaNum         *uint32                             `json:"replicaNum,omitempty"`
+	ShardNum           *uint32                             `json:"shardNum,omitempty"`
+}
+
+// [AliasSpec] describes an alias and the collection, or the collection view, it points to.
+type AliasSpec struct {
+	Alias      string `json:"alias"`
+	Collection string `json:"collection"`
+}
+
+// [UserSpec] describes a user and its privileges.
+//
+// Fields:
+//   - User: (Required) The name of the user.
+//   - Password: (Optional) The password used when the user is created.
+//   - PasswordEnv: (Optional) The environment variable holding the password, preferred over Password
+//     so that the spec can be committed. One of Password and PasswordEnv is required.
+//   - Privileges: (Optional) The privileges of the user, such as {resource: "db1.*", actions: [read]}.
+//
+// Notes: The password of an existing user is never changed by [Apply].
+type UserSpec struct {
+	User        string               `json:"user"`
+	Password    string               `json:"password,omitempty"`
+	PasswordEnv string               `json:"passwordEnv,omitempty"`
+	Privileges  []api_user.Privilege `json:"privileges,omitempty"`
+}
+
+// [Validate] checks the names in the spec and the indexes of the collections.
+func (s *InstanceSpec) Validate() error {
+	names := make(map[string]bool)
+	for i := range s.Databases {
+		db := &s.Databases[i]
+		if db.Name == "" {
+			return fmt.Errorf("database name is empty")
+		}
+		if names[db.Name] {
+			return fmt.Errorf("database %v is declared more than once", db.Name)
+		}
+		names[db.Name] = true
+		collections := make(map[string]bool)
+		for j := range db.Collections {
+			coll := &db.Collections[j]
+			if coll.Database != "" && coll.Database != db.Name {
+				return fmt.Errorf("collection %v is declared in database %v but belongs to %v", coll.Name, db.Name, coll.Database)
+			}
+			if err := coll.Validate(); err != nil {
+				return fmt.Errorf("database %v: %v", db.Name, err)
+			}
+			if collections[coll.Name] {
+				return fmt.Errorf("collection %v.%v is declared more than once", db.Name, coll.Name)
+			}
+			collections[coll.Name] = true
+		}
+		if err := validateAliases(db.Name, db.Aliases); err != nil {
+			return err
+		}
+	}
+	for i := range s.AIDatabases {
+		db := &s.AIDatabases[i]
+		if db.Name == "" {
+			return fmt.Errorf("ai database name is empty")
+		}
+		if names[db.Name] {
+			return fmt.Errorf("database %v is declared more than once", db.Name)
+		}
+		names[db.Name] = true
+		views := make(map[string]bool)
+		for j := range db.CollectionViews {
+			view := &db.CollectionViews[j]
+			if err := view.Validate(); err != nil {
+				return fmt.Errorf("ai database %v: %v", db.Name, err)
+			}
+			if views[view.Name] {
+				return fmt.Errorf("collection view %v.%v is declared more than once", db.Name, view.Name)
+			}
+			views[view.Name] = true
+		}
+		if err := validateAliases(db.Name, db.Aliases); err != nil {
+			return err
+		}
+	}
+	users := make(map[string]bool)
+	for _, user := range s.Users {
+		if user.User == "" {
+			return fmt.Errorf("user name is empty")
+		}
+		if users[user.User] {
+			return fmt.Errorf("user %v is declared more than once", user.User)
+		}
+		users[user.User] = true
+		if user.Password == "" && user.PasswordEnv == "" {
+			return fmt.Errorf("user %v has neither password nor passwordEnv", user.User)
+		}
+		for _, privilege := range user.Privileges {
+			if privilege.Resource == "" || len(privilege.Actions) == 0 {
+				return fmt.Errorf("user %v has a privilege without resource or actions", user.User)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAliases checks that every alias is declared once. An alias may point to a collection
+// not declared in the spec, which must exist when the spec is applied.
+func validateAliases(database string, aliases []AliasSpec) error {
+	seen := make(map[string]bool)
+	for _, alias := range aliases {
+		if alias.Alias == "" || alias.Collection == "" {
+			return fmt.Errorf("database %v has an alias without name or collection", database)
+		}
+		if seen[alias.Alias] {
+			return fmt.Errorf("alias %v.%v is declared more than once", database, alias.Alias)
+		}
+		seen[alias.Alias] = true
+	}
+	return nil
+}
+
+// [Validate] checks the name and the filter indexes of the collection view.
+func (s *CollectionViewSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("collection view name is empty")
+	}
+	_, err := s.FilterIndexes()
+	return err
+}
+
+// [FilterIndexes] converts the index specs into filter indexes.
+func (s *CollectionViewSpec) FilterIndexes() ([]tcvectordb.FilterIndex, error) {
+	var indexes []tcvectordb.FilterIndex
+	for _, index := range s.Indexes {
+		if !index.isFilter() {
+			return nil, fmt.Errorf("collection view %v: field %v is a vector field, which is managed by the AI database",
+				s.Name, index.FieldName)
+		}
+		indexes = append(indexes, index.filterIndex())
+	}
+	return indexes, nil
+}
+
+// [CreateParams] returns the [tcvectordb.CreateCollectionViewParams] of the collection view.
+func (s *CollectionViewSpec) CreateParams() (tcvectordb.CreateCollectionViewParams, error) {
+	filters, err := s.FilterIndexes()
+	if err != nil {
+		return tcvectordb.CreateCollectionViewParams{}, err
+	}
+	return tcvectordb.CreateCollectionViewParams{
+		Description:        s.Description,
+		Indexes:            tcvectordb.Indexes{FilterIndex: filters},
+		Embedding:          s.Embedding,
+		SplitterPreprocess: s.SplitterPreprocess,
+		ParsingProcess:     s.ParsingProcess,
+		ExpectedFileNum:    s.ExpectedFileNum,
+		AverageFileSize:    s.AverageFileSize,
+		ReplicaNum:         s.ReplicaNum,
+		ShardNum:           s.ShardNum,
+	}, nil
+}
+
+// password returns the password of the user, reading PasswordEnv if set.
+func (s *UserSpec) password() (string, error) {
+	if s.PasswordEnv != "" {
+		if password := os.Getenv(s.PasswordEnv); password != "" {
+			return password, nil
+		}
+		if s.Password == "" {
+			return "", fmt.Errorf("environment variable %v of user %v is empty", s.PasswordEnv, s.User)
+		}
+	}
+	return s.Password, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package splitter
+
+import (
+	"regexp"
+	"strings"
+)
+
+const maxHeadingLevel = 6
+
+var markdownHeadingRegexp = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+
+// [MarkdownHeaderSplitter] splits a Markdown text into the sections under the ATX headings, such as
+// "## Usage", and keeps the titles of the headings of each chunk. The headings in the code blocks are ignored.
+//
+// Fields:
+//   - MaxLevel: (Optional) The deepest level of the headings to split by (defaults to 6). The deeper
+//     headings stay in the text of the sections.
+//   - StripHeadings: (Optional) Whether to remove the heading lines from the chunks, which are still
+//     kept as the titles.
+//   - Splitter: (Optional) The splitter to split each section further, such as a [RecursiveCharacterSplitter].
+//     A section is a chunk by default.
+type MarkdownHeaderSplitter struct {
+	MaxLevel      int
+	StripHeadings bool
+	Splitter      Splitter
+}
+
+// [Split] splits the text into chunks.
+func (s *MarkdownHeaderSplitter) Split(text string) []Chunk {
+	maxLevel := s.MaxLevel
+	if maxLevel <= 0 || maxLevel > maxHeadingLevel {
+		maxLevel = maxHeadingLevel
+	}
+	var headings []heading
+	fence := ""
+	for pos := 0; pos < len(text); {
+		lineEnd := len(text)
+		if i := strings.IndexByte(text[pos:], '\n'); i >= 0 {
+			lineEnd = pos + i
+		}
+		line := strings.TrimRight(text[pos:lineEnd], "\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+		default:
+			if m := markdownHeadingRegexp.FindStringSubmatch(line); m != nil && len(m[1]) <= maxLevel {
+				headings = append(headings, heading{level: len(m[1]), title: m[2], start: pos, end: lineEnd})
+			}
+		}
+		pos = lineEnd + 1
+	}
+	return splitSections(text, headings, s.StripHeadings, s.Splitter)
+}
+
+// heading is a heading line at [start, end) of the text.
+type heading struct {
+	level      int
+	title      string
+	start, end int
+}
+
+// splitSections splits the text into the sections between the headings, and splits each section
+// with the splitter. The sections with nothing but the heading are skipped.
+func splitSections(text string, headings []heading, strip bool, splitter Splitter) []Chunk {
+	var (
+		chunks []Chunk
+		stack  []heading
+	)
+	flush := func(start, contentStart, end int) {
+		if strings.TrimSpace(text[contentStart:end]) == "" {
+			return
+		}
+		if strip {
+			start = contentStart
+		}
+		var sectionChunks []Chunk
+		if splitter == nil {
+			sectionChunks = toChunks(text, []span{{start, end}})
+		} else {
+			sectionChunks = splitter.Split(text[start:end])
+			for i := range sectionChunks {
+				sectionChunks[i].StartPos += start
+				sectionChunks[i].EndPos += start
+			}
+		}
+		var title string
+		var parents []string
+		if len(stack) != 0 {
+			title = stack[len(stack)-1].title
+			for _, h := range stack[:len(stack)-1] {
+				parents = append(parents, h.title)
+			}
+		}
+		for _, chunk := range sectionChunks {
+			chunk.Index = len(chunks)
+			chunk.ParagraphTitle = title
+			chunk.AllParentParagraphTitles = parents
+			chunks = append(chunks, chunk)
+		}
+	}
+	start, contentStart := 0, 0
+	for _, h := range headings {
+		flush(start, contentStart, h.start)
+		for len(stack) != 0 && stack[len(stack)-1].level >= h.level {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, h)
+		start, contentStart = h.start, h.end
+	}
+	flush(start, contentStart, len(text))
+	return chunks
+}
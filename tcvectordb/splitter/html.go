@@ -0,0 +1,163 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package splitter
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// [HTMLHeaderSplitter] splits an HTML document into the sections under the h1 to h6 headings, and keeps
+// the titles of the headings of each chunk. The text is extracted from the HTML with the blocks on
+// separate lines and the scripts and styles removed, and the offsets of the chunks are in the extracted text.
+//
+// Fields:
+//   - MaxLevel: (Optional) The deepest level of the headings to split by (defaults to 6). The deeper
+//     headings stay in the text of the sections.
+//   - StripHeadings: (Optional) Whether to remove the heading lines from the chunks, which are still
+//     kept as the titles.
+//   - Splitter: (Optional) The splitter to split each section further, such as a [RecursiveCharacterSplitter].
+//     A section is a chunk by default.
+type HTMLHeaderSplitter struct {
+	MaxLevel      int
+	StripHeadings bool
+	Splitter      Splitter
+}
+
+// [Split] splits the HTML document into chunks.
+func (s *HTMLHeaderSplitter) Split(document string) []Chunk {
+	text, headings := extractHTML(document)
+	maxLevel := s.MaxLevel
+	if maxLevel <= 0 || maxLevel > maxHeadingLevel {
+		maxLevel = maxHeadingLevel
+	}
+	kept := headings[:0]
+	for _, h := range headings {
+		if h.level <= maxLevel {
+			kept = append(kept, h)
+		}
+	}
+	return splitSections(text, kept, s.StripHeadings, s.Splitter)
+}
+
+// [ExtractHTMLText] extracts the text of an HTML document, with the blocks on separate lines and the
+// scripts and styles removed, which is the text the offsets of [HTMLHeaderSplitter] are in.
+func ExtractHTMLText(document string) string {
+	text, _ := extractHTML(document)
+	return text
+}
+
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "figcaption": true, "figure": true, "footer": true, "form": true, "header": true,
+	"hr": true, "li": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "td": true, "th": true, "tr": true, "ul": true,
+}
+
+var htmlSkippedTags = map[string]bool{"head": true, "noscript": true, "script": true, "style": true, "template": true}
+
+// extractHTML extracts the text of the document, and the headings in it.
+func extractHTML(document string) (string, []heading) {
+	var (
+		b        strings.Builder
+		headings []heading
+		skipped  int
+		pre      int
+		current  *heading
+		space    bool
+	)
+	newline := func() {
+		text := b.String()
+		if len(text) != 0 && !strings.HasSuffix(text, "\n") {
+			b.WriteByte('\n')
+		}
+		space = false
+	}
+	z := html.NewTokenizer(strings.NewReader(document))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		name, _ := z.TagName()
+		tag := string(name)
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if htmlSkippedTags[tag] && tt == html.StartTagToken {
+				skipped++
+			}
+			if tag == "pre" && tt == html.StartTagToken {
+				pre++
+			}
+			if level := headingLevel(tag); level != 0 && skipped == 0 {
+				newline()
+				current = &heading{level: level, start: b.Len()}
+			} else if htmlBlockTags[tag] {
+				newline()
+			}
+		case html.EndTagToken:
+			if htmlSkippedTags[tag] && skipped > 0 {
+				skipped--
+			}
+			if tag == "pre" && pre > 0 {
+				pre--
+			}
+			if level := headingLevel(tag); level != 0 && current != nil {
+				current.title = strings.TrimSpace(b.String()[current.start:])
+				current.end = b.Len()
+				newline()
+				if current.title != "" {
+					headings = append(headings, *current)
+				}
+				current = nil
+			} else if htmlBlockTags[tag] {
+				newline()
+			}
+		case html.TextToken:
+			text := string(z.Text())
+			if skipped > 0 || text == "" {
+				continue
+			}
+			if pre > 0 {
+				b.WriteString(text)
+				continue
+			}
+			fields := strings.Fields(text)
+			if len(fields) != 0 && strings.IndexAny(text[:1], " \t\r\n") == 0 {
+				space = true
+			}
+			for i, field := range fields {
+				if (i > 0 || space) && b.Len() != 0 && !strings.HasSuffix(b.String(), "\n") {
+					b.WriteByte(' ')
+				}
+				b.WriteString(field)
+			}
+			space = strings.IndexAny(text[len(text)-1:], " \t\r\n") == 0
+		}
+	}
+	return b.String(), headings
+}
+
+func headingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package splitter
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/tokenizer"
+)
+
+const defaultChunkSize = 500
+
+// DefaultSeparators are the separators of [RecursiveCharacterSplitter] by default, which split by
+// paragraphs, lines, sentences, clauses, words and at last characters, for both Chinese and English.
+var DefaultSeparators = []string{"\n\n", "\n", "。", "！", "？", ". ", "! ", "? ", "；", "; ", "，", ", ", " ", ""}
+
+// [LengthFunc] measures the length of a text.
+type LengthFunc func(text string) int
+
+// [TokenLength] returns a [LengthFunc] counting the tokens of the tokenizer, excluding the stop words
+// if they are enabled.
+func TokenLength(t tokenizer.Tokenizer) LengthFunc {
+	return func(text string) int {
+		return len(t.Tokenize(text))
+	}
+}
+
+// [RecursiveCharacterSplitter] splits a text by the separators in turn, until the pieces fit in the
+// chunk size, and merges the adjacent pieces into chunks.
+//
+// Fields:
+//   - ChunkSize: (Optional) The maximum length of a chunk (defaults to 500). A piece which cannot be
+//     split by any separator may exceed it.
+//   - ChunkOverlap: (Optional) The maximum length of the end of a chunk repeated at the start of the
+//     next chunk (defaults to 0). It is ignored if it is not less than ChunkSize.
+//   - Separators: (Optional) The separators in the order to try (defaults to [DefaultSeparators]).
+//     The empty separator splits into characters. The separators stay at the end of the pieces.
+//   - Length: (Optional) The function measuring the length (defaults to the number of characters).
+type RecursiveCharacterSplitter struct {
+	ChunkSize    int
+	ChunkOverlap int
+	Separators   []string
+	Length       LengthFunc
+}
+
+// [NewTokenSplitter] returns a [RecursiveCharacterSplitter] measuring the chunks by the tokens of a
+// tcvdbtext tokenizer, such as the one of the BM25 encoder.
+func NewTokenSplitter(t tokenizer.Tokenizer, chunkSize, chunkOverlap int) *RecursiveCharacterSplitter {
+	return &RecursiveCharacterSplitter{ChunkSize: chunkSize, ChunkOverlap: chunkOverlap, Length: TokenLength(t)}
+}
+
+// [Split] splits the text into chunks.
+func (s *RecursiveCharacterSplitter) Split(text string) []Chunk {
+	separators := s.Separators
+	if len(separators) == 0 {
+		separators = DefaultSeparators
+	}
+	seps := make([]separator, 0, len(separators))
+	for _, sep := range separators {
+		if sep == "" {
+			seps = append(seps, separator{})
+			continue
+		}
+		seps = append(seps, separator{re: regexp.MustCompile(regexp.QuoteMeta(sep))})
+	}
+	return newMerger(s.ChunkSize, s.ChunkOverlap, s.Length).split(text, seps)
+}
+
+// [RegexSplitter] splits a text where the pattern matches, with each match starting a piece, and merges
+// the adjacent pieces into chunks. The pieces longer than the chunk size are split by [DefaultSeparators].
+//
+// Fields:
+//   - Pattern: (Required) The regular expression where to split, such as `\n第.+章`.
+//   - ChunkSize: (Optional) The maximum length of a chunk (defaults to 500).
+//   - ChunkOverlap: (Optional) The maximum length of the end of a chunk repeated at the start of the
+//     next chunk (defaults to 0).
+//   - Length: (Optional) The function measuring the length (defaults to the number of characters).
+type RegexSplitter struct {
+	Pattern      *regexp.Regexp
+	ChunkSize    int
+	ChunkOverlap int
+	Length       LengthFunc
+}
+
+// [Split] splits the text into chunks.
+func (s *RegexSplitter) Split(text string) []Chunk {
+	seps := []separator{{re: s.Pattern, atStart: true}}
+	for _, sep := range DefaultSeparators {
+		if sep == "" {
+			seps = append(seps, separator{})
+			continue
+		}
+		seps = append(seps, separator{re: regexp.MustCompile(regexp.QuoteMeta(sep))})
+	}
+	return newMerger(s.ChunkSize, s.ChunkOverlap, s.Length).split(text, seps)
+}
+
+// separator splits where re matches, or into characters if re is nil.
+type separator struct {
+	re      *regexp.Regexp
+	atStart bool
+}
+
+// span is the range [start, end) of a piece in the text.
+type span struct {
+	start, end int
+}
+
+type merger struct {
+	size    int
+	overlap int
+	length  LengthFunc
+}
+
+func newMerger(size, overlap int, length LengthFunc) *merger {
+	if size <= 0 {
+		size = defaultChunkSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+	if length == nil {
+		length = utf8.RuneCountInString
+	}
+	return &merger{size: size, overlap: overlap, length: length}
+}
+
+func (m *merger) split(text string, seps []separator) []Chunk {
+	return toChunks(text, m.splitSpan(text, span{0, len(text)}, seps))
+}
+
+// splitSpan splits the span by the first separator found in it, merges the pieces fitting in the size,
+// and splits the others by the rest separators.
+func (m *merger) splitSpan(text string, sp span, seps []separator) []span {
+	sep, rest := separator{}, []separator(nil)
+	for i, s := range seps {
+		if s.re == nil || s.re.MatchString(text[sp.start:sp.end]) {
+			sep, rest = s, seps[i+1:]
+			break
+		}
+	}
+	var (
+		out     []span
+		good    []span
+		lengths []int
+	)
+	for _, piece := range cut(text, sp, sep) {
+		l := m.length(text[piece.start:piece.end])
+		if l <= m.size {
+			good = append(good, piece)
+			lengths = append(lengths, l)
+			continue
+		}
+		out = append(out, m.merge(good, lengths)...)
+		good, lengths = nil, nil
+		if sep.re == nil || len(rest) == 0 {
+			out = append(out, piece)
+			continue
+		}
+		out = append(out, m.splitSpan(text, piece, rest)...)
+	}
+	return append(out, m.merge(good, lengths)...)
+}
+
+// merge merges the adjacent pieces into the spans within the size, and starts each span after the
+// first with at most the overlap of the previous one.
+func (m *merger) merge(pieces []span, lengths []int) []span {
+	var out []span
+	first, total := 0, 0
+	for i := range pieces {
+		if i > first && total+lengths[i] > m.size {
+			out = append(out, span{pieces[first].start, pieces[i-1].end})
+			for first < i && (total > m.overlap || total+lengths[i] > m.size) {
+				total -= lengths[first]
+				first++
+			}
+		}
+		total += lengths[i]
+	}
+	if first < len(pieces) {
+		out = append(out, span{pieces[first].start, pieces[len(pieces)-1].end})
+	}
+	return out
+}
+
+// cut cuts the span into the pieces by the separator.
+func cut(text string, sp span, sep separator) []span {
+	var pieces []span
+	if sep.re == nil {
+		for pos := sp.start; pos < sp.end; {
+			_, size := utf8.DecodeRuneInString(text[pos:sp.end])
+			pieces = append(pieces, span{pos, pos + size})
+			pos += size
+		}
+		return pieces
+	}
+	start := sp.start
+	for _, loc := range sep.re.FindAllStringIndex(text[sp.start:sp.end], -1) {
+		at := sp.start + loc[1]
+		if sep.atStart || loc[0] == loc[1] {
+			at = sp.start + loc[0]
+		}
+		if at > start {
+			pieces = append(pieces, span{start, at})
+			start = at
+		}
+	}
+	if start < sp.end {
+		pieces = append(pieces, span{start, sp.end})
+	}
+	return pieces
+}
+
+// toChunks trims the spaces of the spans, and converts the non-empty ones into chunks.
+func toChunks(text string, spans []span) []Chunk {
+	chunks := make([]Chunk, 0, len(spans))
+	for _, sp := range spans {
+		piece := text[sp.start:sp.end]
+		trimmed := strings.TrimLeftFunc(piece, unicode.IsSpace)
+		start := sp.start + len(piece) - len(trimmed)
+		trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+		if trimmed == "" {
+			continue
+		}
+		chunks = append(chunks, Chunk{Text: trimmed, Index: len(chunks), StartPos: start, EndPos: start + len(trimmed)})
+	}
+	return chunks
+}
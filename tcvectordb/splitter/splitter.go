@@ -0,0 +1,131 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package splitter splits texts into chunks on the client side, to be embedded and upserted into the
+// base collections as documents, where the AI collections split the files on the server with
+// [tcvectordb.DocumentSplitterPreprocess].
+//
+// The splitters are:
+//   - [RecursiveCharacterSplitter], which splits by paragraphs, lines, sentences and words in turn until
+//     the chunks fit, and [NewTokenSplitter] measuring the chunks by the tokens of a tcvdbtext tokenizer.
+//   - [RegexSplitter], which splits where a regular expression matches, like the ChunkSplitter of the server.
+//   - [MarkdownHeaderSplitter] and [HTMLHeaderSplitter], which split by the headings, and keep the
+//     titles of the headings of each chunk, like the AllParentParagraphTitles of the server.
+//
+// A chunk keeps its offsets in the text, and becomes a document by [Chunk.Document] or [Documents]:
+//
+//	chunks := (&splitter.RecursiveCharacterSplitter{ChunkSize: 300, ChunkOverlap: 30}).Split(text)
+//	chunks = splitter.WithMetadata(chunks, map[string]interface{}{"source": "manual.md"})
+//	docs := splitter.Documents(chunks, "manual")
+package splitter
+
+import (
+	"fmt"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+// The fields of the documents converted from the chunks.
+const (
+	FieldText                     = "text"
+	FieldDocId                    = "doc_id"
+	FieldChunkIndex               = "chunk_index"
+	FieldStartPos                 = "start_pos"
+	FieldEndPos                   = "end_pos"
+	FieldParagraphTitle           = "paragraph_title"
+	FieldAllParentParagraphTitles = "all_parent_paragraph_titles"
+)
+
+// [Splitter] splits a text into chunks.
+type Splitter interface {
+	Split(text string) []Chunk
+}
+
+// [Chunk] holds a chunk of a text.
+//
+// Fields:
+//   - Text: The text of the chunk, with the leading and trailing spaces trimmed.
+//   - Index: The index of the chunk in the chunks of the text.
+//   - StartPos: The byte offset of the chunk in the text, so that Text is text[StartPos:EndPos].
+//     The offsets of [HTMLHeaderSplitter] are in the text extracted from the HTML.
+//   - EndPos: The byte offset of the end of the chunk in the text.
+//   - ParagraphTitle: The title of the heading the chunk is under, set by the heading splitters.
+//   - AllParentParagraphTitles: The titles of the parent headings of ParagraphTitle, from the top level.
+//   - Metadata: The fields added to the document of the chunk. See [WithMetadata] for more information.
+type Chunk struct {
+	Text                     string
+	Index                    int
+	StartPos                 int
+	EndPos                   int
+	ParagraphTitle           string
+	AllParentParagraphTitles []string
+	Metadata                 map[string]interface{}
+}
+
+// [Document] converts the chunk into a document with the id. The fields of the document are the text,
+// the index and the offsets of the chunk, the titles if they are set, and the metadata. The metadata named
+// id, vector or sparse_vector is skipped, since they are the reserved fields of the document.
+func (c *Chunk) Document(id string) tcvectordb.Document {
+	fields := map[string]tcvectordb.Field{
+		FieldText:       {Val: c.Text},
+		FieldChunkIndex: {Val: uint64(c.Index)},
+		FieldStartPos:   {Val: uint64(c.StartPos)},
+		FieldEndPos:     {Val: uint64(c.EndPos)},
+	}
+	if c.ParagraphTitle != "" {
+		fields[FieldParagraphTitle] = tcvectordb.Field{Val: c.ParagraphTitle}
+	}
+	if len(c.AllParentParagraphTitles) != 0 {
+		fields[FieldAllParentParagraphTitles] = tcvectordb.Field{Val: c.AllParentParagraphTitles}
+	}
+	for k, v := range c.Metadata {
+		if k == "id" || k == "vector" || k == "sparse_vector" {
+			continue
+		}
+		fields[k] = tcvectordb.Field{Val: v}
+	}
+	return tcvectordb.Document{Id: id, Fields: fields}
+}
+
+// [Documents] converts the chunks of a text into the documents, whose ids are "<idPrefix>-<index>",
+// and whose doc_id fields are idPrefix, so the neighbors of a chunk are found by [tcvectordb.Retrieve].
+func Documents(chunks []Chunk, idPrefix string) []tcvectordb.Document {
+	docs := make([]tcvectordb.Document, 0, len(chunks))
+	for i := range chunks {
+		doc := chunks[i].Document(fmt.Sprintf("%s-%d", idPrefix, chunks[i].Index))
+		doc.Fields[FieldDocId] = tcvectordb.Field{Val: idPrefix}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// [WithMetadata] adds the metadata, such as the source of the text, to the metadata of each chunk,
+// and returns the chunks.
+func WithMetadata(chunks []Chunk, metadata map[string]interface{}) []Chunk {
+	for i := range chunks {
+		merged := make(map[string]interface{}, len(chunks[i].Metadata)+len(metadata))
+		for k, v := range chunks[i].Metadata {
+			merged[k] = v
+		}
+		for k, v := range metadata {
+			merged[k] = v
+		}
+		chunks[i].Metadata = merged
+	}
+	return chunks
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"log"
+	"testing"
+)
+
+func TestListAliases(t *testing.T) {
+	db := cli.Database(database)
+	_, err := db.SetAlias(ctx, collectionName, collectionAlias)
+	printErr(err)
+
+	listRes, err := db.ListAliases(ctx)
+	printErr(err)
+	for _, item := range listRes.Aliases {
+		log.Printf("alias: %v -> %v", item.Alias, item.Collection)
+	}
+
+	getRes, err := db.GetAlias(ctx, collectionAlias)
+	printErr(err)
+	log.Printf("alias %v points to %v", getRes.Alias, getRes.Collection)
+}
+
+func TestSwapAlias(t *testing.T) {
+	db := cli.Database(database)
+	swapRes, err := db.SwapAlias(ctx, collectionName, collectionAlias)
+	printErr(err)
+	log.Printf("alias %v is swapped from %v to %v", collectionAlias, swapRes.PreviousCollection, collectionName)
+}
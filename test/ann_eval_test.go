@@ -0,0 +1,106 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"log"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestEvaluateAnn(t *testing.T) {
+	report, err := tcvectordb.EvaluateAnn(ctx, cli, database, collectionName, tcvectordb.AnnEvalParams{
+		Settings: []tcvectordb.AnnEvalSetting{
+			{Params: &tcvectordb.SearchDocParams{Ef: 16}},
+			{Params: &tcvectordb.SearchDocParams{Ef: 64}},
+			{Params: &tcvectordb.SearchDocParams{Ef: 256}},
+		},
+		SampleSize:   20,
+		TopK:         3,
+		RecallTarget: 0.95,
+	})
+	printErr(err)
+	var buf bytes.Buffer
+	printErr(report.WriteJSON(&buf))
+	log.Println(buf.String())
+}
+
+// annEvalClient serves the documents of the collection and searches them by the exact order of the L2 distances,
+// truncated to the ef of the search to imitate a lossy index.
+type annEvalClient struct {
+	tcvectordb.FlatDocumentInterface
+	docs []tcvectordb.Document
+}
+
+func (c *annEvalClient) Query(ctx context.Context, databaseName, collectionName string, documentIds []string,
+	params ...*tcvectordb.QueryDocumentParams) (*tcvectordb.QueryDocumentResult, error) {
+	param := params[0]
+	res := new(tcvectordb.QueryDocumentResult)
+	for i := param.Offset; i < int64(len(c.docs)) && i < param.Offset+param.Limit; i++ {
+		res.Documents = append(res.Documents, c.docs[i])
+	}
+	return res, nil
+}
+
+func (c *annEvalClient) Search(ctx context.Context, databaseName, collectionName string, vectors [][]float32,
+	params ...*tcvectordb.SearchDocumentParams) (*tcvectordb.SearchDocumentResult, error) {
+	param := params[0]
+	res := new(tcvectordb.SearchDocumentResult)
+	for _, vector := range vectors {
+		var docs []tcvectordb.Document
+		for _, doc := range c.docs {
+			d := (doc.Vector[0] - vector[0]) * (doc.Vector[0] - vector[0])
+			if param.Params != nil && d > float32(param.Params.Ef) {
+				continue
+			}
+			docs = append(docs, doc)
+		}
+		if int64(len(docs)) > param.Limit {
+			docs = docs[:param.Limit]
+		}
+		res.Documents = append(res.Documents, docs)
+	}
+	return res, nil
+}
+
+func TestEvaluateAnnRecall(t *testing.T) {
+	client := &annEvalClient{docs: []tcvectordb.Document{
+		{Id: "0001", Vector: []float32{0}},
+		{Id: "0002", Vector: []float32{1}},
+		{Id: "0003", Vector: []float32{2}},
+		{Id: "0004", Vector: []float32{3}},
+	}}
+	report, err := tcvectordb.EvaluateAnn(ctx, client, database, collectionName, tcvectordb.AnnEvalParams{
+		Settings: []tcvectordb.AnnEvalSetting{
+			{Name: "narrow", Params: &tcvectordb.SearchDocParams{Ef: 0}},
+			{Name: "wide", Params: &tcvectordb.SearchDocParams{Ef: 100}},
+		},
+		QueryVectors: [][]float32{{0}},
+		TopK:         2,
+		MetricType:   tcvectordb.L2,
+		RecallTarget: 0.9,
+	})
+	if err != nil {
+		t.Fatalf("evaluate failed: %v", err)
+	}
+	if report.Results[0].Recall != 0.5 || report.Results[1].Recall != 1 {
+		t.Fatalf("unexpected recalls: %v, %v", report.Results[0].Recall, report.Results[1].Recall)
+	}
+	if report.Recommended == nil || report.Recommended.Name != "wide" {
+		t.Fatalf("unexpected recommendation: %+v", report.Recommended)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("write csv failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv failed: %v", err)
+	}
+	if len(records) != 3 || records[2][len(records[2])-1] != "true" {
+		t.Fatalf("unexpected csv: %v", records)
+	}
+}
@@ -0,0 +1,41 @@
+package test
+
+import (
+	"log"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestAutoSplitSearchAndDelete(t *testing.T) {
+	splitCli, err := tcvectordb.NewClient(url, username, key, &tcvectordb.ClientOption{
+		Timeout:         10 * time.Second,
+		ReadConsistency: tcvectordb.StrongConsistency,
+		AutoSplit:       &tcvectordb.AutoSplitOption{MaxSearchVectors: 20, MaxDocumentIds: 20},
+	})
+	printErr(err)
+	splitCli.Debug(true)
+
+	vectors := make([][]float32, 0, 45)
+	for i := 0; i < 45; i++ {
+		vectors = append(vectors, []float32{0.3123, 0.43, float32(i) / 45})
+	}
+	searchRes, err := splitCli.Search(ctx, database, collectionName, vectors, &tcvectordb.SearchDocumentParams{
+		Params: &tcvectordb.SearchDocParams{Ef: 100},
+		Limit:  2,
+	})
+	printErr(err)
+	log.Printf("search %v vectors, got %v results, warning: %v", len(vectors), len(searchRes.Documents), searchRes.Warning)
+
+	documentIds := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		documentIds = append(documentIds, "not_exist_"+strconv.Itoa(i))
+	}
+	deleteRes, err := splitCli.Delete(ctx, database, collectionName, tcvectordb.DeleteDocumentParams{
+		DocumentIds: documentIds,
+	})
+	printErr(err)
+	log.Printf("delete affected count: %v", deleteRes.AffectedCount)
+}
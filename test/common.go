@@ -13,6 +13,9 @@ var (
 	cli *tcvectordb.Client
 	//cli                    *tcvectordb.RpcClient
 	ctx                    = context.Background()
+	url                    = ""
+	username               = "root"
+	key                    = ""
 	database               = "go-sdk-test-db"
 	collectionName         = "go-sdk-test-coll"
 	collectionAlias        = "go-sdk-test-alias"
@@ -23,8 +26,8 @@ var (
 func init() {
 	// 初始化客户端
 	var err error
-	cli, err = tcvectordb.NewClient("", "root",
-		"", &tcvectordb.ClientOption{Timeout: 10 * time.Second,
+	cli, err = tcvectordb.NewClient(url, username,
+		key, &tcvectordb.ClientOption{Timeout: 10 * time.Second,
 			ReadConsistency: tcvectordb.StrongConsistency})
 
 	if err != nil {
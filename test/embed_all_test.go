@@ -0,0 +1,139 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+// embeddingTransport serves the embedding api, which embeds a text "text-<n>" into {n} and {"n": 1},
+// fails the first call of each batch, and records the batch sizes and the concurrent calls.
+type embeddingTransport struct {
+	mu      sync.Mutex
+	failed  map[string]bool
+	batches []int
+	running int
+	peak    int
+}
+
+func (e *embeddingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var req struct {
+		Data []string `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	e.running++
+	if e.running > e.peak {
+		e.peak = e.running
+	}
+	retried := e.failed[req.Data[0]]
+	e.failed[req.Data[0]] = true
+	if retried {
+		e.batches = append(e.batches, len(req.Data))
+	}
+	e.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	e.mu.Lock()
+	e.running--
+	e.mu.Unlock()
+
+	res := map[string]interface{}{"code": 0, "msg": "operation success"}
+	if !retried {
+		res = map[string]interface{}{"code": 1, "msg": "service busy"}
+	} else {
+		var dense [][]float32
+		var sparse []map[string]float32
+		for _, text := range req.Data {
+			n, _ := strconv.Atoi(strings.TrimPrefix(text, "text-"))
+			dense = append(dense, []float32{float32(n)})
+			sparse = append(sparse, map[string]float32{strconv.Itoa(n): 1})
+		}
+		res["tokenUsed"] = len(req.Data)
+		res["denseVector"] = dense
+		res["sparseVector"] = sparse
+	}
+	body, _ := json.Marshal(res)
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}},
+		Body: io.NopCloser(bytes.NewReader(body)), Request: r}, nil
+}
+
+func TestEmbedAll(t *testing.T) {
+	transport := &embeddingTransport{failed: make(map[string]bool)}
+	cli, err := tcvectordb.NewClient("http://embedding", "root", "key", &tcvectordb.ClientOption{Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	texts := make([]string, 99)
+	for i := range texts {
+		texts[i] = "text-" + strconv.Itoa(i)
+	}
+	texts[50] = strings.Repeat("长", 30) + "text-50"
+
+	result, err := tcvectordb.EmbedAll(context.Background(), cli, "bge-base-zh", texts, &tcvectordb.EmbedAllOption{
+		BatchSize:      8,
+		MaxBatchTokens: 20,
+		Concurrency:    3,
+		RetryInterval:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TokenUsed != 99 || len(result.Vectors) != 99 || len(result.SparseVectors) != 99 {
+		t.Fatalf("unexpected result: %v tokens, %v vectors, %v sparse vectors",
+			result.TokenUsed, len(result.Vectors), len(result.SparseVectors))
+	}
+	for i := range texts {
+		if i != 50 && (result.Vectors[i][0] != float32(i) || result.SparseVectors[i][strconv.Itoa(i)] != 1) {
+			t.Fatalf("vector %v is out of order: %v", i, result.Vectors[i])
+		}
+	}
+	singles := 0
+	for _, size := range transport.batches {
+		if size > 8 {
+			t.Fatalf("batch of %v texts exceeds the batch size", size)
+		}
+		if size == 1 {
+			singles++
+		}
+	}
+	if singles != 1 {
+		t.Fatalf("the long text was not embedded alone: %v", transport.batches)
+	}
+	if transport.peak > 3 {
+		t.Fatalf("%v concurrent calls exceed the concurrency", transport.peak)
+	}
+
+	empty, err := tcvectordb.EmbedAll(context.Background(), cli, "bge-base-zh", nil)
+	if err != nil || len(empty.Vectors) != 0 {
+		t.Fatalf("unexpected result of no texts: %+v, %v", empty, err)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/local"
+)
+
+// newFakeEmbeddingServer serves an OpenAI-compatible embedding api, which embeds the known words
+// into the axes, and counts the embedded texts.
+func newFakeEmbeddingServer(t *testing.T, embedded *int64) *httptest.Server {
+	axes := map[string]int{"apple": 0, "banana": 1, "cherry": 2}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" || r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		atomic.AddInt64(embedded, int64(len(req.Input)))
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		var res struct {
+			Data  []item `json:"data"`
+			Usage struct {
+				TotalTokens int `json:"total_tokens"`
+			} `json:"usage"`
+		}
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vector := make([]float32, 4)
+			axis, ok := axes[req.Input[i]]
+			if !ok {
+				axis = 3
+			}
+			vector[axis] = 1
+			res.Data = append(res.Data, item{Index: i, Embedding: vector})
+		}
+		res.Usage.TotalTokens = len(req.Input)
+		json.NewEncoder(w).Encode(res)
+	}))
+}
+
+func TestClientEmbedding(t *testing.T) {
+	ctx := context.Background()
+	var embedded int64
+	server := newFakeEmbeddingServer(t, &embedded)
+	defer server.Close()
+	engine, err := local.Open("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+	cli, err := tcvectordb.NewClient("http://local", "root", "key", &tcvectordb.ClientOption{
+		Transport: engine,
+		ClientEmbedding: map[string]*tcvectordb.ClientEmbeddingConfig{
+			"db/fruits": {
+				Embedder:  &tcvectordb.OpenAIEmbedder{BaseURL: server.URL, APIKey: "key", Model: "fake"},
+				Field:     "text",
+				BatchSize: 2,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.CreateDatabaseIfNotExists(ctx, "db"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Database("db").CreateCollection(ctx, "fruits", 1, 1, "", localIndexes(tcvectordb.FLAT, tcvectordb.IP, 4)); err != nil {
+		t.Fatal(err)
+	}
+
+	docs := []tcvectordb.Document{
+		{Id: "0001", Fields: map[string]tcvectordb.Field{"text": {Val: "apple"}}},
+		{Id: "0002", Fields: map[string]tcvectordb.Field{"text": {Val: "banana"}}},
+		{Id: "0003", Fields: map[string]tcvectordb.Field{"text": {Val: "cherry"}}},
+		{Id: "0004", Fields: map[string]tcvectordb.Field{"text": {Val: "apple"}}},
+		{Id: "0005", Vector: []float32{0, 0, 0, 1}, Fields: map[string]tcvectordb.Field{"text": {Val: "durian"}}},
+	}
+	upserted, err := cli.Upsert(ctx, "db", "fruits", docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upserted.EmbeddingExtraInfo == nil || upserted.EmbeddingExtraInfo.TokenUsed != 3 {
+		t.Fatalf("upsert used %+v, want 3 tokens", upserted.EmbeddingExtraInfo)
+	}
+	if embedded != 3 {
+		t.Fatalf("embedded %v texts, want 3", embedded)
+	}
+	if docs[0].Vector != nil {
+		t.Fatal("upsert changed the documents of the caller")
+	}
+
+	res, err := cli.SearchByText(ctx, "db", "fruits", map[string][]string{"text": {"banana", "apple"}},
+		&tcvectordb.SearchDocumentParams{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Documents) != 2 || res.Documents[0][0].Id != "0002" || res.Documents[1][0].Score != 1 {
+		t.Fatalf("unexpected search result: %+v", res.Documents)
+	}
+	if res.EmbeddingExtraInfo != nil || embedded != 3 {
+		t.Fatalf("cached texts were embedded again: %+v, %v", res.EmbeddingExtraInfo, embedded)
+	}
+
+	limit := 1
+	hybrid, err := cli.HybridSearch(ctx, "db", "fruits", tcvectordb.HybridSearchDocumentParams{
+		AnnParams: []*tcvectordb.AnnParam{{FieldName: "vector", Data: "cherry"}},
+		Limit:     &limit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hybrid.Documents) != 1 || len(hybrid.Documents[0]) != 1 || hybrid.Documents[0][0].Id != "0003" {
+		t.Fatalf("unexpected hybrid search result: %+v", hybrid.Documents)
+	}
+
+	if _, err := cli.SearchByText(ctx, "db", "fruits", map[string][]string{"title": {"banana"}}); err == nil {
+		t.Fatal("search by the text of another field succeeded, want an error")
+	}
+	if _, err := cli.HybridSearch(ctx, "db", "fruits", tcvectordb.HybridSearchDocumentParams{
+		AnnParams: []*tcvectordb.AnnParam{{Data: "cherry"}},
+		Limit:     &limit,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := cli.Update(ctx, "db", "fruits", tcvectordb.UpdateDocumentParams{
+		QueryIds:     []string{"0001"},
+		UpdateFields: map[string]interface{}{"text": "kiwi"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.EmbeddingExtraInfo == nil || updated.EmbeddingExtraInfo.TokenUsed != 1 {
+		t.Fatalf("update used %+v, want 1 token", updated.EmbeddingExtraInfo)
+	}
+	queried, err := cli.Query(ctx, "db", "fruits", []string{"0001"}, &tcvectordb.QueryDocumentParams{RetrieveVector: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queried.Documents) != 1 || queried.Documents[0].Vector[3] != 1 {
+		t.Fatalf("update did not embed the text: %+v", queried.Documents)
+	}
+}
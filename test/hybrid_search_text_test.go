@@ -0,0 +1,99 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/encoder"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/local"
+)
+
+// termEncoder encodes each known word of the text as a term of score 1.
+type termEncoder map[string]int64
+
+func (e termEncoder) EncodeQuery(text string) ([]encoder.SparseVecItem, error) {
+	var items []encoder.SparseVecItem
+	for _, word := range strings.Fields(text) {
+		if termId, ok := e[word]; ok {
+			items = append(items, encoder.SparseVecItem{TermId: termId, Score: 1})
+		}
+	}
+	return items, nil
+}
+
+func TestHybridSearchByText(t *testing.T) {
+	ctx := context.Background()
+	cli, err := local.NewClient("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	indexes := localIndexes(tcvectordb.FLAT, tcvectordb.IP, 4)
+	indexes.SparseVectorIndex = []tcvectordb.SparseVectorIndex{
+		{FieldName: "sparse_vector", FieldType: tcvectordb.SparseVector, IndexType: tcvectordb.SPARSE_INVERTED, MetricType: tcvectordb.IP},
+	}
+	newLocalCollection(t, cli, "hybrid", indexes)
+
+	docs := localDocuments()
+	docs[0].SparseVector = []encoder.SparseVecItem{{TermId: 1, Score: 0.1}}
+	docs[1].SparseVector = []encoder.SparseVecItem{{TermId: 1, Score: 0.9}, {TermId: 2, Score: 0.5}}
+	docs[2].SparseVector = []encoder.SparseVecItem{{TermId: 3, Score: 1}}
+	docs[3].SparseVector = []encoder.SparseVecItem{{TermId: 2, Score: 0.2}}
+	if _, err := cli.Upsert(ctx, "db", "hybrid", docs); err != nil {
+		t.Fatal(err)
+	}
+
+	embedder := new(keywordEmbedder)
+	params := &tcvectordb.HybridSearchByTextParams{
+		Embedder:      embedder,
+		SparseEncoder: termEncoder{"banana": 1},
+		Limit:         2,
+	}
+	// "apple" ranks 0001 1st by the dense vector, and "banana" ranks 0002 1st by the sparse vector.
+	result, err := tcvectordb.HybridSearchByText(ctx, cli, "db", "hybrid", "apple banana", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(result.Documents[0]); got != "0001,0002" {
+		t.Fatalf("hybrid search by text returned %v, want 0001,0002", got)
+	}
+	if result.EmbeddingExtraInfo == nil || result.EmbeddingExtraInfo.TokenUsed != 1 {
+		t.Fatalf("hybrid search by text used %+v, want 1 token", result.EmbeddingExtraInfo)
+	}
+
+	// Without any known term, only the dense vector is searched.
+	result, err = tcvectordb.HybridSearchByText(ctx, cli, "db", "hybrid", "apple pie", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(result.Documents[0]); got != "0001,0004" {
+		t.Fatalf("dense search by text returned %v, want 0001,0004", got)
+	}
+	if embedder.calls != 2 {
+		t.Fatalf("embedder called %v times, want 2", embedder.calls)
+	}
+
+	if _, err := tcvectordb.HybridSearchByText(ctx, cli, "db", "hybrid", "", params); err == nil {
+		t.Fatal("hybrid search by empty text succeeded, want an error")
+	}
+}
@@ -0,0 +1,239 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestValidateVectorIndex(t *testing.T) {
+	cases := []struct {
+		name  string
+		index tcvectordb.VectorIndex
+		ok    bool
+	}{
+		{
+			name: "hnsw",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.HNSW},
+				Dimension:   768, MetricType: tcvectordb.COSINE,
+				Params: &tcvectordb.HNSWParam{M: 16, EfConstruction: 200},
+			},
+			ok: true,
+		},
+		{
+			name: "hnsw m out of range",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.HNSW},
+				Dimension:   768, MetricType: tcvectordb.COSINE,
+				Params: &tcvectordb.HNSWParam{M: 128, EfConstruction: 200},
+			},
+		},
+		{
+			name: "params mismatch",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.IVF_FLAT},
+				Dimension:   768, MetricType: tcvectordb.L2,
+				Params: &tcvectordb.HNSWParam{M: 16, EfConstruction: 200},
+			},
+		},
+		{
+			name: "ivf nlist out of range",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.IVF_FLAT},
+				Dimension:   768, MetricType: tcvectordb.L2,
+				Params: &tcvectordb.IVFFLATParams{NList: 100000},
+			},
+		},
+		{
+			name: "pq dimension not divisible",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.IVF_PQ},
+				Dimension:   100, MetricType: tcvectordb.L2,
+				Params: &tcvectordb.IVFPQParams{M: 16, NList: 1024},
+			},
+		},
+		{
+			name: "pq m out of range",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.IVF_PQ},
+				MetricType:  tcvectordb.L2,
+				Params:      &tcvectordb.IVFPQParams{M: 8192, NList: 1024},
+			},
+		},
+		{
+			name: "index type from params",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector},
+				Dimension:   768, MetricType: tcvectordb.COSINE,
+				Params: &tcvectordb.HNSWParam{M: 16, EfConstruction: 200},
+			},
+			ok: true,
+		},
+		{
+			name: "index type from invalid params",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector},
+				Dimension:   768, MetricType: tcvectordb.COSINE,
+				Params: &tcvectordb.HNSWParam{M: 128},
+			},
+		},
+		{
+			name: "no index type",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector},
+				Dimension:   768, MetricType: tcvectordb.COSINE,
+			},
+		},
+		{
+			name: "sq type mismatch",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.IVF_SQ16},
+				Dimension:   768, MetricType: tcvectordb.L2,
+				Params: &tcvectordb.IVFSQParams{NList: 1024, IndexType: tcvectordb.IVF_SQ4},
+			},
+		},
+		{
+			name: "hamming on float vector",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.FLAT},
+				Dimension:   768, MetricType: tcvectordb.HAMMING,
+			},
+		},
+		{
+			name: "bin hnsw",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.BinaryVector, IndexType: tcvectordb.BIN_HNSW},
+				Dimension:   16, MetricType: tcvectordb.HAMMING,
+				Params: &tcvectordb.BINHNSWParams{M: 16, EfConstruction: 200},
+			},
+			ok: true,
+		},
+		{
+			name: "binary vector with hnsw",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.BinaryVector, IndexType: tcvectordb.HNSW},
+				Dimension:   16, MetricType: tcvectordb.HAMMING,
+			},
+		},
+		{
+			name: "binary dimension",
+			index: tcvectordb.VectorIndex{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.BinaryVector, IndexType: tcvectordb.BIN_FLAT},
+				Dimension:   12, MetricType: tcvectordb.HAMMING,
+			},
+		},
+	}
+	for _, c := range cases {
+		err := c.index.Validate()
+		if c.ok && err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.name, err)
+		}
+		if !c.ok && err == nil {
+			t.Fatalf("%v: expected an error", c.name)
+		}
+		t.Logf("%v: %v", c.name, err)
+	}
+}
+
+func TestValidateIndexes(t *testing.T) {
+	indexes := tcvectordb.Indexes{
+		VectorIndex: []tcvectordb.VectorIndex{
+			{
+				FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.HNSW},
+				Dimension:   3, MetricType: tcvectordb.COSINE,
+			},
+		},
+		SparseVectorIndex: []tcvectordb.SparseVectorIndex{
+			{FieldName: "sparse_vector", FieldType: tcvectordb.SparseVector, IndexType: tcvectordb.SPARSE_INVERTED, MetricType: tcvectordb.IP},
+		},
+		FilterIndex: []tcvectordb.FilterIndex{
+			{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY},
+		},
+	}
+	if err := indexes.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	indexes.FilterIndex = append(indexes.FilterIndex, tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER})
+	if err := indexes.Validate(); err == nil {
+		t.Fatalf("expected an error for the duplicated field name")
+	}
+
+	indexes.FilterIndex = indexes.FilterIndex[:1]
+	indexes.SparseVectorIndex[0].MetricType = tcvectordb.L2
+	if err := indexes.Validate(); err == nil {
+		t.Fatalf("expected an error for the sparse vector metric type")
+	}
+
+	indexes.SparseVectorIndex[0].MetricType = tcvectordb.IP
+	indexes.FilterIndex = append(indexes.FilterIndex, tcvectordb.FilterIndex{FieldName: "page", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.HNSW})
+	if err := indexes.Validate(); err == nil {
+		t.Fatalf("expected an error for the filter index type")
+	}
+}
+
+func TestValidateAddIndex(t *testing.T) {
+	params := &tcvectordb.AddIndexParams{FilterIndexs: []tcvectordb.FilterIndex{
+		{FieldName: "page", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER},
+		{FieldName: "tags", FieldType: tcvectordb.Array, ElemType: tcvectordb.String, IndexType: tcvectordb.FILTER},
+	}}
+	if err := params.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := []struct {
+		name  string
+		index tcvectordb.FilterIndex
+	}{
+		{"primary key", tcvectordb.FilterIndex{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY}},
+		{"vector field type", tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.FILTER}},
+		{"element type of string", tcvectordb.FilterIndex{FieldName: "author", FieldType: tcvectordb.String, ElemType: tcvectordb.String, IndexType: tcvectordb.FILTER}},
+		{"duplicated field name", tcvectordb.FilterIndex{FieldName: "page", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER}},
+	}
+	for _, c := range cases {
+		invalid := &tcvectordb.AddIndexParams{FilterIndexs: append(append([]tcvectordb.FilterIndex{}, params.FilterIndexs...), c.index)}
+		if err := invalid.Validate(); err == nil {
+			t.Fatalf("%v: expected an error", c.name)
+		}
+	}
+	// AddIndex validates the indexes before the request goes out.
+	err := cli.AddIndex(ctx, database, collectionName, &tcvectordb.AddIndexParams{FilterIndexs: []tcvectordb.FilterIndex{cases[0].index}})
+	if err == nil {
+		t.Fatal("adding the primary key succeeded, want an error")
+	}
+}
+
+func TestIVFSQParamsName(t *testing.T) {
+	if name := (&tcvectordb.IVFSQParams{NList: 1024}).Name(); name != string(tcvectordb.IVF_SQ8) {
+		t.Fatalf("default name should be %v, got %v", tcvectordb.IVF_SQ8, name)
+	}
+	if name := (&tcvectordb.IVFSQParams{NList: 1024, IndexType: tcvectordb.IVF_SQ4}).Name(); name != string(tcvectordb.IVF_SQ4) {
+		t.Fatalf("name should be %v, got %v", tcvectordb.IVF_SQ4, name)
+	}
+}
+
+func TestValidateModifyVectorIndex(t *testing.T) {
+	modify := tcvectordb.ModifyVectorIndex{
+		FieldName:  "vector",
+		FieldType:  string(tcvectordb.BFloat16Vector),
+		MetricType: tcvectordb.COSINE,
+		Params:     &tcvectordb.HNSWParam{M: 8},
+	}
+	if err := modify.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	modify.Params = &tcvectordb.HNSWParam{M: 2}
+	if err := modify.Validate(); err == nil {
+		t.Fatalf("expected an error for M out of range")
+	}
+
+	sparse := tcvectordb.ModifyVectorIndex{FieldName: "sparse_vector", FieldType: string(tcvectordb.SparseVector),
+		MetricType: tcvectordb.IP}
+	if err := sparse.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sparse.Params = &tcvectordb.HNSWParam{M: 16}
+	if err := sparse.Validate(); err == nil {
+		t.Fatalf("expected an error for the sparse vector params")
+	}
+}
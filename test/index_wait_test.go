@@ -0,0 +1,110 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestWaitForTasks(t *testing.T) {
+	res, err := cli.RebuildIndex(ctx, database, collectionName, &tcvectordb.RebuildIndexParams{
+		DropBeforeRebuild: false,
+		Throttle:          1,
+	})
+	printErr(err)
+	log.Printf("rebuild index task ids: %v", res.TaskIds)
+
+	err = tcvectordb.WaitForTasks(ctx, cli, database, collectionName, res.TaskIds, &tcvectordb.WaitIndexOptions{
+		InitialDelay: time.Second,
+		Timeout:      5 * time.Minute,
+		Progress: func(progress tcvectordb.IndexProgress) {
+			log.Printf("status: %v, indexed: %v/%v, elapsed: %v", progress.Status,
+				progress.IndexedCount, progress.DocumentCount, progress.Elapsed)
+		},
+	})
+	var buildErr *tcvectordb.IndexBuildError
+	if errors.As(err, &buildErr) {
+		log.Printf("index build failed: %v", buildErr)
+	}
+	printErr(err)
+}
+
+func TestWaitForIndexReady(t *testing.T) {
+	err := tcvectordb.WaitForIndexReady(ctx, cli, database, collectionName, &tcvectordb.WaitIndexOptions{
+		MaxInterval: 10 * time.Second,
+		Timeout:     time.Minute,
+	})
+	printErr(err)
+}
+
+// statusSequence describes the collection with the index statuses in turn, repeating the last one.
+type statusSequence struct {
+	tcvectordb.FlatIndexInterface
+	statuses []tcvectordb.IndexStatus
+	polls    int
+}
+
+func (s *statusSequence) DescribeCollection(ctx context.Context, databaseName, collectionName string) (*tcvectordb.DescribeCollectionResult, error) {
+	i := s.polls
+	if i >= len(s.statuses) {
+		i = len(s.statuses) - 1
+	}
+	s.polls++
+	result := new(tcvectordb.DescribeCollectionResult)
+	result.IndexStatus = s.statuses[i]
+	return result, nil
+}
+
+func TestWaitForIndexReadySkipsStaleReady(t *testing.T) {
+	// The server reports the start time in its local time without the zone, which may be behind the client.
+	previous := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	stale := tcvectordb.IndexStatus{Status: tcvectordb.IndexStatusReady, StartTime: previous}
+	opts := &tcvectordb.WaitIndexOptions{InitialInterval: time.Millisecond, PreviousStartTime: &previous, Timeout: time.Minute}
+
+	cases := []struct {
+		name     string
+		statuses []tcvectordb.IndexStatus
+		polls    int
+	}{
+		{"ready after building", []tcvectordb.IndexStatus{stale, stale,
+			{Status: "building", StartTime: previous}, stale}, 4},
+		{"build started in an earlier zone", []tcvectordb.IndexStatus{stale,
+			{Status: tcvectordb.IndexStatusReady, StartTime: previous.Add(-7 * time.Hour)}}, 2},
+	}
+	for _, c := range cases {
+		cli := &statusSequence{statuses: c.statuses}
+		if err := tcvectordb.WaitForIndexReady(context.Background(), cli, "db", "coll", opts); err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+		if cli.polls != c.polls {
+			t.Fatalf("%v: polled %v times, want %v", c.name, cli.polls, c.polls)
+		}
+	}
+
+	// The stale ready status is accepted once the build is not reported within the start timeout.
+	cli := &statusSequence{statuses: []tcvectordb.IndexStatus{stale}}
+	err := tcvectordb.WaitForIndexReady(context.Background(), cli, "db", "coll", &tcvectordb.WaitIndexOptions{
+		InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, PreviousStartTime: &previous,
+		StartTimeout: 20 * time.Millisecond})
+	if err != nil || cli.polls < 2 {
+		t.Fatalf("start timeout: polled %v times, err: %v", cli.polls, err)
+	}
+
+	// The build of the tasks is waited for without the previous start time.
+	cli = &statusSequence{statuses: []tcvectordb.IndexStatus{stale, {Status: "building", StartTime: previous}, stale}}
+	err = tcvectordb.WaitForTasks(context.Background(), cli, "db", "coll", []string{"task"},
+		&tcvectordb.WaitIndexOptions{InitialInterval: time.Millisecond, Timeout: time.Minute})
+	if err != nil || cli.polls != 3 {
+		t.Fatalf("wait for tasks: polled %v times, err: %v", cli.polls, err)
+	}
+
+	// No build is waited for without any task.
+	cli = &statusSequence{statuses: []tcvectordb.IndexStatus{stale}}
+	if err := tcvectordb.WaitForTasks(context.Background(), cli, "db", "coll", nil, opts); err != nil || cli.polls != 0 {
+		t.Fatalf("wait for no tasks: polled %v times, err: %v", cli.polls, err)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	api_user "github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/user"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+// fakeInstance keeps the databases, collections, aliases and users of an instance in memory.
+type fakeInstance struct {
+	spec.Client
+	databases map[string]*fakeDatabase
+	handles   map[string]*fakeDatabase
+	users     map[string][]api_user.Privilege
+}
+
+type fakeDatabase struct {
+	tcvectordb.CollectionInterface
+	name        string
+	collections map[string]*tcvectordb.Collection
+	aliases     *fakeAliases
+}
+
+type fakeAliases struct {
+	tcvectordb.AliasInterface
+	aliases map[string]string
+}
+
+func newFakeInstance() *fakeInstance {
+	return &fakeInstance{
+		databases: make(map[string]*fakeDatabase),
+		handles:   make(map[string]*fakeDatabase),
+		users:     map[string][]api_user.Privilege{"root": nil},
+	}
+}
+
+func (f *fakeInstance) ListDatabase(ctx context.Context) (*tcvectordb.ListDatabaseResult, error) {
+	res := new(tcvectordb.ListDatabaseResult)
+	for name := range f.databases {
+		res.Databases = append(res.Databases, tcvectordb.Database{DatabaseName: name})
+	}
+	return res, nil
+}
+
+// handle returns the database with the name, which exists only after it is created, like the
+// databases returned by [tcvectordb.Client.Database].
+func (f *fakeInstance) handle(name string) *fakeDatabase {
+	if f.handles[name] == nil {
+		f.handles[name] = &fakeDatabase{
+			name:        name,
+			collections: make(map[string]*tcvectordb.Collection),
+			aliases:     &fakeAliases{aliases: make(map[string]string)},
+		}
+	}
+	return f.handles[name]
+}
+
+func (f *fakeInstance) CreateDatabaseIfNotExists(ctx context.Context, name string) (*tcvectordb.CreateDatabaseResult, error) {
+	f.databases[name] = f.handle(name)
+	return new(tcvectordb.CreateDatabaseResult), nil
+}
+
+func (f *fakeInstance) DropDatabase(ctx context.Context, name string) (*tcvectordb.DropDatabaseResult, error) {
+	delete(f.databases, name)
+	delete(f.handles, name)
+	return new(tcvectordb.DropDatabaseResult), nil
+}
+
+func (f *fakeInstance) Database(name string) *tcvectordb.Database {
+	db := f.handle(name)
+	return &tcvectordb.Database{CollectionInterface: db, AliasInterface: db.aliases, DatabaseName: name}
+}
+
+func (f *fakeInstance) AddIndex(ctx context.Context, databaseName, collectionName string, params ...*tcvectordb.AddIndexParams) error {
+	coll := f.databases[databaseName].collections[collectionName]
+	coll.Indexes.FilterIndex = append(coll.Indexes.FilterIndex, params[0].FilterIndexs...)
+	return nil
+}
+
+func (f *fakeInstance) ListUser(ctx context.Context) (*tcvectordb.ListUserResult, error) {
+	res := new(tcvectordb.ListUserResult)
+	for user, privileges := range f.users {
+		res.Users = append(res.Users, api_user.UserPrivileges{User: user, Privileges: privileges})
+	}
+	return res, nil
+}
+
+func (f *fakeInstance) CreateUser(ctx context.Context, param tcvectordb.CreateUserParams) error {
+	f.users[param.User] = nil
+	return nil
+}
+
+func (f *fakeInstance) GrantToUser(ctx context.Context, param tcvectordb.GrantToUserParams) error {
+	for _, p := range param.Privileges {
+		f.users[param.User] = append(f.users[param.User], *p)
+	}
+	return nil
+}
+
+func (f *fakeInstance) DropUser(ctx context.Context, param tcvectordb.DropUserParams) error {
+	delete(f.users, param.User)
+	return nil
+}
+
+func (d *fakeDatabase) ListCollection(ctx context.Context) (*tcvectordb.ListCollectionResult, error) {
+	res := new(tcvectordb.ListCollectionResult)
+	for _, coll := range d.collections {
+		res.Collections = append(res.Collections, coll)
+	}
+	return res, nil
+}
+
+func (d *fakeDatabase) CreateCollectionIfNotExists(ctx context.Context, name string, shardNum, replicasNum uint32,
+	description string, indexes tcvectordb.Indexes, params ...*tcvectordb.CreateCollectionParams) (*tcvectordb.Collection, error) {
+	if d.collections[name] == nil {
+		d.collections[name] = &tcvectordb.Collection{
+			DatabaseName:   d.name,
+			CollectionName: name,
+			ShardNum:       shardNum,
+			ReplicasNum:    replicasNum,
+			Description:    description,
+			Indexes:        indexes,
+		}
+		if len(params) != 0 && params[0] != nil {
+			d.collections[name].FilterIndexConfig = params[0].FilterIndexConfig
+		}
+	}
+	return d.collections[name], nil
+}
+
+func (d *fakeDatabase) DropCollection(ctx context.Context, name string) (*tcvectordb.DropCollectionResult, error) {
+	delete(d.collections, name)
+	return new(tcvectordb.DropCollectionResult), nil
+}
+
+func (a *fakeAliases) ListAliases(ctx context.Context) (*tcvectordb.ListAliasesResult, error) {
+	res := new(tcvectordb.ListAliasesResult)
+	for alias, coll := range a.aliases {
+		res.Aliases = append(res.Aliases, tcvectordb.AliasItem{Alias: alias, Collection: coll})
+	}
+	return res, nil
+}
+
+func (a *fakeAliases) SetAlias(ctx context.Context, collectionName, aliasName string) (*tcvectordb.SetAliasResult, error) {
+	a.aliases[aliasName] = collectionName
+	return new(tcvectordb.SetAliasResult), nil
+}
+
+const instanceSpecYaml = `
+databases:
+  - name: docs
+    collections:
+      - name: articles_v2
+        indexes:
+          - {fieldName: id, fieldType: string, indexType: primaryKey}
+          - {fieldName: vector, fieldType: vector, indexType: HNSW, dimension: 768, metricType: COSINE,
+             params: {M: 16, efConstruction: 200}}
+    aliases:
+      - {alias: articles, collection: articles_v2}
+users:
+  - user: reader
+    password: secret
+    privileges:
+      - {resource: "docs.*", actions: [read]}
+`
+
+func readInstanceSpec(t *testing.T, data string) *spec.InstanceSpec {
+	s := new(spec.InstanceSpec)
+	if err := spec.Unmarshal([]byte(data), s); err != nil {
+		t.Fatalf("unmarshal instance spec failed. err: %v", err)
+	}
+	return s
+}
+
+func TestApplyInstanceSpec(t *testing.T) {
+	cli := newFakeInstance()
+	s := readInstanceSpec(t, instanceSpecYaml)
+
+	plan, err := spec.Apply(ctx, cli, s, spec.ApplyOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed. err: %v", err)
+	}
+	if len(plan.Changes) != 5 || len(cli.databases) != 0 {
+		t.Fatalf("unexpected dry run: %+v, databases %v", plan.Changes, len(cli.databases))
+	}
+
+	if _, err = spec.Apply(ctx, cli, s); err != nil {
+		t.Fatalf("apply failed. err: %v", err)
+	}
+	db := cli.databases["docs"]
+	if db == nil || db.collections["articles_v2"] == nil || db.aliases.aliases["articles"] != "articles_v2" {
+		t.Fatalf("instance not applied: %+v", db)
+	}
+	if len(cli.users["reader"]) != 1 {
+		t.Fatalf("privileges not granted: %+v", cli.users["reader"])
+	}
+
+	plan, err = spec.Apply(ctx, cli, s)
+	if err != nil {
+		t.Fatalf("apply again failed. err: %v", err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("apply is not idempotent: %+v", plan.Changes)
+	}
+}
+
+func TestApplyInstanceSpecDrift(t *testing.T) {
+	cli := newFakeInstance()
+	if _, err := spec.Apply(ctx, cli, readInstanceSpec(t, instanceSpecYaml)); err != nil {
+		t.Fatalf("apply failed. err: %v", err)
+	}
+	cli.Database("docs").CreateCollectionIfNotExists(ctx, "manual", 1, 0, "", tcvectordb.Indexes{})
+	cli.CreateUser(ctx, tcvectordb.CreateUserParams{User: "former"})
+
+	data := strings.Replace(instanceSpecYaml, "params: {M: 16, efConstruction: 200}}",
+		"params: {M: 16, efConstruction: 200}}\n          - {fieldName: author, fieldType: string, indexType: filter}", 1)
+	plan, err := spec.Apply(ctx, cli, readInstanceSpec(t, data), spec.ApplyOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("apply failed. err: %v", err)
+	}
+	var sb strings.Builder
+	plan.WriteDiff(&sb)
+	if len(plan.Changes) != 3 || cli.databases["docs"].collections["manual"] != nil || cli.users["former"] != nil {
+		t.Fatalf("unexpected plan:\n%v", sb.String())
+	}
+	if _, ok := cli.users["root"]; !ok {
+		t.Fatalf("root is pruned")
+	}
+	if len(cli.databases["docs"].collections["articles_v2"].Indexes.FilterIndex) != 2 {
+		t.Fatalf("filter index not added")
+	}
+
+	data = strings.Replace(instanceSpecYaml, "dimension: 768", "dimension: 1024", 1)
+	plan, err = spec.Apply(ctx, cli, readInstanceSpec(t, data))
+	if err == nil || len(plan.Conflicts()) != 1 {
+		t.Fatalf("expected a conflict for the dimension, got %+v, err: %v", plan.Changes, err)
+	}
+
+	data = strings.Replace(instanceSpecYaml, "- name: articles_v2\n", "- name: articles_v2\n        description: articles\n"+
+		"        filterIndexConfig: {filterAll: true, fieldsWithoutIndex: [body]}\n", 1)
+	plan, err = spec.Apply(ctx, cli, readInstanceSpec(t, data))
+	if err == nil || len(plan.Conflicts()) != 2 {
+		t.Fatalf("expected conflicts for the description and filterIndexConfig, got %+v, err: %v", plan.Changes, err)
+	}
+}
+
+func TestApplyInstanceSpecFilterIndexConfig(t *testing.T) {
+	cli := newFakeInstance()
+	data := strings.Replace(instanceSpecYaml, "- name: articles_v2\n", "- name: articles_v2\n        description: articles\n"+
+		"        filterIndexConfig: {filterAll: true, fieldsWithoutIndex: [body, title]}\n", 1)
+	if _, err := spec.Apply(ctx, cli, readInstanceSpec(t, data)); err != nil {
+		t.Fatalf("apply failed. err: %v", err)
+	}
+	// The order of the fields without index does not matter.
+	data = strings.Replace(data, "[body, title]", "[title, body]", 1)
+	plan, err := spec.Apply(ctx, cli, readInstanceSpec(t, data))
+	if err != nil || !plan.Empty() {
+		t.Fatalf("unexpected plan: %+v, err: %v", plan.Changes, err)
+	}
+}
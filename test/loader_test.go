@@ -0,0 +1,218 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/loader"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/local"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/splitter"
+)
+
+func writeLoaderFiles(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"guide.md": "---\ntitle: Guide\ntags: [a, b]\n---\n# Getting started\n\nInstall apple.\n\n## Usage\n\nEat banana.\n",
+		"page.html": `<html><head><title>Page</title><meta name="description" content="A page"></head><body>
+<nav>Home | About</nav><h1>Fruits</h1><p>Fresh <b>cherry</b>.</p>
+<table><tr><th>Name</th><th>Color</th></tr><tr><td>apple</td><td>red</td></tr></table>
+<ul><li>one</li><li>two</li></ul><script>alert(1)</script></body></html>`,
+		"notes.txt":       "\ufeffplain\r\ntext\r\n",
+		"rows.csv":        "name,color,text\napple,red,An apple\nbanana,yellow,\"A banana, long\"\n",
+		"items.jsonl":     "{\"text\": \"first\", \"id\": 1}\n\n{\"text\": \"second\", \"id\": 2}",
+		"image.png":       "binary",
+		".hidden/skip.md": "# Skipped",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoaders(t *testing.T) {
+	dir := writeLoaderFiles(t)
+	docs, err := loader.LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 7 {
+		t.Fatalf("loaded %v documents, want 7", len(docs))
+	}
+	// The paths are sorted: guide.md, items.jsonl, notes.txt, page.html, rows.csv.
+	guide := docs[0]
+	if guide.Format != loader.FormatMarkdown || guide.Metadata[loader.MetadataTitle] != "Guide" ||
+		!strings.HasPrefix(guide.Text, "# Getting started") || guide.Metadata[loader.MetadataSource] != filepath.Join(dir, "guide.md") {
+		t.Fatalf("unexpected markdown document: %+v", guide)
+	}
+	if tags, ok := guide.Metadata["tags"].([]interface{}); !ok || len(tags) != 2 {
+		t.Fatalf("unexpected front matter: %+v", guide.Metadata)
+	}
+	if docs[1].Text != "first" || docs[2].Text != "second" || docs[2].Metadata[loader.MetadataLine] != 3 ||
+		docs[2].Metadata["id"].(interface{ String() string }).String() != "2" {
+		t.Fatalf("unexpected jsonl documents: %+v", docs[1:3])
+	}
+	if docs[3].Text != "plain\ntext\n" {
+		t.Fatalf("unexpected text document: %q", docs[3].Text)
+	}
+	page := docs[4]
+	want := "# Fruits\n\nFresh cherry.\n\n| Name | Color |\n| --- | --- |\n| apple | red |\n\n- one\n- two"
+	if page.Format != loader.FormatMarkdown || page.Text != want || page.Metadata[loader.MetadataTitle] != "Page" ||
+		page.Metadata["description"] != "A page" {
+		t.Fatalf("unexpected html document: %q, %+v", page.Text, page.Metadata)
+	}
+	if docs[6].Text != "name: banana\ncolor: yellow\ntext: A banana, long" || docs[6].Metadata[loader.MetadataRow] != 2 {
+		t.Fatalf("unexpected csv document: %+v", docs[6])
+	}
+
+	rows, err := loader.LoadFile(filepath.Join(dir, "rows.csv"), &loader.CSVLoader{
+		TextColumns:     []string{"text"},
+		MetadataColumns: []string{"name"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Text != "An apple" || rows[0].Metadata["name"] != "apple" {
+		t.Fatalf("unexpected csv documents: %+v", rows)
+	}
+	if _, err := loader.LoadFile(filepath.Join(dir, "image.png")); err == nil {
+		t.Fatal("loaded an unsupported file")
+	}
+}
+
+func TestIngest(t *testing.T) {
+	ctx := context.Background()
+	var embedded int64
+	server := newFakeEmbeddingServer(t, &embedded)
+	defer server.Close()
+	engine, err := local.Open("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+	cli, err := tcvectordb.NewClient("http://local", "root", "key", &tcvectordb.ClientOption{
+		Transport: engine,
+		ClientEmbedding: map[string]*tcvectordb.ClientEmbeddingConfig{
+			"db/docs": {
+				Embedder: &tcvectordb.OpenAIEmbedder{BaseURL: server.URL, APIKey: "key", Model: "fake"},
+				Field:    "text",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.CreateDatabaseIfNotExists(ctx, "db"); err != nil {
+		t.Fatal(err)
+	}
+	indexes := localIndexes(tcvectordb.FLAT, tcvectordb.IP, 4)
+	indexes.FilterIndex = append(indexes.FilterIndex,
+		tcvectordb.FilterIndex{FieldName: splitter.FieldDocId, FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER})
+	if _, err := cli.Database("db").CreateCollection(ctx, "docs", 1, 1, "", indexes); err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := loader.LoadDir(writeLoaderFiles(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlap := 5
+	params := &loader.IngestParams{ChunkSize: 20, ChunkOverlap: &overlap, BatchSize: 3}
+	chunks := loader.Split(docs, params)
+	res, err := loader.Ingest(ctx, cli, "db", "docs", docs, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Chunks != len(chunks) || res.AffectedCount != len(chunks) || res.TokenUsed == 0 {
+		t.Fatalf("unexpected ingest result: %+v, %v chunks", res, len(chunks))
+	}
+	count, err := cli.Count(ctx, "db", "docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(count.Count) != len(chunks) {
+		t.Fatalf("counted %v documents, want %v", count.Count, len(chunks))
+	}
+
+	found := false
+	for _, chunk := range chunks {
+		if chunk.Fields["paragraph_title"].String() == "Usage" && chunk.Fields["title"].String() == "Guide" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("the chunks have no titles: %+v", chunks)
+	}
+
+	again, err := loader.Ingest(ctx, cli, "db", "docs", docs, params)
+	if err != nil || again.Chunks != len(chunks) {
+		t.Fatalf("ingest again failed: %+v, %v", again, err)
+	}
+	count, err = cli.Count(ctx, "db", "docs")
+	if err != nil || int(count.Count) != len(chunks) {
+		t.Fatalf("ingest again duplicated the chunks: %+v, %v", count, err)
+	}
+
+	// The chunks left over by a shorter document are deleted with DeleteStale.
+	longest := 0
+	for i := range docs {
+		if len(docs[i].Text) > len(docs[longest].Text) {
+			longest = i
+		}
+	}
+	docs[longest].Text = "short"
+	params.DeleteStale = true
+	shorter := loader.Split(docs, params)
+	if len(shorter) >= len(chunks) {
+		t.Fatalf("the shorter documents have %v chunks, want less than %v", len(shorter), len(chunks))
+	}
+	if _, err := loader.Ingest(ctx, cli, "db", "docs", docs, params); err != nil {
+		t.Fatal(err)
+	}
+	count, err = cli.Count(ctx, "db", "docs")
+	if err != nil || int(count.Count) != len(shorter) {
+		t.Fatalf("counted %+v documents after deleting stale chunks, want %v, err: %v", count, len(shorter), err)
+	}
+
+	// No overlap is kept with a zero ChunkOverlap.
+	text := []loader.Document{{Text: "alpha beta gamma delta epsilon zeta eta theta iota kappa lambda mu"}}
+	overlapped := func(overlap int) bool {
+		split := loader.Split(text, &loader.IngestParams{ChunkSize: 20, ChunkOverlap: &overlap})
+		for i := 1; i < len(split); i++ {
+			if split[i].Fields[splitter.FieldStartPos].Uint64() < split[i-1].Fields[splitter.FieldEndPos].Uint64() {
+				return true
+			}
+		}
+		return false
+	}
+	if overlapped(0) || !overlapped(5) {
+		t.Fatalf("chunk overlap 0 overlapped %v, and 5 overlapped %v", overlapped(0), overlapped(5))
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/encoder"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/api/document"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/local"
+)
+
+func localIndexes(indexType tcvectordb.IndexType, metric tcvectordb.MetricType, dim uint32) tcvectordb.Indexes {
+	vector := tcvectordb.VectorIndex{
+		FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: indexType},
+		Dimension:   dim,
+		MetricType:  metric,
+	}
+	if indexType == tcvectordb.HNSW {
+		vector.Params = &tcvectordb.HNSWParam{M: 16, EfConstruction: 100}
+	}
+	return tcvectordb.Indexes{
+		VectorIndex: []tcvectordb.VectorIndex{vector},
+		FilterIndex: []tcvectordb.FilterIndex{
+			{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY},
+			{FieldName: "author", FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER},
+			{FieldName: "page", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER},
+			{FieldName: "tags", FieldType: tcvectordb.Array, IndexType: tcvectordb.FILTER},
+		},
+	}
+}
+
+func newLocalCollection(t *testing.T, cli tcvectordb.VdbClient, name string, indexes tcvectordb.Indexes,
+	params ...*tcvectordb.CreateCollectionParams) {
+	ctx := context.Background()
+	if _, err := cli.CreateDatabaseIfNotExists(ctx, "db"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.CreateCollection(ctx, "db", name, 1, 1, "", indexes, params...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func localDocuments() []tcvectordb.Document {
+	return []tcvectordb.Document{
+		{Id: "0001", Vector: []float32{1, 0, 0, 0}, Fields: map[string]tcvectordb.Field{
+			"author": {Val: "Tom"}, "page": {Val: 21}, "tags": {Val: []string{"a", "b"}}}},
+		{Id: "0002", Vector: []float32{0, 1, 0, 0}, Fields: map[string]tcvectordb.Field{
+			"author": {Val: "Tom"}, "page": {Val: 5}, "tags": {Val: []string{"b"}}}},
+		{Id: "0003", Vector: []float32{0, 0, 1, 0}, Fields: map[string]tcvectordb.Field{
+			"author": {Val: "Jerry"}, "page": {Val: 100}, "tags": {Val: []string{"c"}}}},
+		{Id: "0004", Vector: []float32{0.9, 0.1, 0, 0}, Fields: map[string]tcvectordb.Field{
+			"author": {Val: "Jerry"}, "page": {Val: 9}}},
+	}
+}
+
+func TestLocalEngineDocuments(t *testing.T) {
+	ctx := context.Background()
+	cli, err := local.NewClient("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	var vdb tcvectordb.VdbClient = cli
+	newLocalCollection(t, vdb, "books", localIndexes(tcvectordb.FLAT, tcvectordb.L2, 4))
+
+	upserted, err := vdb.Upsert(ctx, "db", "books", localDocuments())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upserted.AffectedCount != 4 {
+		t.Fatalf("upserted %v documents, want 4", upserted.AffectedCount)
+	}
+
+	queried, err := vdb.Query(ctx, "db", "books", nil, &tcvectordb.QueryDocumentParams{
+		Filter: tcvectordb.NewFilter(`author="Tom"`).Or(`page > 50`),
+		Sort:   []document.SortRule{{FieldName: "page", Direction: "desc"}},
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(queried.Documents); got != "0003,0001,0002" || queried.Total != 3 {
+		t.Fatalf("query returned %v of %v, want 0003,0001,0002 of 3", got, queried.Total)
+	}
+
+	count, err := vdb.Count(ctx, "db", "books", tcvectordb.CountDocumentParams{
+		CountFilter: tcvectordb.NewFilter(tcvectordb.Include("tags", []string{"b"})),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count.Count != 2 {
+		t.Fatalf("counted %v documents, want 2", count.Count)
+	}
+
+	searched, err := vdb.Search(ctx, "db", "books", [][]float32{{1, 0, 0, 0}}, &tcvectordb.SearchDocumentParams{
+		Filter: tcvectordb.NewFilter(`page < 50`),
+		Limit:  2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(searched.Documents[0]); got != "0001,0004" || searched.Documents[0][0].Score != 0 {
+		t.Fatalf("search returned %v, want 0001,0004", got)
+	}
+
+	updated, err := vdb.Update(ctx, "db", "books", tcvectordb.UpdateDocumentParams{
+		QueryIds:     []string{"0002"},
+		UpdateFields: map[string]tcvectordb.Field{"author": {Val: "Jerry"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := vdb.Delete(ctx, "db", "books", tcvectordb.DeleteDocumentParams{Filter: tcvectordb.NewFilter(`author="Jerry"`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.AffectedCount != 1 || deleted.AffectedCount != 3 {
+		t.Fatalf("updated %v and deleted %v documents, want 1 and 3", updated.AffectedCount, deleted.AffectedCount)
+	}
+
+	if _, err = vdb.Query(ctx, "db", "books", nil, &tcvectordb.QueryDocumentParams{Filter: tcvectordb.NewFilter(`title="x"`)}); err == nil ||
+		!strings.Contains(err.Error(), strconv.Itoa(tcvectordb.ERR_SYNTAX_ERROR)) {
+		t.Fatalf("filter on a field without index returned %v", err)
+	}
+	exists, err := vdb.ExistsCollection(ctx, "db", "missing")
+	if err != nil || exists {
+		t.Fatalf("ExistsCollection returned %v, %v for a missing collection", exists, err)
+	}
+}
+
+func localIds(docs []tcvectordb.Document) string {
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, doc.Id)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestLocalEngineHNSWRecall(t *testing.T) {
+	ctx := context.Background()
+	cli, err := local.NewClient("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	const dim = 16
+	newLocalCollection(t, cli, "flat", localIndexes(tcvectordb.FLAT, tcvectordb.COSINE, dim))
+	newLocalCollection(t, cli, "hnsw", localIndexes(tcvectordb.HNSW, tcvectordb.COSINE, dim))
+
+	r := rand.New(rand.NewSource(7))
+	randomVector := func() []float32 {
+		v := make([]float32, dim)
+		for i := range v {
+			v[i] = r.Float32()*2 - 1
+		}
+		return v
+	}
+	var docs []tcvectordb.Document
+	for i := 0; i < 2000; i++ {
+		docs = append(docs, tcvectordb.Document{Id: strconv.Itoa(i), Vector: randomVector(),
+			Fields: map[string]tcvectordb.Field{"page": {Val: i}}})
+	}
+	for _, name := range []string{"flat", "hnsw"} {
+		if _, err := cli.Upsert(ctx, "db", name, docs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var queries [][]float32
+	for i := 0; i < 20; i++ {
+		queries = append(queries, randomVector())
+	}
+	for _, filter := range []*tcvectordb.Filter{nil, tcvectordb.NewFilter("page >= 1000"), tcvectordb.NewFilter("page < 100")} {
+		params := &tcvectordb.SearchDocumentParams{Filter: filter, Limit: 10, Params: &tcvectordb.SearchDocParams{Ef: 64}}
+		exact, err := cli.Search(ctx, "db", "flat", queries, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		approximate, err := cli.Search(ctx, "db", "hnsw", queries, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found, total := 0, 0
+		for i := range queries {
+			want := make(map[string]bool)
+			for _, doc := range exact.Documents[i] {
+				want[doc.Id] = true
+			}
+			for _, doc := range approximate.Documents[i] {
+				if want[doc.Id] {
+					found++
+				}
+			}
+			total += len(want)
+		}
+		if recall := float64(found) / float64(total); recall < 0.9 {
+			t.Fatalf("the recall of HNSW with filter %q is %v", filter.Cond(), recall)
+		}
+	}
+}
+
+func TestLocalEngineHybridSearch(t *testing.T) {
+	ctx := context.Background()
+	cli, err := local.NewClient("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	indexes := localIndexes(tcvectordb.FLAT, tcvectordb.IP, 4)
+	indexes.SparseVectorIndex = []tcvectordb.SparseVectorIndex{
+		{FieldName: "sparse_vector", FieldType: tcvectordb.SparseVector, IndexType: tcvectordb.SPARSE_INVERTED, MetricType: tcvectordb.IP},
+	}
+	newLocalCollection(t, cli, "hybrid", indexes)
+
+	docs := localDocuments()
+	docs[0].SparseVector = []encoder.SparseVecItem{{TermId: 1, Score: 0.1}}
+	docs[1].SparseVector = []encoder.SparseVecItem{{TermId: 1, Score: 0.9}, {TermId: 2, Score: 0.5}}
+	docs[2].SparseVector = []encoder.SparseVecItem{{TermId: 3, Score: 1}}
+	docs[3].SparseVector = []encoder.SparseVecItem{{TermId: 2, Score: 0.2}}
+	if _, err := cli.Upsert(ctx, "db", "hybrid", docs); err != nil {
+		t.Fatal(err)
+	}
+
+	query := []encoder.SparseVecItem{{TermId: 1, Score: 1}, {TermId: 2, Score: 1}}
+	fullText, err := cli.FullTextSearch(ctx, "db", "hybrid", tcvectordb.FullTextSearchParams{
+		Match: &tcvectordb.FullTextSearchMatchOption{Data: [][]encoder.SparseVecItem{query}},
+		Limit: &[]int{10}[0],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(fullText.Documents[0]); got != "0002,0004,0001" {
+		t.Fatalf("full text search returned %v, want 0002,0004,0001", got)
+	}
+
+	limit := 2
+	hybrid, err := cli.HybridSearch(ctx, "db", "hybrid", tcvectordb.HybridSearchDocumentParams{
+		AnnParams: []*tcvectordb.AnnParam{{FieldName: "vector", Data: []float32{1, 0, 0, 0}, Limit: &limit}},
+		Match:     []*tcvectordb.MatchOption{{FieldName: "sparse_vector", Data: query, Limit: &limit}},
+		Rerank:    &tcvectordb.RerankOption{Method: tcvectordb.RerankRrf, RrfK: 1},
+		Limit:     &limit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0004 ranks 2nd by both ann and match, ahead of 0001 and 0002 which rank 1st by one of them.
+	if got := localIds(hybrid.Documents[0]); got != "0004,0001" {
+		t.Fatalf("hybrid search returned %v, want 0004,0001", got)
+	}
+}
+
+func TestLocalEngineTTLAndReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cli, err := local.NewClient(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexes := localIndexes(tcvectordb.HNSW, tcvectordb.L2, 4)
+	indexes.FilterIndex = append(indexes.FilterIndex,
+		tcvectordb.FilterIndex{FieldName: "expire_at", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER})
+	newLocalCollection(t, cli, "ttl", indexes, &tcvectordb.CreateCollectionParams{
+		TtlConfig: &tcvectordb.TtlConfig{Enable: true, TimeField: "expire_at"},
+	})
+	docs := localDocuments()
+	docs[0].Fields["expire_at"] = tcvectordb.Field{Val: time.Now().Add(-time.Hour).Unix()}
+	docs[1].Fields["expire_at"] = tcvectordb.Field{Val: time.Now().Add(time.Hour).Unix()}
+	if _, err := cli.Upsert(ctx, "db", "ttl", docs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Delete(ctx, "db", "ttl", tcvectordb.DeleteDocumentParams{DocumentIds: []string{"0003"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Database("db").SetAlias(ctx, "ttl", "ttl_alias"); err != nil {
+		t.Fatal(err)
+	}
+	cli.Close()
+
+	cli, err = local.NewClient(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	queried, err := cli.Query(ctx, "db", "ttl_alias", nil, &tcvectordb.QueryDocumentParams{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(queried.Documents); got != "0002,0004" {
+		t.Fatalf("query after reopening returned %v, want 0002,0004", got)
+	}
+	searched, err := cli.Search(ctx, "db", "ttl", [][]float32{{1, 0, 0, 0}}, &tcvectordb.SearchDocumentParams{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(searched.Documents[0]); got != "0004" {
+		t.Fatalf("search after reopening returned %v, want 0004", got)
+	}
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/encoder"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestMultiCollectionSearch(t *testing.T) {
+	searchRes, err := tcvectordb.MultiCollectionSearch(ctx, cli, tcvectordb.MultiCollectionSearchParams{
+		Targets: []tcvectordb.SearchTarget{
+			{DatabaseName: database, CollectionName: collectionName},
+			{DatabaseName: database, CollectionName: collectionAlias},
+		},
+		Vectors: [][]float32{{0.3123, 0.43, 0.213}},
+		Search: &tcvectordb.SearchDocumentParams{
+			Params: &tcvectordb.SearchDocParams{Ef: 100},
+			Limit:  5,
+		},
+		Limit: 5,
+	})
+	printErr(err)
+	log.Printf("warning: %v", searchRes.Warning)
+	for _, targetErr := range searchRes.Errors {
+		log.Printf("target error: %v", targetErr)
+	}
+	for i, docs := range searchRes.Documents {
+		log.Printf("doc %d result: ", i)
+		for _, doc := range docs {
+			log.Printf("collection: %v, document: %+v", doc.CollectionName, doc.Document)
+		}
+	}
+}
+
+func TestMultiCollectionFullTextSearch(t *testing.T) {
+	limit := 5
+	searchRes, err := tcvectordb.MultiCollectionSearch(ctx, cli, tcvectordb.MultiCollectionSearchParams{
+		Targets: []tcvectordb.SearchTarget{
+			{DatabaseName: database, CollectionName: collectionName},
+			{DatabaseName: database, CollectionName: embedCollWithSparseVec},
+		},
+		FullTextSearch: &tcvectordb.FullTextSearchParams{
+			Match: &tcvectordb.FullTextSearchMatchOption{
+				FieldName: "sparse_vector",
+				Data:      [][]encoder.SparseVecItem{{{TermId: 1172076521, Score: 0.71}, {TermId: 3314281220, Score: 0.29}}},
+			},
+			Limit: &limit,
+		},
+	})
+	printErr(err)
+	for i, docs := range searchRes.Documents {
+		log.Printf("doc %d result: ", i)
+		for _, doc := range docs {
+			log.Printf("collection: %v, document: %+v", doc.CollectionName, doc.Document)
+		}
+	}
+}
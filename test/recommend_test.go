@@ -0,0 +1,136 @@
+package test
+
+import (
+	"context"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestFlatRecommend(t *testing.T) {
+	searchRes, err := tcvectordb.Recommend(ctx, cli, database, collectionName, tcvectordb.RecommendParams{
+		SearchDocumentParams: tcvectordb.SearchDocumentParams{
+			Params:       &tcvectordb.SearchDocParams{Ef: 100},
+			OutputFields: []string{"bookName", "page"},
+			Limit:        3,
+		},
+		PositiveIds: []string{"0001", "0002"},
+		NegativeIds: []string{"0003"},
+	})
+	printErr(err)
+	for i, docs := range searchRes.Documents {
+		log.Printf("doc %d result: ", i)
+		for _, doc := range docs {
+			log.Printf("document: %+v", doc)
+		}
+	}
+}
+
+func TestFlatRecommendByRocchio(t *testing.T) {
+	gamma := float32(0.3)
+	searchRes, err := tcvectordb.Recommend(ctx, cli, database, collectionName, tcvectordb.RecommendParams{
+		SearchDocumentParams: tcvectordb.SearchDocumentParams{
+			Params: &tcvectordb.SearchDocParams{Ef: 100},
+			Limit:  3,
+		},
+		QueryVector:     []float32{0.3123, 0.43, 0.213},
+		NegativeVectors: [][]float32{{0.233, 0.12, 0.97}},
+		Strategy:        tcvectordb.RecommendRocchio,
+		Gamma:           &gamma,
+	})
+	printErr(err)
+	for i, docs := range searchRes.Documents {
+		log.Printf("doc %d result: ", i)
+		for _, doc := range docs {
+			log.Printf("document: %+v", doc)
+		}
+	}
+}
+
+// exampleStore returns the vectors of the examples by Query, and records the vector and the limit of Search.
+type exampleStore struct {
+	tcvectordb.FlatDocumentInterface
+	vectors  map[string][]float32
+	searched []float32
+	limit    int64
+}
+
+func (s *exampleStore) Query(ctx context.Context, databaseName, collectionName string, documentIds []string,
+	params ...*tcvectordb.QueryDocumentParams) (*tcvectordb.QueryDocumentResult, error) {
+	result := new(tcvectordb.QueryDocumentResult)
+	for _, id := range documentIds {
+		result.Documents = append(result.Documents, tcvectordb.Document{Id: id, Vector: s.vectors[id]})
+	}
+	return result, nil
+}
+
+func (s *exampleStore) Search(ctx context.Context, databaseName, collectionName string, vectors [][]float32,
+	params ...*tcvectordb.SearchDocumentParams) (*tcvectordb.SearchDocumentResult, error) {
+	s.searched, s.limit = vectors[0], params[0].Limit
+	return &tcvectordb.SearchDocumentResult{Documents: [][]tcvectordb.Document{
+		{{Id: "0001"}, {Id: "0004"}, {Id: "0003"}, {Id: "0005"}, {Id: "0006"}},
+	}}, nil
+}
+
+func vectorNear(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(float64(a[i]-b[i])) > 1e-6 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRecommendVector(t *testing.T) {
+	ctx := context.Background()
+	store := &exampleStore{vectors: map[string][]float32{
+		"0001": {1, 0}, "0002": {0, 1}, "0003": {0, 0.5},
+	}}
+	res, err := tcvectordb.Recommend(ctx, store, "db", "coll", tcvectordb.RecommendParams{
+		SearchDocumentParams: tcvectordb.SearchDocumentParams{Limit: 2},
+		PositiveIds:          []string{"0001", "0002"},
+		NegativeIds:          []string{"0003"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// avg(positive) + (avg(positive) - avg(negative)) = [0.5, 0.5] + [0.5, 0]
+	if !vectorNear(store.searched, []float32{1, 0.5}) {
+		t.Fatalf("average searched %v, want [1 0.5]", store.searched)
+	}
+	// The limit covers the examples, which are excluded from the results.
+	if store.limit != 5 || len(res.Documents[0]) != 2 || res.Documents[0][0].Id != "0004" || res.Documents[0][1].Id != "0005" {
+		t.Fatalf("average searched limit %v and returned %+v, want limit 5 and 0004,0005", store.limit, res.Documents[0])
+	}
+
+	gamma := float32(0.5)
+	_, err = tcvectordb.Recommend(ctx, store, "db", "coll", tcvectordb.RecommendParams{
+		QueryVector:     []float32{1, 1},
+		PositiveVectors: [][]float32{{0, 2}},
+		NegativeVectors: [][]float32{{1, 0}},
+		Strategy:        tcvectordb.RecommendRocchio,
+		Gamma:           &gamma,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1*[1, 1] + 0.75*[0, 2] - 0.5*[1, 0]
+	if !vectorNear(store.searched, []float32{0.5, 2.5}) {
+		t.Fatalf("rocchio searched %v, want [0.5 2.5]", store.searched)
+	}
+
+	for name, param := range map[string]tcvectordb.RecommendParams{
+		"inconsistent dimension": {PositiveVectors: [][]float32{{1, 0}, {1, 0, 0}}},
+		"no positive example":    {NegativeVectors: [][]float32{{1, 0}}},
+		"unknown strategy":       {PositiveVectors: [][]float32{{1, 0}}, Strategy: "unknown"},
+	} {
+		if _, err := tcvectordb.Recommend(ctx, store, "db", "coll", param); err == nil {
+			t.Fatalf("%v: recommend succeeded, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestDocumentIterator(t *testing.T) {
+	it := tcvectordb.NewDocumentIterator(cli, database, collectionName, &tcvectordb.DocumentIteratorParams{
+		OutputFields: []string{"id", "bookName"},
+		BatchSize:    2,
+	})
+	for {
+		docs, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		printErr(err)
+		log.Printf("batch: %+v", docs)
+	}
+	log.Printf("scanned %v documents", it.Offset())
+}
+
+func TestReindex(t *testing.T) {
+	capture := tcvectordb.NewWriteCapture(cli)
+	res, err := tcvectordb.Reindex(ctx, cli, database, collectionName, tcvectordb.ReindexParams{
+		NewCollectionName: collectionName + "-reindex",
+		Indexes: &tcvectordb.Indexes{
+			VectorIndex: []tcvectordb.VectorIndex{{
+				FilterIndex: tcvectordb.FilterIndex{
+					FieldName: "vector",
+					FieldType: tcvectordb.Vector,
+					IndexType: tcvectordb.HNSW,
+				},
+				Dimension:  3,
+				MetricType: tcvectordb.COSINE,
+				Params:     &tcvectordb.HNSWParam{M: 32, EfConstruction: 200},
+			}},
+			FilterIndex: []tcvectordb.FilterIndex{
+				{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY},
+				{FieldName: "bookName", FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER},
+				{FieldName: "page", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER},
+			},
+		},
+		Alias:   collectionAlias,
+		Capture: capture,
+		Progress: func(copied int64) {
+			log.Printf("copied %v documents", copied)
+		},
+	})
+	printErr(err)
+	log.Printf("reindex result: %+v", res)
+}
+
+func TestReindexDropOldRequiresAlias(t *testing.T) {
+	_, err := tcvectordb.Reindex(ctx, cli, database, collectionName, tcvectordb.ReindexParams{DropOld: true})
+	if err == nil {
+		t.Fatal("reindex with DropOld and without Alias succeeded, want an error")
+	}
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestFlatRerankSearchResults(t *testing.T) {
+	vectors := [][]float32{{0.3123, 0.43, 0.213}}
+	denseRes, err := cli.Search(ctx, database, collectionName, vectors, &tcvectordb.SearchDocumentParams{
+		Params: &tcvectordb.SearchDocParams{Ef: 100},
+		Limit:  10,
+	})
+	printErr(err)
+	filterRes, err := cli.Search(ctx, database, collectionName, vectors, &tcvectordb.SearchDocumentParams{
+		Params: &tcvectordb.SearchDocParams{Ef: 100},
+		Filter: tcvectordb.NewFilter(`bookName="三国演义"`),
+		Limit:  10,
+	})
+	printErr(err)
+
+	rerankRes, err := tcvectordb.RerankSearchResults(ctx, &tcvectordb.RRFReranker{Weights: []float32{1, 2}}, 3,
+		tcvectordb.RerankSource{DatabaseName: database, CollectionName: collectionName, Result: denseRes},
+		tcvectordb.RerankSource{DatabaseName: database, CollectionName: collectionName, Result: filterRes})
+	printErr(err)
+	for i, docs := range rerankRes.Documents {
+		log.Printf("doc %d result: ", i)
+		for _, doc := range docs {
+			log.Printf("document: %+v", doc)
+		}
+	}
+}
+
+func TestRRFReranker(t *testing.T) {
+	lists := []tcvectordb.RankedList{
+		{CollectionName: "coll_a", Documents: []tcvectordb.Document{{Id: "0001"}, {Id: "0002"}, {Id: "0003"}}},
+		{CollectionName: "coll_a", Documents: []tcvectordb.Document{{Id: "0003"}, {Id: "0002"}}},
+		{CollectionName: "coll_b", Documents: []tcvectordb.Document{{Id: "0001"}}},
+	}
+	docs, err := (&tcvectordb.RRFReranker{}).Rerank(context.Background(), lists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 4 || docs[0].Id != "0003" || docs[1].Id != "0002" || docs[3].CollectionName != "coll_b" {
+		t.Fatalf("unexpected rrf result: %+v", docs)
+	}
+}
+
+func TestWeightedReranker(t *testing.T) {
+	lists := []tcvectordb.RankedList{
+		{MetricType: tcvectordb.L2, Documents: []tcvectordb.Document{{Id: "0001", Score: 0.1}, {Id: "0002", Score: 0.5}}},
+		{Documents: []tcvectordb.Document{{Id: "0002", Score: 8}, {Id: "0001", Score: 2}}},
+	}
+	docs, err := (&tcvectordb.WeightedReranker{Weights: []float32{0.3, 0.7}}).Rerank(context.Background(), lists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 || docs[0].Id != "0002" || docs[0].Score != 0.7 {
+		t.Fatalf("unexpected weighted result: %+v", docs)
+	}
+}
+
+func TestTimeDecayReranker(t *testing.T) {
+	now := time.Now()
+	lists := []tcvectordb.RankedList{{Documents: []tcvectordb.Document{
+		{Id: "0001", Fields: map[string]tcvectordb.Field{"ts": {Val: uint64(now.Add(-30 * 24 * time.Hour).Unix())}}},
+		{Id: "0002", Fields: map[string]tcvectordb.Field{"ts": {Val: now.Format(time.RFC3339)}}},
+	}}}
+	docs, err := (&tcvectordb.TimeDecayReranker{
+		Field:  "ts",
+		Origin: now,
+		Scale:  7 * 24 * time.Hour,
+	}).Rerank(context.Background(), lists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 || docs[0].Id != "0002" {
+		t.Fatalf("unexpected time decay result: %+v", docs)
+	}
+}
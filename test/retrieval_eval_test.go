@@ -0,0 +1,102 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/eval"
+)
+
+func TestRetrievalMetrics(t *testing.T) {
+	grades := map[string]int{"a": 2, "b": 1, "c": 0}
+	retrieved := []string{"c", "a", "d", "b"}
+
+	if rr := eval.ReciprocalRank(retrieved, grades, 10, 1); rr != 0.5 {
+		t.Fatalf("unexpected rr: %v", rr)
+	}
+	if ap := eval.AveragePrecision(retrieved, grades, 10, 1); ap != (0.5+0.5)/2 {
+		t.Fatalf("unexpected ap: %v", ap)
+	}
+	if recall := eval.Recall(retrieved, grades, 2, 1); recall != 0.5 {
+		t.Fatalf("unexpected recall: %v", recall)
+	}
+	ndcg := eval.NDCG(retrieved, grades, 10)
+	expected := (3/math.Log2(3) + 1/math.Log2(5)) / (3 + 1/math.Log2(3))
+	if math.Abs(ndcg-expected) > 1e-9 {
+		t.Fatalf("unexpected ndcg: %v, expected %v", ndcg, expected)
+	}
+	if ndcg := eval.NDCG([]string{"a", "b"}, grades, 10); ndcg != 1 {
+		t.Fatalf("ideal ranking should score 1, got %v", ndcg)
+	}
+}
+
+func TestEvaluateRetrieval(t *testing.T) {
+	qrels, err := eval.ReadQrels(strings.NewReader("q1 0 a 2\nq1 0 b 1\n# comment\nq2 0 c 1\nq3 0 d 0\n"))
+	if err != nil {
+		t.Fatalf("read qrels failed: %v", err)
+	}
+	queries := []eval.Query{{Id: "q1"}, {Id: "q2"}, {Id: "q3"}}
+	rankings := map[string][]string{"q1": {"a", "b"}, "q2": {"x", "c"}}
+	configs := []eval.Config{
+		{Name: "good", Retriever: eval.RetrieverFunc(func(ctx context.Context, query eval.Query, limit int) ([]string, error) {
+			return rankings[query.Id], nil
+		})},
+		{Name: "empty", Retriever: eval.RetrieverFunc(func(ctx context.Context, query eval.Query, limit int) ([]string, error) {
+			return nil, nil
+		})},
+	}
+	report, err := eval.Evaluate(ctx, queries, qrels, configs, &eval.EvaluateParams{K: 5})
+	if err != nil {
+		t.Fatalf("evaluate failed: %v", err)
+	}
+	if report.QueryCount != 2 || len(report.SkippedQueries) != 1 || report.SkippedQueries[0] != "q3" {
+		t.Fatalf("unexpected queries: %v, skipped %v", report.QueryCount, report.SkippedQueries)
+	}
+	if good := report.Results[0]; good.MRR != 0.75 || good.Recall != 1 || good.MAP != 0.75 {
+		t.Fatalf("unexpected result: %+v", good)
+	}
+	if best := report.Best(); best.Name != "good" {
+		t.Fatalf("unexpected best: %v", best.Name)
+	}
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf, true); err != nil {
+		t.Fatalf("write csv failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 1+2*3 {
+		t.Fatalf("unexpected csv:\n%v", buf.String())
+	}
+}
+
+func TestEvaluateHybridSearch(t *testing.T) {
+	queries := []eval.Query{{Id: "q1", Text: "三国演义"}}
+	qrels := eval.Qrels{}
+	qrels.Add("q1", "0001", 1)
+	configs := []eval.Config{
+		{Name: "dense", Retriever: eval.NewSearchByTextRetriever(cli, database, embeddingCollection, "segment", nil)},
+	}
+	for _, weight := range []float32{0.3, 0.5, 0.7} {
+		configs = append(configs, eval.Config{
+			Name: fmt.Sprintf("hybrid weight=%v", weight),
+			Retriever: eval.NewHybridSearchRetriever(cli, database, embedCollWithSparseVec, tcvectordb.HybridSearchDocumentParams{
+				AnnParams: []*tcvectordb.AnnParam{{FieldName: "text"}},
+				Match:     []*tcvectordb.MatchOption{{FieldName: "sparse_vector"}},
+				Rerank: &tcvectordb.RerankOption{
+					Method:    tcvectordb.RerankWeighted,
+					FieldList: []string{"vector", "sparse_vector"},
+					Weight:    []float32{weight, 1 - weight},
+				},
+			}, nil),
+		})
+	}
+	report, err := eval.Evaluate(ctx, queries, qrels, configs)
+	printErr(err)
+	var buf bytes.Buffer
+	printErr(report.WriteJSON(&buf))
+	log.Println(buf.String())
+}
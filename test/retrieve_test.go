@@ -0,0 +1,111 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/local"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/splitter"
+)
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+	cli, err := local.NewClient("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	indexes := localIndexes(tcvectordb.FLAT, tcvectordb.IP, 4)
+	indexes.FilterIndex = append(indexes.FilterIndex,
+		tcvectordb.FilterIndex{FieldName: "doc_id", FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER},
+		tcvectordb.FilterIndex{FieldName: "chunk_index", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER})
+	newLocalCollection(t, cli, "chunks", indexes)
+
+	var sentences []string
+	for i := 0; i < 10; i++ {
+		sentences = append(sentences, fmt.Sprintf("Sentence %d.", i))
+	}
+	text := strings.Join(sentences, " ")
+	chunks := (&splitter.RecursiveCharacterSplitter{ChunkSize: 24, ChunkOverlap: 12}).Split(text)
+	docs := splitter.Documents(splitter.WithMetadata(chunks, map[string]interface{}{"source": "a.txt"}), "a")
+	for i := range docs {
+		docs[i].Vector = []float32{0, 0.1, 0, 0}
+	}
+	docs[4].Vector = []float32{1, 0, 0, 0}
+	other := splitter.Documents(splitter.WithMetadata((&splitter.RecursiveCharacterSplitter{}).Split("Another text."),
+		map[string]interface{}{"source": "b.txt"}), "b")
+	other[0].Vector = []float32{0.5, 0, 0, 0}
+	single := tcvectordb.Document{Id: "single", Vector: []float32{0.4, 0, 0, 0},
+		Fields: map[string]tcvectordb.Field{"text": {Val: "A lone chunk."}}}
+	if _, err := cli.Upsert(ctx, "db", "chunks", append(append(docs, other...), single)); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := tcvectordb.Retrieve(ctx, cli, "db", "chunks", tcvectordb.RetrieveParams{
+		Vector:      []float32{1, 0, 0, 0},
+		Limit:       3,
+		ExpandChunk: []int{1, 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Passages) != 3 {
+		t.Fatalf("got %v passages, want 3: %+v", len(res.Passages), res.Passages)
+	}
+	first := res.Passages[0]
+	want := text[chunks[3].StartPos:chunks[5].EndPos]
+	if first.Citation != 1 || first.DocId != "a" || first.Source != "a.txt" || first.StartChunk != 3 ||
+		first.EndChunk != 5 || first.Text != want || len(first.Documents) != 3 {
+		t.Fatalf("unexpected first passage: %+v, want text %q", first, want)
+	}
+	if res.Passages[1].Source != "b.txt" || res.Passages[2].Source != "single" || res.Passages[2].Text != "A lone chunk." {
+		t.Fatalf("unexpected passages: %+v", res.Passages[1:])
+	}
+	if !strings.HasPrefix(res.Context, "[1] a.txt\n"+want+"\n\n[2] b.txt\nAnother text.") {
+		t.Fatalf("unexpected context: %q", res.Context)
+	}
+
+	// The windows of the chunks 3 and 5 overlap, and are merged into a passage.
+	docs[5].Vector = []float32{0.9, 0, 0, 0}
+	if _, err := cli.Upsert(ctx, "db", "chunks", docs[5:6]); err != nil {
+		t.Fatal(err)
+	}
+	budget := tcvectordb.RetrieveParams{Vector: []float32{1, 0, 0, 0}, Limit: 2, ExpandChunk: []int{1, 1}}
+	res, err = tcvectordb.Retrieve(ctx, cli, "db", "chunks", budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Passages) != 1 || res.Passages[0].StartChunk != 3 || res.Passages[0].EndChunk != 6 {
+		t.Fatalf("unexpected merged passages: %+v", res.Passages)
+	}
+
+	budget.MaxTokens = 5
+	res, err = tcvectordb.Retrieve(ctx, cli, "db", "chunks", budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Passages) != 0 || res.Context != "" {
+		t.Fatalf("passages exceed the token budget: %+v", res.Passages)
+	}
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestPlanSchemaChange(t *testing.T) {
+	desired := tcvectordb.Indexes{
+		VectorIndex: []tcvectordb.VectorIndex{
+			{
+				FilterIndex: tcvectordb.FilterIndex{
+					FieldName: "vector",
+					FieldType: tcvectordb.Vector,
+					IndexType: tcvectordb.HNSW,
+				},
+				Dimension:  3,
+				MetricType: tcvectordb.COSINE,
+				Params:     &tcvectordb.HNSWParam{M: 32, EfConstruction: 200},
+			},
+		},
+		FilterIndex: []tcvectordb.FilterIndex{
+			{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY},
+			{FieldName: "bookName", FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER},
+			{FieldName: "page", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER},
+		},
+	}
+	plan, err := tcvectordb.PlanSchemaChange(ctx, cli, database, collectionName, desired)
+	printErr(err)
+	log.Println(plan.String())
+
+	err = plan.Apply(ctx, cli)
+	printErr(err)
+}
+
+func TestDiffIndexes(t *testing.T) {
+	current := tcvectordb.Indexes{
+		VectorIndex: []tcvectordb.VectorIndex{{
+			FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.HNSW},
+			Dimension:   3,
+			MetricType:  tcvectordb.COSINE,
+			Params:      &tcvectordb.HNSWParam{M: 16, EfConstruction: 200},
+		}},
+		FilterIndex: []tcvectordb.FilterIndex{
+			{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY},
+			{FieldName: "author", FieldType: tcvectordb.String, IndexType: tcvectordb.FILTER},
+		},
+	}
+	desired := tcvectordb.Indexes{
+		VectorIndex: []tcvectordb.VectorIndex{{
+			FilterIndex: tcvectordb.FilterIndex{FieldName: "vector", FieldType: tcvectordb.Vector, IndexType: tcvectordb.HNSW},
+			Dimension:   3,
+			Params:      &tcvectordb.HNSWParam{M: 32, EfConstruction: 200},
+		}},
+		FilterIndex: []tcvectordb.FilterIndex{
+			{FieldName: "id", FieldType: tcvectordb.String, IndexType: tcvectordb.PRIMARY},
+			{FieldName: "page", FieldType: tcvectordb.Uint64, IndexType: tcvectordb.FILTER},
+		},
+	}
+	plan := tcvectordb.DiffIndexes(current, desired, nil)
+	log.Println(plan.String())
+	if plan.NeedRecreate || !plan.NeedRebuild || len(plan.Steps) != 3 ||
+		plan.Steps[0].Action != tcvectordb.SchemaDropFilterIndex ||
+		plan.Steps[1].Action != tcvectordb.SchemaAddFilterIndex ||
+		plan.Steps[2].Action != tcvectordb.SchemaModifyVectorIndex {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	desired.VectorIndex[0].Dimension = 768
+	plan = tcvectordb.DiffIndexes(current, desired, nil)
+	if !plan.NeedRecreate {
+		t.Fatalf("dimension change should need recreating: %+v", plan)
+	}
+}
+
+// modifyRecorder records the vector indexes modified, and describes the collection like statusSequence.
+type modifyRecorder struct {
+	statusSequence
+	modified []string
+}
+
+func (m *modifyRecorder) ModifyVectorIndex(ctx context.Context, databaseName, collectionName string, param tcvectordb.ModifyVectorIndexParam) error {
+	m.modified = append(m.modified, param.VectorIndexes[0].FieldName)
+	return nil
+}
+
+func TestSchemaChangeApplyWaitsForBuild(t *testing.T) {
+	previous := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	stale := tcvectordb.IndexStatus{Status: tcvectordb.IndexStatusReady, StartTime: previous}
+	// The first status is described before the step is submitted.
+	cli := &modifyRecorder{statusSequence: statusSequence{statuses: []tcvectordb.IndexStatus{
+		stale, stale, {Status: "building", StartTime: previous}, stale}}}
+	plan := &tcvectordb.SchemaChangePlan{DatabaseName: "db", CollectionName: "coll", Steps: []tcvectordb.SchemaChangeStep{{
+		Action:      tcvectordb.SchemaModifyVectorIndex,
+		FieldName:   "vector",
+		VectorIndex: &tcvectordb.ModifyVectorIndex{FieldName: "vector"},
+	}}}
+	err := plan.Apply(context.Background(), cli, &tcvectordb.ApplySchemaChangeParams{
+		Wait: &tcvectordb.WaitIndexOptions{InitialInterval: time.Millisecond, Timeout: time.Minute},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The ready status of the previous build is skipped until the build of the step is reported.
+	if len(cli.modified) != 1 || cli.polls != 4 {
+		t.Fatalf("modified %v and polled %v times, want 1 step and 4 polls", cli.modified, cli.polls)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/local"
+)
+
+// countingTransport counts the document reads sent to the local engine.
+type countingTransport struct {
+	engine *local.Engine
+	reads  int64
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(r.URL.Path, "/search") || strings.HasSuffix(r.URL.Path, "/query") {
+		atomic.AddInt64(&c.reads, 1)
+	}
+	return c.engine.RoundTrip(r)
+}
+
+func TestSearchCache(t *testing.T) {
+	ctx := context.Background()
+	engine, err := local.Open("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+	transport := &countingTransport{engine: engine}
+	backend := tcvectordb.NewMemorySearchCache(1 << 20)
+	cli, err := tcvectordb.NewClient("http://local", "root", "key", &tcvectordb.ClientOption{
+		Transport:   transport,
+		SearchCache: &tcvectordb.SearchCacheOption{Backend: backend},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.CreateDatabaseIfNotExists(ctx, "db"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Database("db").CreateCollection(ctx, "cached", 1, 1, "", localIndexes(tcvectordb.FLAT, tcvectordb.L2, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Upsert(ctx, "db", "cached", localDocuments()); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(filter string) []tcvectordb.Document {
+		res, err := cli.Search(ctx, "db", "cached", [][]float32{{1, 0, 0, 0}}, &tcvectordb.SearchDocumentParams{
+			Filter: tcvectordb.NewFilter(filter),
+			Limit:  2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Documents[0]
+	}
+	first := search(`author="Tom"`)
+	second := search(`author="Tom"`)
+	// The cached result has the same field types as the one from the server.
+	if transport.reads != 1 || !reflect.DeepEqual(first, second) || second[0].Fields["page"].Int64() != 21 {
+		t.Fatalf("the repeated search read the server %v times, and returned %v and %v", transport.reads, localIds(first), localIds(second))
+	}
+	search(`author="Jerry"`)
+	if transport.reads != 2 {
+		t.Fatalf("a search with another filter was served from the cache")
+	}
+
+	if _, err := cli.Delete(ctx, "db", "cached", tcvectordb.DeleteDocumentParams{DocumentIds: []string{"0001"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := localIds(search(`author="Tom"`)); transport.reads != 3 || got != "0002" {
+		t.Fatalf("the search after deleting returned %v after %v reads", got, transport.reads)
+	}
+}
+
+func TestSearchCacheAlias(t *testing.T) {
+	ctx := context.Background()
+	engine, err := local.Open("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+	transport := &countingTransport{engine: engine}
+	cli, err := tcvectordb.NewClient("http://local", "root", "key", &tcvectordb.ClientOption{
+		Transport:   transport,
+		SearchCache: &tcvectordb.SearchCacheOption{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := cli.CreateDatabaseIfNotExists(ctx, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"books_v1", "books_v2"} {
+		if _, err := db.CreateCollection(ctx, name, 1, 1, "", localIndexes(tcvectordb.FLAT, tcvectordb.L2, 4)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cli.Upsert(ctx, "db", "books_v1", localDocuments()[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Upsert(ctx, "db", "books_v2", localDocuments()[2:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Database("db").SetAlias(ctx, "books_v1", "books"); err != nil {
+		t.Fatal(err)
+	}
+
+	query := func() string {
+		res, err := cli.Query(ctx, "db", "books", nil, &tcvectordb.QueryDocumentParams{Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return localIds(res.Documents)
+	}
+	query()
+	if got := query(); transport.reads != 1 || got != "0001,0002" {
+		t.Fatalf("the repeated query through the alias returned %v after %v reads", got, transport.reads)
+	}
+
+	// The writes through the collection invalidate the results read through its alias.
+	if _, err := cli.Delete(ctx, "db", "books_v1", tcvectordb.DeleteDocumentParams{DocumentIds: []string{"0001"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := query(); transport.reads != 2 || got != "0002" {
+		t.Fatalf("the query after deleting through the collection returned %v after %v reads", got, transport.reads)
+	}
+
+	// The results read through the alias are invalidated when it is moved.
+	if _, err := cli.Database("db").SwapAlias(ctx, "books_v2", "books"); err != nil {
+		t.Fatal(err)
+	}
+	if got := query(); transport.reads != 3 || got != "0003,0004" {
+		t.Fatalf("the query after swapping the alias returned %v after %v reads", got, transport.reads)
+	}
+
+	// The writes through the alias invalidate the results read through the collection.
+	queryV2 := func() string {
+		res, err := cli.Query(ctx, "db", "books_v2", nil, &tcvectordb.QueryDocumentParams{Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return localIds(res.Documents)
+	}
+	queryV2()
+	if _, err := cli.Delete(ctx, "db", "books", tcvectordb.DeleteDocumentParams{DocumentIds: []string{"0003"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := queryV2(); transport.reads != 5 || got != "0004" {
+		t.Fatalf("the query after deleting through the alias returned %v after %v reads", got, transport.reads)
+	}
+}
+
+func TestMemorySearchCache(t *testing.T) {
+	ctx := context.Background()
+	cache := tcvectordb.NewMemorySearchCache(20)
+	cache.Set(ctx, "a", []byte("123456789"), 0)
+	cache.Set(ctx, "b", []byte("123456789"), 0)
+	cache.Get(ctx, "a")
+	cache.Set(ctx, "c", []byte("123456789"), 0)
+	if _, ok, _ := cache.Get(ctx, "b"); ok {
+		t.Fatalf("the least recently used value was not evicted")
+	}
+	if _, ok, _ := cache.Get(ctx, "a"); !ok {
+		t.Fatalf("a recently used value was evicted")
+	}
+
+	cache.Set(ctx, "d", []byte("1"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok, _ := cache.Get(ctx, "d"); ok {
+		t.Fatalf("an expired value was returned")
+	}
+}
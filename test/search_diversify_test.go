@@ -0,0 +1,76 @@
+package test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+)
+
+func TestFlatSearchWithMMR(t *testing.T) {
+	lambda := float32(0.5)
+	searchRes, err := tcvectordb.SearchWithMMR(ctx, cli, database, collectionName, [][]float32{
+		{0.3123, 0.43, 0.213},
+	}, tcvectordb.MMRSearchParams{
+		SearchDocumentParams: tcvectordb.SearchDocumentParams{
+			Params: &tcvectordb.SearchDocParams{Ef: 100},
+			Limit:  2,
+		},
+		FetchLimit: 10,
+		Lambda:     &lambda,
+		MetricType: tcvectordb.COSINE,
+	})
+	printErr(err)
+	for i, docs := range searchRes.Documents {
+		log.Printf("doc %d result: ", i)
+		for _, doc := range docs {
+			log.Printf("document: %+v", doc)
+		}
+	}
+}
+
+func TestFlatSearchWithGroupBy(t *testing.T) {
+	searchRes, err := tcvectordb.SearchWithGroupBy(ctx, cli, database, collectionName, [][]float32{
+		{0.3123, 0.43, 0.213},
+	}, tcvectordb.GroupBySearchParams{
+		SearchDocumentParams: tcvectordb.SearchDocumentParams{
+			Params:       &tcvectordb.SearchDocParams{Ef: 100},
+			OutputFields: []string{"page"},
+			Limit:        2,
+		},
+		GroupByField: "bookName",
+		GroupSize:    2,
+	})
+	printErr(err)
+	for i, groups := range searchRes.Groups {
+		log.Printf("doc %d result: ", i)
+		for _, group := range groups {
+			log.Printf("group %v: %+v", group.Value, group.Documents)
+		}
+	}
+}
+
+func TestMaximalMarginalRelevance(t *testing.T) {
+	docs := []tcvectordb.Document{
+		{Id: "0001", Score: 0.99, Vector: []float32{1, 0, 0}},
+		{Id: "0002", Score: 0.98, Vector: []float32{0.99, 0.01, 0}},
+		{Id: "0003", Score: 0.70, Vector: []float32{0, 1, 0}},
+	}
+	selected := tcvectordb.MaximalMarginalRelevance(nil, docs, 2, 0.5, tcvectordb.COSINE)
+	if len(selected) != 2 || selected[0].Id != "0001" || selected[1].Id != "0003" {
+		t.Fatalf("unexpected mmr result: %+v", selected)
+	}
+}
+
+func TestGroupDocuments(t *testing.T) {
+	docs := []tcvectordb.Document{
+		{Id: "0001", Fields: map[string]tcvectordb.Field{"bookName": {Val: "西游记"}}},
+		{Id: "0002", Fields: map[string]tcvectordb.Field{"bookName": {Val: "西游记"}}},
+		{Id: "0003", Fields: map[string]tcvectordb.Field{"bookName": {Val: "三国演义"}}},
+		{Id: "0004", Fields: map[string]tcvectordb.Field{"bookName": {Val: "红楼梦"}}},
+	}
+	groups := tcvectordb.GroupDocuments(docs, "bookName", 1, 2)
+	if len(groups) != 2 || groups[0].Documents[0].Id != "0001" || groups[1].Documents[0].Id != "0003" {
+		t.Fatalf("unexpected group result: %+v", groups)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/local"
+)
+
+// keywordEmbedder embeds the prompts by their keywords, with a small offset for the longer prompts.
+type keywordEmbedder struct {
+	calls int
+}
+
+func (k *keywordEmbedder) Embed(ctx context.Context, texts []string) (*tcvectordb.EmbeddingResult, error) {
+	k.calls++
+	result := &tcvectordb.EmbeddingResult{TokenUsed: int64(len(texts))}
+	for _, text := range texts {
+		vector := []float32{0, 0, 0, 1}
+		if strings.Contains(text, "apple") {
+			vector = []float32{1, 0, 0, 0}
+		} else if strings.Contains(text, "banana") {
+			vector = []float32{0, 1, 0, 0}
+		}
+		vector[2] = float32(len(text)) / 200
+		result.Vectors = append(result.Vectors, vector)
+	}
+	return result, nil
+}
+
+func TestSemanticCache(t *testing.T) {
+	ctx := context.Background()
+	cli, err := local.NewClient("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	embedder := &keywordEmbedder{}
+	cache, err := tcvectordb.NewSemanticCache(cli, "db", "llm_cache", tcvectordb.SemanticCacheOption{
+		Embedder:    embedder,
+		ScopeFields: []string{"model"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.CreateCollection(ctx, cli, 1, 0, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.CreateCollection(ctx, cli, 1, 0, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	scope := map[string]string{"model": "hunyuan"}
+	hit, err := cache.Get(ctx, "what is an apple", scope)
+	if err != nil || hit != nil {
+		t.Fatalf("unexpected hit of an empty cache: %+v, %v", hit, err)
+	}
+	if err := cache.Set(ctx, "what is an apple", "a fruit", scope); err != nil {
+		t.Fatal(err)
+	}
+	if embedder.calls != 1 {
+		t.Fatalf("the prompt was embedded %v times, want 1", embedder.calls)
+	}
+
+	hit, err = cache.Get(ctx, "tell me what an apple is", scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hit == nil || hit.Response != "a fruit" || hit.Prompt != "what is an apple" || hit.Score < 0.95 ||
+		hit.ExpireAt.Before(time.Now().Add(23*time.Hour)) {
+		t.Fatalf("unexpected hit: %+v", hit)
+	}
+	if hit, err := cache.Get(ctx, "what is a banana", scope); err != nil || hit != nil {
+		t.Fatalf("unexpected hit of a different prompt: %+v, %v", hit, err)
+	}
+	if hit, err := cache.Get(ctx, "what is an apple", map[string]string{"model": "other"}); err != nil || hit != nil {
+		t.Fatalf("unexpected hit of a different scope: %+v, %v", hit, err)
+	}
+	if _, err := cache.Get(ctx, "what is an apple", map[string]string{"tenant": "a"}); err == nil {
+		t.Fatal("looked up with an unknown scope field")
+	}
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 3 || stats.Errors != 1 || stats.HitRate() != 0.25 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	expiring, err := tcvectordb.NewSemanticCache(cli, "db", "llm_cache", tcvectordb.SemanticCacheOption{
+		Embedder:    embedder,
+		TTL:         time.Nanosecond,
+		ScopeFields: []string{"model"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := expiring.Set(ctx, "what is a banana", "a fruit", scope); err != nil {
+		t.Fatal(err)
+	}
+	if hit, err := expiring.Get(ctx, "what is a banana", scope); err != nil || hit != nil {
+		t.Fatalf("unexpected hit of an expired response: %+v, %v", hit, err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/spec"
+)
+
+const collectionSpecYaml = `
+name: articles
+database: docs
+shardNum: 1
+replicaNum: 0
+indexes:
+  - {fieldName: id, fieldType: string, indexType: primaryKey}
+  - {fieldName: tags, fieldType: array, elemType: string, indexType: filter}
+  - {fieldName: vector, fieldType: vector, indexType: HNSW, dimension: 768, metricType: COSINE,
+     params: {M: 16, efConstruction: 200}}
+  - {fieldName: sparse_vector, fieldType: sparseVector, indexType: inverted, metricType: IP}
+ttlConfig: {enable: true, timeField: expire_at}
+`
+
+func TestCollectionSpecToIndexes(t *testing.T) {
+	var s spec.CollectionSpec
+	if err := spec.Unmarshal([]byte(collectionSpecYaml), &s); err != nil {
+		t.Fatalf("unmarshal spec failed. err: %v", err)
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("validate spec failed. err: %v", err)
+	}
+	indexes, err := s.ToIndexes()
+	if err != nil {
+		t.Fatalf("convert indexes failed. err: %v", err)
+	}
+	if len(indexes.FilterIndex) != 2 || len(indexes.VectorIndex) != 1 || len(indexes.SparseVectorIndex) != 1 {
+		t.Fatalf("unexpected indexes: %+v", indexes)
+	}
+	params, ok := indexes.VectorIndex[0].Params.(*tcvectordb.HNSWParam)
+	if !ok || params.M != 16 || params.EfConstruction != 200 {
+		t.Fatalf("unexpected vector index params: %+v", indexes.VectorIndex[0].Params)
+	}
+	if indexes.FilterIndex[1].ElemType != tcvectordb.String {
+		t.Fatalf("unexpected elem type: %v", indexes.FilterIndex[1].ElemType)
+	}
+	if s.TtlConfig == nil || !s.TtlConfig.Enable || s.TtlConfig.TimeField != "expire_at" {
+		t.Fatalf("unexpected ttl config: %+v", s.TtlConfig)
+	}
+}
+
+func TestCollectionSpecInvalid(t *testing.T) {
+	var s spec.CollectionSpec
+	data := strings.Replace(collectionSpecYaml, "M: 16", "M: 128", 1)
+	if err := spec.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatalf("unmarshal spec failed. err: %v", err)
+	}
+	if err := s.Validate(); err == nil {
+		t.Fatalf("expected an error for M out of range")
+	}
+	if err := spec.Unmarshal([]byte("name: [unclosed"), &s); err == nil {
+		t.Fatalf("expected an error for invalid yaml")
+	}
+}
+
+func TestCollectionSpecRoundTrip(t *testing.T) {
+	var s spec.CollectionSpec
+	if err := spec.Unmarshal([]byte(collectionSpecYaml), &s); err != nil {
+		t.Fatalf("unmarshal spec failed. err: %v", err)
+	}
+	indexes, err := s.ToIndexes()
+	if err != nil {
+		t.Fatalf("convert indexes failed. err: %v", err)
+	}
+	coll := &tcvectordb.Collection{
+		DatabaseName:   s.Database,
+		CollectionName: s.Name,
+		ShardNum:       s.ShardNum,
+		ReplicasNum:    s.ReplicaNum,
+		Indexes:        indexes,
+		TtlConfig:      s.TtlConfig,
+	}
+	described := spec.FromCollection(coll)
+	if !reflect.DeepEqual(described.Indexes, s.Indexes) {
+		t.Fatalf("indexes changed after round trip: %+v", described.Indexes)
+	}
+
+	data, err := spec.MarshalYAML(described)
+	if err != nil {
+		t.Fatalf("marshal spec failed. err: %v", err)
+	}
+	var again spec.CollectionSpec
+	if err := spec.Unmarshal(data, &again); err != nil {
+		t.Fatalf("unmarshal marshaled spec failed. err: %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(again, described) {
+		t.Fatalf("spec changed after yaml round trip:\n%s", data)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2023 Tencent Cloud.
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the vectordb-sdk-java), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is furnished
+// to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A
+// PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package test
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/tencent/vectordatabase-sdk-go/tcvdbtext/tokenizer"
+	"github.com/tencent/vectordatabase-sdk-go/tcvectordb/splitter"
+)
+
+// checkChunks checks the chunks are within the size, and their offsets are in the text.
+func checkChunks(t *testing.T, text string, chunks []splitter.Chunk, size int, length splitter.LengthFunc) {
+	t.Helper()
+	if len(chunks) == 0 {
+		t.Fatal("no chunks")
+	}
+	for i, chunk := range chunks {
+		if chunk.Index != i || text[chunk.StartPos:chunk.EndPos] != chunk.Text {
+			t.Fatalf("chunk %v has wrong index or offsets: %+v", i, chunk)
+		}
+		if size > 0 && length(chunk.Text) > size {
+			t.Fatalf("chunk %v exceeds the size: %q", i, chunk.Text)
+		}
+	}
+}
+
+func TestRecursiveCharacterSplitter(t *testing.T) {
+	text := "向量数据库是一款全托管的自研企业级分布式数据库服务。它专用于存储、检索、分析多维向量数据。\n\n" +
+		"The database supports many index types and similarity metrics. It stores a billion vectors per " +
+		"collection, and answers millions of queries per second with a latency of milliseconds.\n" +
+		"Averyveryveryveryveryveryveryverylongwordwithoutanyspaces."
+	s := &splitter.RecursiveCharacterSplitter{ChunkSize: 40, ChunkOverlap: 15}
+	chunks := s.Split(text)
+	checkChunks(t, text, chunks, 40, utf8.RuneCountInString)
+	overlapped := false
+	for i := 1; i < len(chunks); i++ {
+		if chunks[i].StartPos < chunks[i-1].EndPos {
+			overlapped = true
+		}
+		if chunks[i].StartPos < chunks[i-1].StartPos {
+			t.Fatalf("chunks are out of order: %+v", chunks)
+		}
+	}
+	if !overlapped {
+		t.Fatal("no chunks overlap")
+	}
+	if chunks[0].Text != "向量数据库是一款全托管的自研企业级分布式数据库服务。" {
+		t.Fatalf("unexpected first chunk: %q", chunks[0].Text)
+	}
+	joined := strings.Join(strings.Fields(text), "")
+	for _, chunk := range chunks {
+		if !strings.Contains(joined, strings.Join(strings.Fields(chunk.Text), "")) {
+			t.Fatalf("unexpected chunk: %q", chunk.Text)
+		}
+	}
+
+	whole := (&splitter.RecursiveCharacterSplitter{}).Split("  short text \n")
+	if len(whole) != 1 || whole[0].Text != "short text" || whole[0].StartPos != 2 {
+		t.Fatalf("unexpected chunks of a short text: %+v", whole)
+	}
+}
+
+func TestRegexSplitter(t *testing.T) {
+	text := "序言。\n第一章 总则\n第一条 内容。\n第二章 细则\n第二条 内容。第三条 内容。"
+	s := &splitter.RegexSplitter{Pattern: regexp.MustCompile(`第.章`), ChunkSize: 16}
+	chunks := s.Split(text)
+	checkChunks(t, text, chunks, 16, utf8.RuneCountInString)
+	var texts []string
+	for _, chunk := range chunks {
+		texts = append(texts, chunk.Text)
+	}
+	want := []string{"序言。", "第一章 总则\n第一条 内容。", "第二章 细则", "第二条 内容。第三条 内容。"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Fatalf("got chunks %q, want %q", texts, want)
+	}
+}
+
+func TestMarkdownHeaderSplitter(t *testing.T) {
+	text := "Intro.\n\n# Guide\n\nGuide text.\n\n## Install\n\nRun it.\n\n```sh\n# not a heading\n```\n\n" +
+		"### Linux\n\nUse apt.\n\n## Usage\n\nCall it.\n"
+	chunks := (&splitter.MarkdownHeaderSplitter{}).Split(text)
+	checkChunks(t, text, chunks, 0, nil)
+	type section struct {
+		title   string
+		parents []string
+		prefix  string
+	}
+	want := []section{
+		{"", nil, "Intro."},
+		{"Guide", nil, "# Guide"},
+		{"Install", []string{"Guide"}, "## Install"},
+		{"Linux", []string{"Guide", "Install"}, "### Linux"},
+		{"Usage", []string{"Guide"}, "## Usage"},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %v chunks, want %v: %+v", len(chunks), len(want), chunks)
+	}
+	for i, w := range want {
+		c := chunks[i]
+		if c.ParagraphTitle != w.title || !reflect.DeepEqual(c.AllParentParagraphTitles, w.parents) ||
+			!strings.HasPrefix(c.Text, w.prefix) {
+			t.Fatalf("chunk %v is %+v, want %+v", i, c, w)
+		}
+	}
+	if !strings.Contains(chunks[2].Text, "# not a heading") {
+		t.Fatalf("code block was split: %q", chunks[2].Text)
+	}
+
+	stripped := (&splitter.MarkdownHeaderSplitter{
+		MaxLevel:      2,
+		StripHeadings: true,
+		Splitter:      &splitter.RecursiveCharacterSplitter{ChunkSize: 10},
+	}).Split(text)
+	checkChunks(t, text, stripped, 0, nil)
+	for _, c := range stripped {
+		if c.ParagraphTitle == "Linux" || strings.HasPrefix(c.Text, "## ") {
+			t.Fatalf("unexpected chunk: %+v", c)
+		}
+	}
+}
+
+func TestHTMLHeaderSplitter(t *testing.T) {
+	document := `<html><head><title>T</title><style>p {}</style></head><body>
+<h1>Guide</h1><p>Guide <b>text</b>.</p>
+<h2>Install</h2><p>Run</p><script>var x;</script><ul><li>one</li><li>two</li></ul>
+<h2>Usage</h2><p>Call it.</p></body></html>`
+	text := splitter.ExtractHTMLText(document)
+	chunks := (&splitter.HTMLHeaderSplitter{StripHeadings: true}).Split(document)
+	checkChunks(t, text, chunks, 0, nil)
+	want := [][]string{{"Guide", "Guide text."}, {"Install", "Run\none\ntwo"}, {"Usage", "Call it."}}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %v chunks, want %v: %+v", len(chunks), len(want), chunks)
+	}
+	for i, w := range want {
+		if chunks[i].ParagraphTitle != w[0] || chunks[i].Text != w[1] {
+			t.Fatalf("chunk %v is %+v, want %q", i, chunks[i], w)
+		}
+	}
+	if !reflect.DeepEqual(chunks[1].AllParentParagraphTitles, []string{"Guide"}) {
+		t.Fatalf("unexpected parent titles: %v", chunks[1].AllParentParagraphTitles)
+	}
+}
+
+// whitespaceTokenizer splits the sentences by the spaces.
+type whitespaceTokenizer struct {
+	tokenizer.Tokenizer
+}
+
+func (whitespaceTokenizer) Tokenize(sentence string) []string {
+	return strings.Fields(sentence)
+}
+
+func TestTokenSplitterDocuments(t *testing.T) {
+	text := strings.Repeat("one two three four five. ", 20)
+	tok := whitespaceTokenizer{}
+	chunks := splitter.NewTokenSplitter(tok, 12, 4).Split(text)
+	checkChunks(t, text, chunks, 12, splitter.TokenLength(tok))
+
+	chunks = splitter.WithMetadata(chunks, map[string]interface{}{"source": "a.txt"})
+	docs := splitter.Documents(chunks, "a")
+	if len(docs) != len(chunks) || docs[1].Id != "a-1" {
+		t.Fatalf("unexpected documents: %+v", docs)
+	}
+	fields := docs[1].Fields
+	if fields[splitter.FieldText].String() != chunks[1].Text || fields["source"].String() != "a.txt" ||
+		fields[splitter.FieldStartPos].Val != uint64(chunks[1].StartPos) {
+		t.Fatalf("unexpected document fields: %+v", fields)
+	}
+	if _, ok := fields[splitter.FieldParagraphTitle]; ok {
+		t.Fatal("empty paragraph title is set")
+	}
+}